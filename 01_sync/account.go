@@ -0,0 +1,142 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrInsufficientFunds indica que un retiro supera el saldo disponible.
+var ErrInsufficientFunds = errors.New("fondos insuficientes")
+
+// ErrInvalidAmount indica que el monto de una operación no es positivo.
+var ErrInvalidAmount = errors.New("el monto debe ser positivo")
+
+// BankAccount es el comportamiento común de todas las implementaciones de cuenta del módulo.
+// Cada una resuelve la sincronización de forma distinta, pero deben comportarse igual.
+type BankAccount interface {
+	Deposit(amount int) error
+	Withdraw(amount int) error
+	Balance() int
+}
+
+// Account es una cuenta bancaria segura para uso concurrente.
+// El saldo solo se accede a través de sus métodos, que se sincronizan con un RWMutex:
+// muchos pueden consultar Balance a la vez, pero Deposit y Withdraw son exclusivos.
+type Account struct {
+	balance int
+	initial int
+	journal *Journal // nil si la cuenta no registra sus operaciones
+	mu      sync.RWMutex
+}
+
+// AccountOption configura una Account.
+type AccountOption func(*Account)
+
+// WithJournal hace que la cuenta registre cada Deposit y Withdraw en un Journal.
+func WithJournal() AccountOption {
+	return func(a *Account) {
+		a.journal = &Journal{}
+	}
+}
+
+// NewAccount crea una cuenta con un saldo inicial.
+func NewAccount(initial int, opts ...AccountOption) *Account {
+	a := &Account{balance: initial, initial: initial}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Deposit agrega amount al saldo.
+func (a *Account) Deposit(amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("❌ depósito de %d: %w", amount, ErrInvalidAmount)
+	}
+	a.mu.Lock() // Bloquea lecturas y escrituras de otras goroutines
+	defer a.mu.Unlock()
+	a.balance += amount
+	a.record(OperationDeposit, amount, nil)
+	return nil
+}
+
+// Withdraw retira amount del saldo. Falla con ErrInsufficientFunds si no alcanza.
+// La comprobación y el descuento ocurren bajo el mismo lock, así que dos retiros
+// concurrentes nunca dejan el saldo en negativo.
+func (a *Account) Withdraw(amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("❌ retiro de %d: %w", amount, ErrInvalidAmount)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if amount > a.balance {
+		err := fmt.Errorf("❌ retiro de %d con saldo %d: %w", amount, a.balance, ErrInsufficientFunds)
+		a.record(OperationWithdraw, amount, err)
+		return err
+	}
+	a.balance -= amount
+	a.record(OperationWithdraw, amount, nil)
+	return nil
+}
+
+// Balance retorna el saldo actual.
+func (a *Account) Balance() int {
+	a.mu.RLock() // Solo bloquea escrituras: otras lecturas pueden ocurrir a la vez
+	defer a.mu.RUnlock()
+	return a.balance
+}
+
+// History retorna las operaciones registradas, o nil si la cuenta no usa WithJournal.
+func (a *Account) History() []Operation {
+	if a.journal == nil {
+		return nil
+	}
+	return a.journal.History()
+}
+
+// Verify reproduce el historial y comprueba que explica el saldo actual.
+// Toma el lock de lectura para que no se cuelen operaciones entre leer el historial y el saldo.
+func (a *Account) Verify() error {
+	if a.journal == nil {
+		return errors.New("❌ la cuenta no registra sus operaciones (usar WithJournal)")
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return Replay(a.initial, a.journal.History(), a.balance)
+}
+
+// record registra una operación si la cuenta tiene Journal. Se llama con a.mu tomado,
+// así que el orden del registro es el mismo orden en que se aplicaron las operaciones.
+func (a *Account) record(kind OperationKind, amount int, err error) {
+	if a.journal == nil {
+		return
+	}
+	a.journal.record(Operation{
+		Kind:        kind,
+		Amount:      amount,
+		Balance:     a.balance,
+		Err:         err,
+		Time:        time.Now(),
+		GoroutineID: goroutineID(),
+	})
+}
+
+// accountVariants son las implementaciones de BankAccount que se comparan en las demostraciones.
+var accountVariants = []struct {
+	name       string
+	newAccount func(initial int) BankAccount
+}{
+	{"RWMutex", func(initial int) BankAccount { return NewAccount(initial) }},
+	{"Atomic", func(initial int) BankAccount { return NewAtomicAccount(initial) }},
+	{"Channel", func(initial int) BankAccount { return NewChannelAccount(initial) }},
+}
+
+// closeAccount detiene las implementaciones que tienen goroutines propias (ChannelAccount).
+func closeAccount(account BankAccount) {
+	if closer, ok := account.(io.Closer); ok {
+		closer.Close()
+	}
+}
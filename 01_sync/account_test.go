@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// mutexAccount es la versión con un sync.Mutex simple, solo para comparar: a diferencia
+// de Account, las lecturas también son exclusivas.
+type mutexAccount struct {
+	balance int
+	mu      sync.Mutex
+}
+
+func (a *mutexAccount) Deposit(amount int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.balance += amount
+	return nil
+}
+
+func (a *mutexAccount) Withdraw(amount int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if amount > a.balance {
+		return ErrInsufficientFunds
+	}
+	a.balance -= amount
+	return nil
+}
+
+func (a *mutexAccount) Balance() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.balance
+}
+
+// operation es un depósito (amount > 0 en deposit) o un retiro sobre una cuenta.
+type operation struct {
+	deposit bool
+	amount  int
+	wantErr error
+}
+
+func TestAccounts(t *testing.T) {
+	tests := []struct {
+		name        string
+		initial     int
+		ops         []operation
+		wantBalance int
+	}{
+		{"depósito", 0, []operation{{true, 50, nil}}, 50},
+		{"retiro", 100, []operation{{false, 30, nil}}, 70},
+		{"retiro de todo el saldo", 40, []operation{{false, 40, nil}}, 0},
+		{"fondos insuficientes", 10, []operation{{false, 11, ErrInsufficientFunds}}, 10},
+		{"depósito negativo", 10, []operation{{true, -5, ErrInvalidAmount}}, 10},
+		{"depósito de cero", 10, []operation{{true, 0, ErrInvalidAmount}}, 10},
+		{"retiro negativo", 10, []operation{{false, -5, ErrInvalidAmount}}, 10},
+		{"secuencia", 0, []operation{{true, 20, nil}, {false, 25, ErrInsufficientFunds}, {true, 10, nil}, {false, 25, nil}}, 5},
+	}
+	for _, variant := range accountVariants {
+		for _, tt := range tests {
+			t.Run(variant.name+"/"+tt.name, func(t *testing.T) {
+				account := variant.newAccount(tt.initial)
+				defer closeAccount(account)
+				for _, op := range tt.ops {
+					var err error
+					if op.deposit {
+						err = account.Deposit(op.amount)
+					} else {
+						err = account.Withdraw(op.amount)
+					}
+					if !errors.Is(err, op.wantErr) {
+						t.Errorf("operación %+v: err = %v, quiero %v", op, err, op.wantErr)
+					}
+				}
+				if got := account.Balance(); got != tt.wantBalance {
+					t.Errorf("Balance = %d, quiero %d", got, tt.wantBalance)
+				}
+			})
+		}
+	}
+}
+
+func TestAccountsConcurrent(t *testing.T) {
+	for _, variant := range accountVariants {
+		t.Run(variant.name, func(t *testing.T) {
+			account := variant.newAccount(100)
+			defer closeAccount(account)
+
+			// 50 depósitos de 2 y 30 retiros de 10: el saldo nunca alcanza para todos los
+			// retiros, así que algunos fallan, pero el total siempre cuadra
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			withdrawn := 0
+			for range 50 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := account.Deposit(2); err != nil {
+						t.Errorf("Deposit: %v", err)
+					}
+				}()
+			}
+			for range 30 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					err := account.Withdraw(10)
+					switch {
+					case err == nil:
+						mu.Lock()
+						withdrawn += 10
+						mu.Unlock()
+					case !errors.Is(err, ErrInsufficientFunds):
+						t.Errorf("Withdraw: %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got, want := account.Balance(), 100+50*2-withdrawn; got != want || got < 0 {
+				t.Errorf("Balance = %d, quiero %d (retirado: %d)", got, want, withdrawn)
+			}
+		})
+	}
+}
+
+// BenchmarkAccounts mide cada implementación con distintas proporciones de lecturas y
+// escrituras. RWMutex gana terreno cuanto más lecturas hay (y más CPUs), las operaciones
+// atómicas evitan los locks por completo, y los canales pagan el costo de pasar cada
+// operación a otra goroutine.
+func BenchmarkAccounts(b *testing.B) {
+	variants := append([]struct {
+		name       string
+		newAccount func(initial int) BankAccount
+	}{
+		{"Mutex", func(initial int) BankAccount { return &mutexAccount{balance: initial} }},
+	}, accountVariants...)
+
+	for _, readPercent := range []int{90, 50, 10} {
+		for _, variant := range variants {
+			b.Run(fmt.Sprintf("%d%%lecturas/%s", readPercent, variant.name), func(b *testing.B) {
+				account := variant.newAccount(0)
+				defer closeAccount(account)
+				b.SetParallelism(8) // 8 goroutines por CPU
+				b.RunParallel(func(pb *testing.PB) {
+					for i := 0; pb.Next(); i++ {
+						if i%100 < readPercent {
+							account.Balance()
+						} else {
+							account.Deposit(1)
+						}
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// AtomicAccount es una cuenta sincronizada con operaciones atómicas en lugar de locks.
+// Deposit y Balance son una sola instrucción atómica; Withdraw necesita comprobar el
+// saldo antes de descontar, así que usa un ciclo de CompareAndSwap.
+type AtomicAccount struct {
+	balance atomic.Int64
+}
+
+// NewAtomicAccount crea una cuenta atómica con un saldo inicial.
+func NewAtomicAccount(initial int) *AtomicAccount {
+	a := &AtomicAccount{}
+	a.balance.Store(int64(initial))
+	return a
+}
+
+// Deposit agrega amount al saldo.
+func (a *AtomicAccount) Deposit(amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("❌ depósito de %d: %w", amount, ErrInvalidAmount)
+	}
+	a.balance.Add(int64(amount))
+	return nil
+}
+
+// Withdraw retira amount del saldo. Falla con ErrInsufficientFunds si no alcanza.
+// Si otra goroutine cambió el saldo entre la lectura y el CompareAndSwap, se reintenta
+// con el saldo nuevo: así la comprobación y el descuento siguen siendo atómicos.
+func (a *AtomicAccount) Withdraw(amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("❌ retiro de %d: %w", amount, ErrInvalidAmount)
+	}
+	for {
+		current := a.balance.Load()
+		if int64(amount) > current {
+			return fmt.Errorf("❌ retiro de %d con saldo %d: %w", amount, current, ErrInsufficientFunds)
+		}
+		if a.balance.CompareAndSwap(current, current-int64(amount)) {
+			return nil
+		}
+	}
+}
+
+// Balance retorna el saldo actual.
+func (a *AtomicAccount) Balance() int {
+	return int(a.balance.Load())
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BoundedBuffer es una cola de capacidad fija sincronizada con variables de condición.
+// Put espera mientras el buffer está lleno y Get espera mientras está vacío; cada uno
+// despierta al otro con Signal. Close usa Broadcast para despertar a todos los que esperan.
+type BoundedBuffer[T any] struct {
+	items    []T
+	capacity int
+	closed   bool
+	mu       sync.Mutex
+	notFull  *sync.Cond // Señalada cuando se libera un espacio
+	notEmpty *sync.Cond // Señalada cuando llega un elemento
+}
+
+// NewBoundedBuffer crea un buffer con la capacidad indicada. Hace panic si capacity < 1:
+// con capacidad 0, Put esperaría para siempre un espacio que nunca se libera.
+func NewBoundedBuffer[T any](capacity int) *BoundedBuffer[T] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("NewBoundedBuffer: la capacidad debe ser al menos 1, se recibió %d", capacity))
+	}
+	b := &BoundedBuffer[T]{capacity: capacity}
+	b.notFull = sync.NewCond(&b.mu)
+	b.notEmpty = sync.NewCond(&b.mu)
+	return b
+}
+
+// Put agrega item, esperando si el buffer está lleno. Retorna false si el buffer se cerró.
+func (b *BoundedBuffer[T]) Put(item T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// Wait libera el lock mientras duerme y lo vuelve a tomar al despertar. La condición
+	// se revisa en un ciclo porque otra goroutine pudo ocupar el espacio antes.
+	for len(b.items) == b.capacity && !b.closed {
+		b.notFull.Wait()
+	}
+	if b.closed {
+		return false
+	}
+	b.items = append(b.items, item)
+	b.notEmpty.Signal() // Despierta a un consumidor
+	return true
+}
+
+// Get saca el elemento más antiguo, esperando si el buffer está vacío.
+// Retorna false cuando el buffer está cerrado y ya no quedan elementos.
+func (b *BoundedBuffer[T]) Get() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for len(b.items) == 0 && !b.closed {
+		b.notEmpty.Wait()
+	}
+	if len(b.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	item := b.items[0]
+	b.items = b.items[1:]
+	b.notFull.Signal() // Despierta a un productor
+	return item, true
+}
+
+// Close cierra el buffer: los consumidores vacían lo que queda y los productores
+// bloqueados retornan false.
+func (b *BoundedBuffer[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.notFull.Broadcast() // Despierta a todos, no solo a uno
+	b.notEmpty.Broadcast()
+}
+
+// producerConsumerConfig configura la demostración de productores y consumidores.
+type producerConsumerConfig struct {
+	producers, consumers int
+	bufferSize           int
+	itemsPerProducer     int
+}
+
+// demonstrateProducerConsumer reparte el trabajo de varios productores entre varios
+// consumidores a través de un BoundedBuffer pequeño, y comprueba que se consumió cada
+// elemento exactamente una vez.
+func demonstrateProducerConsumer(config producerConsumerConfig) {
+	fmt.Printf("\n🏭 Productores/consumidores con sync.Cond (%d productores, %d consumidores, buffer de %d):\n",
+		config.producers, config.consumers, config.bufferSize)
+	buffer := NewBoundedBuffer[int](config.bufferSize)
+
+	var producers sync.WaitGroup
+	for p := range config.producers {
+		producers.Add(1)
+		go func() {
+			defer producers.Done()
+			for i := range config.itemsPerProducer {
+				buffer.Put(p*config.itemsPerProducer + i)
+			}
+		}()
+	}
+
+	var consumers sync.WaitGroup
+	consumed := make([]int, config.consumers) // Cada consumidor escribe solo su posición
+	sum := make([]int, config.consumers)
+	for c := range config.consumers {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				item, ok := buffer.Get()
+				if !ok {
+					return
+				}
+				time.Sleep(time.Millisecond) // Simula procesar el elemento
+				consumed[c]++
+				sum[c] += item
+			}
+		}()
+	}
+
+	producers.Wait()
+	buffer.Close() // Ya no llegan más elementos: los consumidores terminan al vaciar el buffer
+	consumers.Wait()
+
+	total, totalSum := 0, 0
+	for c := range config.consumers {
+		fmt.Printf("   👷 Consumidor %d procesó %d elementos\n", c+1, consumed[c])
+		total += consumed[c]
+		totalSum += sum[c]
+	}
+	n := config.producers * config.itemsPerProducer
+	if total != n || totalSum != n*(n-1)/2 {
+		fmt.Printf("❌ Se esperaban %d elementos, se consumieron %d\n", n, total)
+		return
+	}
+	fmt.Printf("✅ Los %d elementos se consumieron exactamente una vez\n", total)
+}
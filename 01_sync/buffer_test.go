@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestNewBoundedBufferInvalidCapacity(t *testing.T) {
+	for _, capacity := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewBoundedBuffer(%d) no hizo panic", capacity)
+				}
+			}()
+			NewBoundedBuffer[int](capacity)
+		}()
+	}
+}
+
+func TestBoundedBuffer(t *testing.T) {
+	buffer := NewBoundedBuffer[int](2)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for n := range 5 { // Más elementos que la capacidad: Put espera a que Get libere espacio
+			if !buffer.Put(n) {
+				t.Errorf("Put(%d) = false con el buffer abierto", n)
+			}
+		}
+		buffer.Close()
+	}()
+
+	for want := range 5 {
+		if got, ok := buffer.Get(); !ok || got != want {
+			t.Errorf("Get = (%d, %t), quiero (%d, true)", got, ok, want)
+		}
+	}
+	<-done
+	if _, ok := buffer.Get(); ok {
+		t.Error("Get sobre un buffer cerrado y vacío = true, quiero false")
+	}
+	if buffer.Put(9) {
+		t.Error("Put sobre un buffer cerrado = true, quiero false")
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrAccountClosed indica que se operó sobre una ChannelAccount ya cerrada.
+var ErrAccountClosed = errors.New("la cuenta está cerrada")
+
+// withdrawal es una solicitud de retiro: el dueño del saldo responde por result.
+type withdrawal struct {
+	amount int
+	result chan error
+}
+
+// ChannelAccount es una cuenta sin locks: "no comuniques compartiendo memoria, comparte
+// memoria comunicando". Una sola goroutine es dueña del saldo y atiende las solicitudes
+// que le llegan por canales, una a la vez, así que nunca hay acceso concurrente al saldo.
+type ChannelAccount struct {
+	deposits    chan int
+	withdrawals chan withdrawal
+	balances    chan chan int
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+// NewChannelAccount crea la cuenta e inicia la goroutine dueña del saldo.
+// Hay que llamar a Close para detenerla.
+func NewChannelAccount(initial int) *ChannelAccount {
+	a := &ChannelAccount{
+		deposits:    make(chan int),
+		withdrawals: make(chan withdrawal),
+		balances:    make(chan chan int),
+		done:        make(chan struct{}),
+	}
+	go a.run(initial)
+	return a
+}
+
+// run es la goroutine dueña del saldo: el saldo es una variable local que nadie más ve.
+func (a *ChannelAccount) run(balance int) {
+	for {
+		select {
+		case amount := <-a.deposits:
+			balance += amount
+		case w := <-a.withdrawals:
+			if w.amount > balance {
+				w.result <- fmt.Errorf("❌ retiro de %d con saldo %d: %w", w.amount, balance, ErrInsufficientFunds)
+				continue
+			}
+			balance -= w.amount
+			w.result <- nil
+		case reply := <-a.balances:
+			reply <- balance
+		case <-a.done:
+			return
+		}
+	}
+}
+
+// Deposit agrega amount al saldo. Como el canal no tiene buffer, al retornar el
+// depósito ya fue recibido y cualquier operación posterior lo verá aplicado.
+func (a *ChannelAccount) Deposit(amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("❌ depósito de %d: %w", amount, ErrInvalidAmount)
+	}
+	select {
+	case a.deposits <- amount:
+		return nil
+	case <-a.done:
+		return ErrAccountClosed
+	}
+}
+
+// Withdraw retira amount del saldo. Falla con ErrInsufficientFunds si no alcanza.
+func (a *ChannelAccount) Withdraw(amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("❌ retiro de %d: %w", amount, ErrInvalidAmount)
+	}
+	w := withdrawal{amount: amount, result: make(chan error, 1)}
+	select {
+	case a.withdrawals <- w:
+		return <-w.result
+	case <-a.done:
+		return ErrAccountClosed
+	}
+}
+
+// Balance retorna el saldo actual, o 0 si la cuenta ya está cerrada.
+func (a *ChannelAccount) Balance() int {
+	reply := make(chan int, 1)
+	select {
+	case a.balances <- reply:
+		return <-reply
+	case <-a.done:
+		return 0
+	}
+}
+
+// Close detiene la goroutine dueña del saldo. Se puede llamar más de una vez.
+func (a *ChannelAccount) Close() error {
+	a.closeOnce.Do(func() { close(a.done) })
+	return nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config es la configuración de la aplicación. Una vez publicada nunca se modifica:
+// cada cambio crea una copia nueva (copy-on-write).
+type Config struct {
+	Version        int
+	MaxConnections int
+	Timeout        time.Duration
+	Features       map[string]bool
+}
+
+// clone copia la configuración, incluido el map, para poder modificar la copia
+// sin afectar a quienes todavía leen la original.
+func (c *Config) clone() *Config {
+	copied := *c
+	copied.Features = maps.Clone(c.Features)
+	return &copied
+}
+
+// ConfigStore publica la configuración actual con un atomic.Pointer. Leer es una sola
+// carga atómica, sin locks: ideal para datos que se leen constantemente y cambian poco.
+type ConfigStore struct {
+	current atomic.Pointer[Config]
+}
+
+// NewConfigStore crea el store con una configuración inicial.
+func NewConfigStore(initial *Config) *ConfigStore {
+	s := &ConfigStore{}
+	s.current.Store(initial)
+	return s
+}
+
+// Load retorna la configuración actual. Es una foto consistente: aunque otro la
+// reemplace mientras se usa, esta no cambia. No se debe modificar.
+func (s *ConfigStore) Load() *Config {
+	return s.current.Load()
+}
+
+// Update aplica change sobre una copia de la configuración actual y la publica
+// reemplazando la anterior de una vez. Si otra actualización ganó la carrera,
+// se repite sobre la versión nueva para no perder ninguno de los dos cambios.
+func (s *ConfigStore) Update(change func(*Config)) *Config {
+	for {
+		old := s.current.Load()
+		updated := old.clone()
+		change(updated)
+		updated.Version = old.Version + 1
+		if s.current.CompareAndSwap(old, updated) {
+			return updated
+		}
+	}
+}
+
+// demonstrateCopyOnWrite lanza lectores que revisan la configuración continuamente
+// mientras otra goroutine la actualiza. Cada lector ve siempre una foto coherente:
+// MaxConnections y Timeout cambian juntos y nunca ve una mitad de cada versión.
+func demonstrateCopyOnWrite() {
+	fmt.Println("\n📸 Configuración copy-on-write con atomic.Pointer:")
+	store := NewConfigStore(&Config{MaxConnections: 10, Timeout: 10 * time.Second, Features: map[string]bool{"beta": false}})
+
+	var wg sync.WaitGroup
+	var reads, inconsistent atomic.Int64
+	stop := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				config := store.Load()
+				// Invariante de cada versión: Timeout = MaxConnections segundos
+				if config.Timeout != time.Duration(config.MaxConnections)*time.Second {
+					inconsistent.Add(1)
+				}
+				reads.Add(1)
+			}
+		}()
+	}
+
+	for i := range 100 {
+		store.Update(func(c *Config) {
+			c.MaxConnections = 10 + i
+			c.Timeout = time.Duration(c.MaxConnections) * time.Second
+			c.Features["beta"] = i%2 == 0
+		})
+		time.Sleep(time.Millisecond)
+	}
+	close(stop)
+	wg.Wait()
+
+	config := store.Load()
+	fmt.Printf("✅ Versión final %d (MaxConnections %d, Timeout %v, beta %t)\n",
+		config.Version, config.MaxConnections, config.Timeout, config.Features["beta"])
+	fmt.Printf("✅ %d lecturas, %d fotos inconsistentes\n", reads.Load(), inconsistent.Load())
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+// rwConfigStore es el enfoque con RWMutex, solo para comparar: los lectores toman el
+// lock de lectura y las actualizaciones modifican la configuración en el lugar.
+type rwConfigStore struct {
+	mu     sync.RWMutex
+	config Config
+}
+
+// MaxConnections lee un campo bajo el lock de lectura. Cada campo que se lea por
+// separado puede venir de una versión distinta; leer varios juntos requiere otro método.
+func (s *rwConfigStore) MaxConnections() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config.MaxConnections
+}
+
+func (s *rwConfigStore) SetMaxConnections(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config.MaxConnections = n
+	s.config.Version++
+}
+
+// BenchmarkConfigReads compara leer la configuración con atomic.Pointer contra hacerlo
+// con un RWMutex, con una escritura cada 1.000 operaciones.
+func BenchmarkConfigReads(b *testing.B) {
+	b.Run("atomic.Pointer", func(b *testing.B) {
+		store := NewConfigStore(&Config{MaxConnections: 10})
+		b.SetParallelism(8)
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				if i%1000 == 0 {
+					store.Update(func(c *Config) { c.MaxConnections++ })
+				} else {
+					_ = store.Load().MaxConnections
+				}
+			}
+		})
+	})
+	b.Run("RWMutex", func(b *testing.B) {
+		store := &rwConfigStore{config: Config{MaxConnections: 10}}
+		b.SetParallelism(8)
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				if i%1000 == 0 {
+					store.SetMaxConnections(i)
+				} else {
+					_ = store.MaxConnections()
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// fetchService simula consultar un servicio externo que tarda delay. Si fails es true,
+// falla al terminar; si el contexto se cancela antes, abandona la consulta.
+func fetchService(ctx context.Context, name string, delay time.Duration, fails bool) error {
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+		fmt.Printf("   🛑 %s canceló su consulta: %v\n", name, context.Cause(ctx))
+		return ctx.Err()
+	}
+	if fails {
+		return fmt.Errorf("❌ %s no respondió correctamente", name)
+	}
+	fmt.Printf("   ✅ %s respondió en %v\n", name, delay)
+	return nil
+}
+
+// demonstrateErrGroup consulta varios servicios en paralelo con syncutil.Group.
+// El primer fallo cancela a los que siguen esperando, así que la operación completa
+// termina en cuanto se sabe que no puede salir bien.
+func demonstrateErrGroup() {
+	fmt.Println("\n🧑‍🤝‍🧑 Tareas coordinadas: el primer error cancela las demás")
+	services := []struct {
+		name  string
+		delay time.Duration
+		fails bool
+	}{
+		{"usuarios", 50 * time.Millisecond, false},
+		{"pagos", 100 * time.Millisecond, true},
+		{"inventario", time.Second, false},
+		{"envíos", 2 * time.Second, false},
+	}
+
+	start := time.Now()
+	group, ctx := syncutil.WithContext(context.Background())
+	for _, service := range services {
+		group.Go(func() error {
+			return fetchService(ctx, service.name, service.delay, service.fails)
+		})
+	}
+	err := group.Wait()
+	fmt.Printf("⏱️ Terminó en %v en lugar de esperar %v\n", time.Since(start).Round(10*time.Millisecond), 2*time.Second)
+	if err != nil {
+		fmt.Println("📋 Errores:", err)
+	}
+
+	// Las tareas que no dependen del contexto terminan igual, y Wait reporta todos sus errores
+	group, _ = syncutil.WithContext(context.Background())
+	for _, field := range []string{"nombre", "correo", "teléfono"} {
+		group.Go(func() error {
+			return fmt.Errorf("❌ el campo %s está vacío", field)
+		})
+	}
+	if err := group.Wait(); err != nil {
+		fmt.Printf("📋 Validación del formulario:\n%v\n", err)
+	}
+}
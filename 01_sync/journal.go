@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// OperationKind identifica el tipo de una operación registrada en el Journal.
+type OperationKind string
+
+const (
+	OperationDeposit  OperationKind = "depósito"
+	OperationWithdraw OperationKind = "retiro"
+)
+
+// Operation es una entrada del Journal.
+type Operation struct {
+	Kind        OperationKind
+	Amount      int
+	Balance     int   // Saldo después de la operación
+	Err         error // Error de la operación; las fallidas no cambian el saldo
+	Time        time.Time
+	GoroutineID uint64
+}
+
+// Journal es un registro de operaciones seguro para uso concurrente y de solo agregar:
+// las entradas nunca se modifican ni se borran.
+type Journal struct {
+	mu         sync.Mutex
+	operations []Operation
+}
+
+// record agrega una operación al final del registro.
+func (j *Journal) record(op Operation) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.operations = append(j.operations, op)
+}
+
+// History retorna una copia de las operaciones en el orden en que se aplicaron.
+func (j *Journal) History() []Operation {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]Operation(nil), j.operations...)
+}
+
+// Replay aplica history sobre initial y comprueba que cada operación deja el saldo
+// que quedó registrado, que los retiros fallidos de verdad no tenían fondos, y que
+// el resultado coincide con final.
+func Replay(initial int, history []Operation, final int) error {
+	balance := initial
+	for i, op := range history {
+		switch {
+		case op.Err != nil && op.Kind == OperationWithdraw && op.Amount <= balance:
+			return fmt.Errorf("❌ operación %d: retiro de %d rechazado con saldo %d", i, op.Amount, balance)
+		case op.Err != nil:
+			// No cambió el saldo
+		case op.Kind == OperationDeposit:
+			balance += op.Amount
+		case op.Kind == OperationWithdraw:
+			balance -= op.Amount
+		}
+		if balance != op.Balance {
+			return fmt.Errorf("❌ operación %d (%s de %d): saldo esperado %d, registrado %d", i, op.Kind, op.Amount, balance, op.Balance)
+		}
+	}
+	if balance != final {
+		return fmt.Errorf("❌ el historial da un saldo de %d pero la cuenta tiene %d", balance, final)
+	}
+	return nil
+}
+
+// goroutineID obtiene el id de la goroutine actual leyendo la primera línea de su
+// stack ("goroutine 42 [running]:"). Go no lo expone a propósito para que no se use
+// como estado; aquí solo sirve para mostrar en el historial quién hizo cada operación.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	id, _ := strconv.ParseUint(string(buf[:bytes.IndexByte(buf, ' ')]), 10, 64)
+	return id
+}
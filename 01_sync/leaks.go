@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// checkGoroutineLeaks ejecuta fn y compara la cantidad de goroutines antes y después.
+// Da un margen de tiempo para que las goroutines que están terminando alcancen a salir.
+// Retorna cuántas goroutines quedaron vivas de más.
+func checkGoroutineLeaks(fn func()) int {
+	before := runtime.NumGoroutine()
+	fn()
+	return goroutinesAbove(before)
+}
+
+// goroutinesAbove espera hasta 500ms a que vuelva a haber baseline goroutines y
+// retorna cuántas hay de más.
+func goroutinesAbove(baseline int) int {
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	return runtime.NumGoroutine() - baseline
+}
+
+// demonstrateWaitTimeout muestra una goroutine que se queda bloqueada para siempre
+// (un canal que nadie cierra). Con wg.Wait el programa colgaría; con WaitTimeout se
+// detecta el problema y el chequeo de goroutines confirma la fuga.
+func demonstrateWaitTimeout() {
+	fmt.Println("\n⏲️ WaitGroup con timeout:")
+
+	leaked := checkGoroutineLeaks(func() {
+		var wg sync.WaitGroup
+		for range 3 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				time.Sleep(50 * time.Millisecond)
+			}()
+		}
+		fmt.Println("   ✅ Tareas correctas terminaron a tiempo:", syncutil.WaitTimeout(&wg, time.Second))
+	})
+	fmt.Println("   🔍 Goroutines filtradas:", leaked)
+
+	baseline := runtime.NumGoroutine()
+	stuck := make(chan struct{}) // Nadie lo cierra: la goroutine que lo lee nunca termina
+	leaked = checkGoroutineLeaks(func() {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-stuck
+		}()
+		fmt.Println("   ❌ Tarea bloqueada terminó a tiempo:", syncutil.WaitTimeout(&wg, 200*time.Millisecond))
+	})
+	// La tarea bloqueada y la goroutine auxiliar de WaitTimeout siguen vivas
+	fmt.Println("   🔍 Goroutines filtradas:", leaked)
+
+	close(stuck) // Libera la goroutine bloqueada para no dejarla viva
+	fmt.Println("   🧹 Tras cerrar el canal quedan filtradas:", goroutinesAbove(baseline))
+}
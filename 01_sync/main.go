@@ -12,48 +12,108 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"sync"
-)
-
-var balance int = 0
 
-// Deposit agrega una cantidad específica al balance de manera segura utilizando un mutex.
-// amount: La cantidad de dinero a depositar.
-// wg: Un puntero a un WaitGroup para sincronizar la finalización de la operación.
-// Esta función asegura que el acceso al balance sea seguro en un entorno concurrente,
-// bloqueando el mutex antes de modificar el balance y desbloqueándolo después.
-func Deposit(amount int, wg *sync.WaitGroup, mu *sync.RWMutex) {
-	defer wg.Done()
-	defer mu.Unlock() // Desbloquea el mutex después de modificar balance
-
-	mu.Lock() // Bloquea el mutex antes de modificar balance
-	balance += amount
-}
-
-func Balance(wg *sync.WaitGroup, mu *sync.RWMutex) int {
-	defer wg.Done()
-	defer mu.RUnlock() // Desbloquea el mutex después de leer balance
-
-	mu.RLock() // Bloquea el mutex para lectura
-	b := balance
-	fmt.Println("✅ Current balance is", b)
-	return balance
-}
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
 
 // 1 Deposit() -> Escribiendo (Posible condición de carrera)
 // N Balance() -> Muchos leyendo (Seguro)
+// Ejecutar con go run -race para comprobar que Account no tiene condiciones de carrera.
 func main() {
+	account := NewAccount(0)
 	var wg sync.WaitGroup
-	var mu sync.RWMutex
 
 	for i := 1; i <= 20; i++ {
 		wg.Add(1)
-		go Deposit(i, &wg, &mu)
+		go func() {
+			defer wg.Done()
+			account.Deposit(i)
+		}()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fmt.Println("✅ Current balance is", account.Balance())
+		}()
+	}
+	wg.Wait()
+	fmt.Println("Balance final:", account.Balance())
+
+	demonstrateWithdrawals()
+	demonstrateJournal()
+	demonstrateProducerConsumer(producerConsumerConfig{producers: 3, consumers: 2, bufferSize: 4, itemsPerProducer: 20})
+	demonstrateSemaphore()
+	demonstrateErrGroup()
+	demonstrateWaitTimeout()
+	demonstrateCopyOnWrite()
+}
+
+// demonstrateWithdrawals lanza más retiros de los que el saldo permite cubrir, sobre
+// cada implementación de cuenta (con locks, atómica y con canales). Los que no
+// alcanzan fallan con ErrInsufficientFunds y el saldo nunca queda negativo.
+func demonstrateWithdrawals() {
+	fmt.Println("\n💸 Retiros concurrentes sobre un saldo de 100:")
+	var rejected syncutil.SafeCounter[string] // Retiros rechazados por implementación
+	for _, variant := range accountVariants {
+		account := variant.newAccount(100)
+		var wg sync.WaitGroup
 
+		for range 15 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := account.Withdraw(10); errors.Is(err, ErrInsufficientFunds) {
+					rejected.Inc(variant.name)
+				}
+			}()
+		}
+		wg.Wait()
+
+		fmt.Printf("✅ %-8s retiros rechazados: %d, saldo final: %d\n", variant.name, rejected.Get(variant.name), account.Balance())
+		closeAccount(account)
+	}
+
+	if err := NewAccount(0).Deposit(-5); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// demonstrateJournal mezcla depósitos y retiros concurrentes sobre una cuenta con
+// Journal, muestra las primeras operaciones del historial y verifica que reproducirlo
+// da exactamente el saldo final.
+func demonstrateJournal() {
+	fmt.Println("\n📒 Historial de operaciones:")
+	account := NewAccount(20, WithJournal())
+	var wg sync.WaitGroup
+	for i := range 10 {
 		wg.Add(1)
-		go Balance(&wg, &mu)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				account.Deposit(5)
+			} else {
+				account.Withdraw(15)
+			}
+		}()
 	}
 	wg.Wait()
-	fmt.Println("Balance final:", balance)
+
+	for _, op := range account.History()[:5] {
+		status := "✅"
+		if op.Err != nil {
+			status = "❌"
+		}
+		fmt.Printf("   %s %s %-8s %3d -> saldo %3d (goroutine %d)\n",
+			status, op.Time.Format("15:04:05.000000"), op.Kind, op.Amount, op.Balance, op.GoroutineID)
+	}
+	fmt.Printf("   ... %d operaciones en total\n", len(account.History()))
+
+	if err := account.Verify(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("✅ El historial reproduce el saldo final:", account.Balance())
 }
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// maxConnections es el límite de "conexiones a la base de datos" simultáneas.
+const maxConnections = 3
+
+// demonstrateSemaphore lanza muchas consultas contra una base de datos que solo acepta
+// maxConnections conexiones. Los reportes pesados ocupan dos conexiones, las consultas
+// que no pueden esperar usan TryAcquire y las que tienen un plazo usan Acquire con timeout.
+func demonstrateSemaphore() {
+	fmt.Printf("\n🚦 Semáforo: máximo %d conexiones a la base de datos\n", maxConnections)
+	connections := syncutil.NewSemaphore(maxConnections)
+	var peak atomic.Int32
+
+	query := func(name string, weight int) {
+		if err := connections.Acquire(context.Background(), weight); err != nil {
+			fmt.Println("❌", err)
+			return
+		}
+		defer connections.Release(weight)
+
+		inUse := int32(connections.InUse())
+		for {
+			current := peak.Load()
+			if inUse <= current || peak.CompareAndSwap(current, inUse) {
+				break
+			}
+		}
+		fmt.Printf("   🗄️ %s usando %d conexión(es), %d/%d ocupadas\n", name, weight, inUse, maxConnections)
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%3 == 0 {
+				query(fmt.Sprintf("Reporte %d", i), 2)
+			} else {
+				query(fmt.Sprintf("Consulta %d", i), 1)
+			}
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond) // Deja que las consultas ocupen las conexiones
+	if !connections.TryAcquire(1) {
+		fmt.Println("   🙅 TryAcquire: no hay conexión disponible ahora mismo, la consulta urgente no espera")
+	} else {
+		connections.Release(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := connections.Acquire(ctx, 1); err != nil {
+		fmt.Println("   ⌛ Acquire con timeout:", err)
+	} else {
+		connections.Release(1)
+	}
+
+	wg.Wait()
+	fmt.Printf("✅ Máximo de conexiones simultáneas: %d (límite %d)\n", peak.Load(), maxConnections)
+}
@@ -0,0 +1,41 @@
+package main
+
+// Fibonacci calcula el n-ésimo número de Fibonacci de forma recursiva.
+func Fibonacci(n int) int {
+	if n <= 0 {
+		return 0
+	} else if n == 1 {
+		return 1
+	}
+	return Fibonacci(n-1) + Fibonacci(n-2)
+}
+
+// FibonacciIterative calcula el n-ésimo número de Fibonacci en O(n) con dos variables.
+func FibonacciIterative(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	previous, current := 0, 1
+	for range n - 1 {
+		previous, current = current, previous+current
+	}
+	return current
+}
+
+// FibonacciMemoized calcula el n-ésimo número de Fibonacci de forma recursiva,
+// guardando los resultados intermedios en un mapa local a la llamada.
+func FibonacciMemoized(n int) int {
+	memo := make(map[int]int)
+	var fib func(n int) int
+	fib = func(n int) int {
+		if n <= 1 {
+			return max(n, 0)
+		}
+		if value, exists := memo[n]; exists {
+			return value
+		}
+		memo[n] = fib(n-1) + fib(n-2)
+		return memo[n]
+	}
+	return fib(n)
+}
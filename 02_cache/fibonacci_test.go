@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/memoize"
+)
+
+// benchmarkN es el número de Fibonacci usado en los benchmarks.
+const benchmarkN = 30
+
+func TestFibonacciVariants(t *testing.T) {
+	want := []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55}
+	for _, variant := range []struct {
+		name string
+		fn   func(int) int
+	}{
+		{"Recursiva", Fibonacci},
+		{"Iterativa", FibonacciIterative},
+		{"Memoizada", FibonacciMemoized},
+	} {
+		for n, expected := range want {
+			if got := variant.fn(n); got != expected {
+				t.Errorf("%s(%d) = %d, quiero %d", variant.name, n, got, expected)
+			}
+		}
+	}
+}
+
+// benchmarkFibonacci mide fn directamente y detrás de una Memory, para comparar
+// cuánto ahorra el cache en cada variante.
+func benchmarkFibonacci(b *testing.B, fn func(int) int) {
+	b.Run("sin cache", func(b *testing.B) {
+		for b.Loop() {
+			fn(benchmarkN)
+		}
+	})
+	b.Run("con Memory", func(b *testing.B) {
+		cache := memoize.NewMemory(func(n int) (int, error) { return fn(n), nil }, memoize.WithLogging(false))
+		for b.Loop() {
+			cache.Get(benchmarkN)
+		}
+	})
+}
+
+func BenchmarkFibonacciRecursive(b *testing.B) {
+	benchmarkFibonacci(b, Fibonacci)
+}
+
+func BenchmarkFibonacciIterative(b *testing.B) {
+	benchmarkFibonacci(b, FibonacciIterative)
+}
+
+func BenchmarkFibonacciMemoized(b *testing.B) {
+	benchmarkFibonacci(b, FibonacciMemoized)
+}
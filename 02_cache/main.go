@@ -5,76 +5,268 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/memoize"
 )
 
-// CacheableFunction define el tipo de función que puede ser cacheada.
-type CacheableFunction func(key int) (any, error)
+// GetFibonacci adapta la función Fibonacci para el tipo memoize.CacheableFunction.
+func GetFibonacci(n int) (int, error) {
+	return Fibonacci(n), nil
+}
+
+// main ejecuta el ejemplo de cache usando la función Fibonacci.
+func main() {
+	cache := memoize.NewMemory(GetFibonacci,
+		memoize.WithOnMiss(func(duration time.Duration) { fmt.Println("⏱️ Time taken:", duration) }),
+		memoize.WithOnHit(func(saved time.Duration) { fmt.Println("⚡ Time saved:", saved) }),
+		// Los resultados se guardan al salir: en la siguiente ejecución Fibonacci(45) ya está cacheado
+		memoize.WithAutoSave(filepath.Join(os.TempDir(), "fibonacci_memory.gob")),
+	)
+	defer func() {
+		if err := cache.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+	fibonacciNumbers := []int{35, 40, 44, 40, 45}
+
+	for _, n := range fibonacciNumbers {
+		fmt.Printf("\n🔢 Fibonacci de %d... ", n)
+		result, err := cache.Get(n)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Printf("🔢 Resultado => %v\n", result)
+	}
+
+	stats := cache.Stats()
+	fmt.Printf("\n📊 Aciertos: %d, Cálculos: %d, Tiempo calculando: %v\n", stats.Hits, stats.Misses, stats.TimeSpent)
+	fmt.Printf("💡 El cache ahorró %.1fs\n", stats.TimeSaved.Seconds())
 
-// CachedFunctionResult es un tipo que representa el resultado de una función cacheada.
-type CachedFunctionResult struct {
-	value any   // Valor calculado por la función
-	err   error // Error retornado por la función
+	demonstrateConcurrentMisses()
+	demonstrateMemoize()
+	demonstrateFileStore()
+	demonstrateInvalidation()
+	demonstrateCompositeKeys()
+	demonstratePrefetch()
+	demonstrateNegativeCaching()
+	demonstratePanicRecovery()
 }
 
-type Memory struct {
-	f     CacheableFunction            // Función a cachear
-	cache map[int]CachedFunctionResult // Mapa para almacenar resultados cacheados
+// demonstrateConcurrentMisses lanza varias goroutines que piden claves a la vez.
+// Las llamadas repetidas a la misma clave comparten un solo cálculo, y como los locks
+// son por clave, las claves distintas se calculan en paralelo sin bloquearse.
+func demonstrateConcurrentMisses() {
+	fmt.Println("\n🔄 Llamadas concurrentes:")
+	cache := memoize.NewMemory(GetFibonacci)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for _, n := range []int{40, 40, 40, 35, 41} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := cache.Get(n)
+			if err != nil {
+				panic(err)
+			}
+			fmt.Printf("🔢 Fibonacci(%d) => %v\n", n, result)
+		}()
+	}
+	wg.Wait()
+	fmt.Println("⏱️ Time taken:", time.Since(start))
 }
 
-// newMemory inicializa una instancia de Memory con la función a cachear.
-func newMemory(f CacheableFunction) *Memory {
-	return &Memory{
-		f:     f,
-		cache: make(map[int]CachedFunctionResult),
+// demonstrateMemoize envuelve funciones arbitrarias con Memoize sin construir Memory
+// a mano. La función memoizada tiene la misma firma que la original.
+func demonstrateMemoize() {
+	fmt.Println("\n🎁 Decorador Memoize:")
+	fibonacci := memoize.Memoize(GetFibonacci, memoize.WithLogging(false))
+	for _, n := range []int{38, 38} {
+		start := time.Now()
+		result, _ := fibonacci(n)
+		fmt.Printf("🔢 Fibonacci(%d) = %v ⏱️ %v\n", n, result, time.Since(start))
+	}
+
+	countVowels := memoize.Memoize(CountVowels)
+	for _, word := range []string{"murciélago", "murciélago"} {
+		result, _ := countVowels(word)
+		fmt.Printf("🔤 Vocales en %q = %d\n", word, result)
 	}
 }
 
-// Get retorna el valor cacheado para una clave. Si no existe, lo calcula y lo almacena.
-func (m *Memory) Get(key int) (any, error) {
-	result, isCached := m.cache[key]
-	if isCached {
-		fmt.Println("[✅Cacheado]")
-		return result.value, result.err
-	}
-	// Calcula el valor y lo almacena en el cache
-	result.value, result.err = m.f(key)
-	m.cache[key] = result
-	fmt.Printf("[⚙️Calculado]\n")
-	return result.value, result.err
+// demonstrateFileStore usa un FileStore compartido por dos instancias de Memory:
+// la segunda encuentra en disco el valor que calculó la primera.
+func demonstrateFileStore() {
+	fmt.Println("\n💾 Store en archivos:")
+	store, err := memoize.NewFileStore[int, int](filepath.Join(os.TempDir(), "fibonacci_cache"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer store.Delete(42)
+
+	for i := range 2 {
+		cache := memoize.NewMemory(GetFibonacci, memoize.WithStore(store))
+		start := time.Now()
+		result, _ := cache.Get(42)
+		fmt.Printf("🔢 Instancia %d: Fibonacci(42) = %v ⏱️ %v\n", i+1, result, time.Since(start))
+	}
 }
 
-// GetFibonacci adapta la función Fibonacci para el tipo Function.
-func GetFibonacci(n int) (any, error) {
-	return Fibonacci(n), nil
+// demonstrateInvalidation muestra cómo descartar resultados cacheados
+// para forzar que se vuelvan a calcular.
+func demonstrateInvalidation() {
+	fmt.Println("\n🧹 Invalidación de resultados:")
+	cache := memoize.NewMemory(GetFibonacci, memoize.WithLogging(false))
+	for n := range 10 {
+		cache.Get(n)
+	}
+
+	fmt.Println("🗑️ Invalidate(3):", cache.Invalidate(3))
+	fmt.Println("🗑️ Invalidate(3) de nuevo:", cache.Invalidate(3))
+
+	removed := cache.InvalidateWhere(func(n, value int) bool { return value%2 == 0 })
+	fmt.Printf("🗑️ InvalidateWhere(resultado par) eliminó %d resultados\n", removed)
+	fmt.Printf("🗑️ InvalidateAll eliminó %d resultados\n", cache.InvalidateAll())
+
+	// Las etiquetas agrupan resultados relacionados para invalidarlos juntos
+	for n := range 10 {
+		if n < 5 {
+			cache.GetWithTags(n, "pequeños")
+		} else {
+			cache.GetWithTags(n, "grandes")
+		}
+	}
+	fmt.Printf("🏷️ InvalidateTag(\"grandes\") eliminó %d resultados\n", cache.InvalidateTag("grandes"))
 }
 
-// main ejecuta el ejemplo de cache usando la función Fibonacci.
-func main() {
-	cache := newMemory(GetFibonacci)
-	fibonacciNumbers := []int{35, 40, 44, 40, 45}
+// demonstrateCompositeKeys memoiza funciones con varios argumentos o con argumentos
+// no comparables (slices), construyendo una clave compuesta para cada llamada.
+func demonstrateCompositeKeys() {
+	fmt.Println("\n🔑 Claves compuestas:")
+	var binomial func(n, k int) (int, error)
+	binomial = memoize.Memoize2(func(n, k int) (int, error) {
+		if k == 0 || k == n {
+			return 1, nil
+		}
+		left, _ := binomial(n-1, k-1)
+		right, _ := binomial(n-1, k)
+		return left + right, nil
+	}, memoize.WithLogging(false))
+	result, _ := binomial(60, 30)
+	fmt.Printf("🔢 C(60, 30) = %d\n", result)
 
-	for _, n := range fibonacciNumbers {
-		start := time.Now()
+	sum := memoize.MemoizeBy(SlowSum, func(numbers []int) string { return memoize.HashKey(numbers) })
+	for _, numbers := range [][]int{{1, 2, 3}, {4, 5}, {1, 2, 3}} {
+		total, _ := sum(numbers)
+		fmt.Printf("➕ Suma de %v = %d\n", numbers, total)
+	}
+}
 
-		fmt.Printf("\n🔢 Fibonacci de %d... ", n)
-		result, err := cache.Get(n)
-		if err != nil {
-			panic(err)
+// demonstratePrefetch calienta el cache en segundo plano y luego mide las consultas,
+// que ya no necesitan calcular nada.
+func demonstratePrefetch() {
+	fmt.Println("\n🔥 Precálculo en segundo plano:")
+	cache := memoize.NewMemory(GetFibonacci, memoize.WithLogging(false), memoize.WithPrefetchParallelism(2))
+	keys := []int{36, 37, 38, 39}
+
+	start := time.Now()
+	handle := cache.Prefetch(keys...)
+	fmt.Println("⏳ Precalculando", keys, "con 2 goroutines...")
+	if err := handle.Wait(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("✅ Cache caliente en", time.Since(start))
+
+	start = time.Now()
+	for _, n := range keys {
+		cache.Get(n)
+	}
+	fmt.Printf("⚡ %d consultas en %v\n", len(keys), time.Since(start))
+}
+
+// demonstrateNegativeCaching consulta repetidamente un usuario inexistente.
+// El "no encontrado" se cachea con un TTL corto, así que la función solo se llama
+// de nuevo cuando ese TTL vence.
+func demonstrateNegativeCaching() {
+	fmt.Println("\n🚫 Cache negativo:")
+	lookups := 0
+	findUser := func(id int) (string, error) {
+		lookups++
+		if id == 1 {
+			return "Ana", nil
 		}
+		return "", fmt.Errorf("usuario %d: %w", id, memoize.ErrNotFound)
+	}
+	cache := memoize.NewMemory(findUser, memoize.WithLogging(false), memoize.WithNegativeTTL(time.Second))
 
-		fmt.Printf("🔢 Resultado => %v\n", result)
-		fmt.Println("⏱️ Time taken:", time.Since(start))
+	for range 3 {
+		_, err := cache.Get(99)
+		fmt.Println("🔍", err)
+	}
+	fmt.Println("   Esperando a que venza el TTL negativo...")
+	time.Sleep(1100 * time.Millisecond)
+	cache.Get(99)
+
+	stats := cache.Stats()
+	fmt.Printf("📊 Llamadas a la función: %d, aciertos negativos: %d\n", lookups, stats.Negative)
+}
+
+// demonstratePanicRecovery memoiza una función que hace panic con ciertos argumentos.
+// Todos los llamadores concurrentes reciben el mismo *memoize.PanicError y el fallo
+// no queda guardado en el cache.
+func demonstratePanicRecovery() {
+	fmt.Println("\n💥 Recuperación de panics:")
+	divide := memoize.Memoize(func(n int) (int, error) {
+		time.Sleep(200 * time.Millisecond)
+		return 100 / n, nil // n == 0 provoca un panic en tiempo de ejecución
+	})
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := divide(0)
+			var panicErr *memoize.PanicError
+			if errors.As(err, &panicErr) {
+				fmt.Println("🛟 Error recibido:", panicErr)
+			}
+		}()
+	}
+	wg.Wait()
+
+	_, err := divide(0)
+	fmt.Println("🔁 Segundo intento (no estaba cacheado):", err)
+}
+
+// SlowSum suma un slice simulando un cálculo lento. Los slices no son comparables,
+// así que para memoizarla hace falta una clave construida con HashKey.
+func SlowSum(numbers []int) (int, error) {
+	time.Sleep(300 * time.Millisecond)
+	total := 0
+	for _, n := range numbers {
+		total += n
 	}
+	return total, nil
 }
 
-// Fibonacci calcula el n-ésimo número de Fibonacci de forma recursiva.
-func Fibonacci(n int) int {
-	if n <= 0 {
-		return 0
-	} else if n == 1 {
-		return 1
+// CountVowels cuenta las vocales de una palabra simulando un cálculo lento.
+func CountVowels(word string) (int, error) {
+	time.Sleep(500 * time.Millisecond)
+	count := 0
+	for _, r := range strings.ToLower(word) {
+		if strings.ContainsRune("aeiouáéíóú", r) {
+			count++
+		}
 	}
-	return Fibonacci(n-1) + Fibonacci(n-2)
+	return count, nil
 }
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/rediscache"
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/singleflight"
+)
+
+// redisCoordinator extiende la deduplicación de Service entre procesos.
+// Dentro de un proceso, Service ya garantiza un solo cálculo por trabajo; entre procesos,
+// quien calcula es el que logra el lock (SETNX) en el cache compartido, y los demás
+// esperan la notificación por pub/sub o leen el resultado guardado.
+type redisCoordinator struct {
+	redis     *rediscache.SimpleRedisCache
+	instance  string        // Identificador de este proceso, guardado como valor del lock
+	lockTTL   time.Duration // Si el dueño del lock muere, otro proceso lo toma tras este tiempo
+	resultTTL time.Duration // Tiempo de vida del resultado compartido (0 = nunca expira)
+}
+
+// newDistributedService crea un Service que, además de deduplicar dentro del proceso,
+// se coordina con otras instancias (otros procesos) a través de redis. instance
+// identifica a este proceso.
+func newDistributedService(work func(job int) (int, error), resultTTL time.Duration, redis *rediscache.SimpleRedisCache, instance string) *Service {
+	s := newService(work, resultTTL)
+	s.coordinator = &redisCoordinator{
+		redis:     redis,
+		instance:  instance,
+		lockTTL:   10 * time.Second,
+		resultTTL: resultTTL,
+	}
+	return s
+}
+
+// run obtiene el resultado de job calculándolo una sola vez entre todos los procesos.
+func (c *redisCoordinator) run(job int, work func(job int) (int, error)) (int, error) {
+	lockKey := fmt.Sprintf("job:%d:lock", job)
+	resultKey := fmt.Sprintf("job:%d:result", job)
+	channel := fmt.Sprintf("job:%d:done", job)
+
+	for {
+		// Suscribirse antes de mirar el resultado: si el dueño publica justo en medio,
+		// o lo encontramos guardado o nos llega el mensaje, pero nunca se pierde
+		messages, unsubscribe := c.redis.Subscribe(channel)
+
+		if value, found := c.redis.Get(resultKey); found {
+			unsubscribe()
+			result := value.(JobResult)
+			return result.Value, result.Err
+		}
+
+		if c.redis.SetNX(lockKey, c.instance, c.lockTTL) {
+			unsubscribe()
+			fmt.Printf("🔒 %s obtuvo el lock de Fibonacci de %d\n", c.instance, job)
+			return c.own(job, work, lockKey, resultKey, channel)
+		}
+
+		fmt.Printf("📡 %s espera a que otro proceso calcule Fibonacci de %d\n", c.instance, job)
+		select {
+		case message := <-messages:
+			unsubscribe()
+			result := message.(JobResult)
+			return result.Value, result.Err
+		case <-time.After(c.lockTTL):
+			unsubscribe() // El dueño del lock pudo haber muerto: se vuelve a intentar
+		}
+	}
+}
+
+// own calcula job como dueño del lock. El lock se libera en un defer, así que también se
+// libera si work hace panic, y solo si sigue siendo de esta instancia: si el cálculo tardó
+// más que lockTTL, otro proceso pudo haberlo tomado y no hay que quitárselo. Ante un panic
+// se publica el error antes de relanzarlo, para que los procesos que esperan no se queden
+// hasta el timeout.
+func (c *redisCoordinator) own(job int, work func(job int) (int, error), lockKey, resultKey, channel string) (result int, err error) {
+	defer c.redis.DeleteIfEquals(lockKey, c.instance)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			c.redis.Publish(channel, JobResult{Err: &singleflight.PanicError{Value: recovered, Stack: debug.Stack()}})
+			panic(recovered) // Service.execute lo convierte en *PanicError para este proceso
+		}
+	}()
+
+	result, err = work(job)
+	if err == nil { // Igual que en Service, los errores no se cachean
+		c.redis.Set(resultKey, JobResult{Value: result}, c.resultTTL)
+	}
+	c.redis.Publish(channel, JobResult{Value: result, Err: err})
+	return result, err
+}
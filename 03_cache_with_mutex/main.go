@@ -1,89 +1,246 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/rediscache"
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/singleflight"
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
 )
 
-type Service struct {
-	InProgress map[int]bool
-	IsPending  map[int][]chan int
-	mu         sync.RWMutex
-}
+// main ejecuta varios trabajos concurrentes usando goroutines y un servicio que gestiona el estado de los trabajos.
+// El objetivo es evitar cálculos duplicados y notificar a los clientes cuando el resultado esté disponible.
+func main() {
+	service := newService(ExpensiveFibonacci, time.Minute) // Instancia el servicio que gestiona los trabajos concurrentes
+	jobs := []int{3, 4, 5, 5, 4, 8, 8, 8, -1, -1}          // Lista de trabajos a ejecutar (con repetidos para simular concurrencia; -1 falla)
 
-func newService() *Service {
-	return &Service{
-		InProgress: make(map[int]bool),
-		IsPending:  make(map[int][]chan int),
+	var wg sync.WaitGroup // WaitGroup para esperar a que todas las goroutines terminen
+	wg.Add(len(jobs))
+	for _, job := range jobs {
+		go func(j int) {
+			defer wg.Done()                // Marca la goroutine como finalizada
+			result, err := service.Work(j) // Ejecuta el trabajo y gestiona la sincronización y notificación
+			if err != nil {
+				fmt.Printf("❌ Error en el trabajo %d: %v\n", j, err)
+				return
+			}
+			fmt.Printf("📦 Trabajo %d => %d\n", j, result)
+		}(job)
 	}
-}
-
-func (s *Service) Work(job int) {
-	s.mu.RLock()
+	wg.Wait() // Espera a que todas las goroutines finalicen
 
-	isJobInProgress := s.InProgress[job]
-	if isJobInProgress {
-		s.mu.RUnlock()
-		response := make(chan int)
-		defer close(response)
+	// Deduplicación + memoización: los trabajos ya terminados salen del cache sin recalcular
+	fmt.Println("\n🔁 Repitiendo trabajos ya calculados:")
+	for _, job := range []int{5, 8} {
+		service.Work(job)
+	}
 
-		s.mu.Lock()
-		s.IsPending[job] = append(s.IsPending[job], response)
-		s.mu.Unlock()
+	stats := service.Stats()
+	fmt.Printf("\n📊 Llamadas: %d, Cálculos: %d, Compartidas: %d, Desde cache: %d, Espera promedio: %v\n",
+		stats.Calls, stats.Executions, stats.Shared, stats.CacheHits, stats.AverageWait.Round(time.Millisecond))
+	fmt.Printf("💡 Se evitaron %d de %d cálculos\n", stats.Avoided(), stats.Calls)
+
+	demonstrateGroup()
+	demonstrateTimeouts()
+	demonstrateStress()
+	demonstrateForget()
+	demonstratePanics()
+	demonstrateDistributed()
+}
 
-		fmt.Printf("⏳ Esperando resultado de Fibonacci de %d\n", job)
+// demonstrateDistributed simula dos procesos, cada uno con su propio Service, que
+// comparten un cache estilo Redis. Aunque ambos reciben pedidos del mismo trabajo,
+// Fibonacci de 21 se calcula una sola vez en total.
+func demonstrateDistributed() {
+	fmt.Println("\n🌐 Deduplicación entre procesos con SETNX + pub/sub:")
+	redis := rediscache.NewSimpleRedisCache()
+	redis.SetLogging(false)
+	processA := newDistributedService(ExpensiveFibonacci, time.Minute, redis, "proceso-A")
+	processB := newDistributedService(ExpensiveFibonacci, time.Minute, redis, "proceso-B")
+	processA.quiet, processB.quiet = true, true
+
+	var wg sync.WaitGroup
+	for _, process := range []*Service{processA, processB, processA, processB} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := process.Work(21)
+			if err != nil {
+				fmt.Println("❌", err)
+				return
+			}
+			fmt.Printf("📦 Trabajo 21 => %d\n", result)
+		}()
+	}
+	wg.Wait()
 
-		resp := <-response
+	// Un proceso que arranca después encuentra el resultado ya guardado en Redis
+	processC := newDistributedService(ExpensiveFibonacci, time.Minute, redis, "proceso-C")
+	result, _ := processC.Work(21)
+	fmt.Printf("📦 proceso-C (recién iniciado) => %d sin calcular\n", result)
+}
 
-		fmt.Printf("✅ Resultado recibido de Fibonacci de %d: %d\n", job, resp)
-		return
+// demonstratePanics hace que el cálculo compartido haga panic: todos los waiters
+// reciben el mismo *singleflight.PanicError y el programa sigue funcionando.
+func demonstratePanics() {
+	fmt.Println("\n💥 Panic en el cálculo compartido:")
+	service := newService(func(job int) (int, error) {
+		time.Sleep(200 * time.Millisecond)
+		panic(fmt.Sprintf("el trabajo %d explotó", job))
+	}, 0)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.Work(13)
+			var panicErr *singleflight.PanicError
+			if errors.As(err, &panicErr) {
+				fmt.Println("🛟 Waiter recibió:", panicErr)
+			}
+		}()
 	}
-	s.mu.RUnlock()
+	wg.Wait()
+}
 
-	s.mu.Lock()
-	// Si no está en progreso, lo marcamos como tal y comenzamos el trabajo
-	s.InProgress[job] = true
-	s.mu.Unlock()
+// demonstrateForget olvida un cálculo en curso: el siguiente Work no espera al
+// cálculo viejo, sino que lanza uno nuevo.
+func demonstrateForget() {
+	fmt.Println("\n🧽 Forget durante un cálculo:")
+	service := newService(ExpensiveFibonacci, time.Minute)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		service.Work(11)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	fmt.Println("🧽 Los datos cambiaron: Forget(11)")
+	service.Forget(11)
+	service.Work(11) // Lanza un cálculo nuevo en lugar de esperar el anterior
+	wg.Wait()
+}
 
-	result := ExpensiveFibonacci(job)
+// demonstrateStress lanza muchas goroutines sobre pocas claves y muestra cuántas
+// veces se calculó cada una. Ejecutar con go run -race para verificar además que no
+// hay condiciones de carrera.
+func demonstrateStress() {
+	const goroutines, keys = 1000, 10
+	fmt.Printf("\n🏋️ Prueba de estrés: %d goroutines sobre %d claves\n", goroutines, keys)
+
+	var executions syncutil.SafeCounter[int] // Cálculos por clave
+	service := newService(func(job int) (int, error) {
+		executions.Inc(job)
+		time.Sleep(50 * time.Millisecond)
+		return job * job, nil
+	}, 0)
+	service.quiet = true
+
+	var wg sync.WaitGroup
+	var failures atomic.Int32
+	for i := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job := i % keys
+			if result, err := service.Work(job); err != nil || result != job*job {
+				failures.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
 
-	s.mu.RLock()
-	pendingWorkers, exists := s.IsPending[job]
-	s.mu.RUnlock()
+	counts := make([]int, keys)
+	for job := range keys {
+		counts[job] = executions.Get(job)
+	}
+	fmt.Printf("📊 Cálculos por clave: %v (resultados incorrectos: %d)\n", counts, failures.Load())
+}
 
-	if exists {
-		for _, ch := range pendingWorkers {
-			ch <- result
-		}
-		fmt.Printf("🔔 Notificados a todos los pendientes de Fibonacci de %d\n", job)
+// demonstrateTimeouts muestra waiters que abandonan la espera cuando vence su contexto,
+// tanto en Service como en singleflight.Group, sin bloquear al que calcula.
+func demonstrateTimeouts() {
+	fmt.Println("\n⌛ Waiters con timeout:")
+	service := newService(ExpensiveFibonacci, 0)
+	var group singleflight.Group[int, int]
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		service.Work(9) // Tarda 5 segundos
+	}()
+	go func() {
+		defer wg.Done()
+		group.Do(9, func() (int, error) { return ExpensiveFibonacci(9) })
+	}()
+	time.Sleep(100 * time.Millisecond) // Deja que empiecen los cálculos
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := service.WorkCtx(ctx, 9); err != nil {
+		fmt.Println("🚪 Service: el waiter se rindió:", err)
+	}
+	if _, err, _ := group.DoCtx(ctx, 9, func() (int, error) { return ExpensiveFibonacci(9) }); err != nil {
+		fmt.Println("🚪 Group: el waiter se rindió:", err)
 	}
 
-	s.mu.Lock()
-	s.InProgress[job] = false
-	s.IsPending[job] = make([]chan int, 0)
-	s.mu.Unlock()
+	wg.Wait()
+	fmt.Printf("📊 Group: %+v\n", group.Stats())
+
+	// DoChan permite combinar la espera con otros canales en un select
+	results := group.DoChan(10, func() (int, error) { return ExpensiveFibonacci(10) })
+	select {
+	case result := <-results:
+		fmt.Println("📦 DoChan =>", result.Val, result.Err)
+	case <-time.After(time.Second):
+		fmt.Println("🚪 DoChan: timeout tras 1s; el cálculo sigue y su resultado queda en el canal")
+	}
+	fmt.Println("📦 DoChan (leído más tarde) =>", (<-results).Val)
 }
 
-// main ejecuta varios trabajos concurrentes usando goroutines y un servicio que gestiona el estado de los trabajos.
-// El objetivo es evitar cálculos duplicados y notificar a los clientes cuando el resultado esté disponible.
-func main() {
-	service := newService()               // Instancia el servicio que gestiona los trabajos concurrentes
-	jobs := []int{3, 4, 5, 5, 4, 8, 8, 8} // Lista de trabajos a ejecutar (con repetidos para simular concurrencia)
+// demonstrateGroup resuelve el mismo problema con singleflight.Group, la versión
+// genérica y reutilizable de la técnica de Service.
+func demonstrateGroup() {
+	fmt.Println("\n🧩 Mismo ejemplo con singleflight.Group:")
+	var group singleflight.Group[int, int]
+	jobs := []int{3, 3, 3, 7, 7}
 
-	var wg sync.WaitGroup // WaitGroup para esperar a que todas las goroutines terminen
-	wg.Add(len(jobs))
+	var wg sync.WaitGroup
 	for _, job := range jobs {
-		go func(j int) {
-			defer wg.Done() // Marca la goroutine como finalizada
-			service.Work(j) // Ejecuta el trabajo y gestiona la sincronización y notificación
-		}(job)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err, shared := group.Do(job, func() (int, error) {
+				return ExpensiveFibonacci(job)
+			})
+			if err != nil {
+				fmt.Printf("❌ Error en el trabajo %d: %v\n", job, err)
+				return
+			}
+			fmt.Printf("📦 Trabajo %d => %d (compartido: %t)\n", job, result, shared)
+		}()
 	}
-	wg.Wait() // Espera a que todas las goroutines finalicen
+	wg.Wait()
+
+	stats := group.Stats()
+	fmt.Printf("📊 Llamadas: %d, Ejecuciones: %d, Compartidas: %d\n", stats.Calls, stats.Executions, stats.Shared)
 }
 
-func ExpensiveFibonacci(n int) int {
+// ExpensiveFibonacci simula un cálculo lento que puede fallar (con n negativo).
+func ExpensiveFibonacci(n int) (int, error) {
 	fmt.Printf("⚙️ Calculando Fibonacci de %d...\n", n)
 	time.Sleep(5 * time.Second)
-	return n
+	if n < 0 {
+		return 0, fmt.Errorf("❌ Fibonacci no está definido para %d", n)
+	}
+	return n, nil
 }
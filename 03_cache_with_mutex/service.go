@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/singleflight"
+)
+
+// defaultShards es el número de particiones de locks de newService.
+const defaultShards = 32
+
+// CachedResult es un resultado ya calculado junto con su momento de expiración.
+type CachedResult struct {
+	Value     int
+	ExpiresAt time.Time // Cero significa que nunca expira
+}
+
+// IsExpired indica si el resultado ya no debe reutilizarse.
+func (r CachedResult) IsExpired() bool {
+	return !r.ExpiresAt.IsZero() && time.Now().After(r.ExpiresAt)
+}
+
+// JobResult es lo que recibe cada waiter: el valor calculado o el error del cálculo.
+type JobResult struct {
+	Value int
+	Err   error
+}
+
+// jobCall representa un cálculo en curso para un trabajo.
+// Se crea y se registra en la misma sección crítica en la que se comprueba si ya
+// existía, así que nunca hay dos cálculos del mismo trabajo a la vez. Todos los que
+// llegan mientras tanto esperan en done, que se cierra una sola vez al terminar:
+// nadie se queda sin el resultado, llegue cuando llegue.
+type jobCall struct {
+	done   chan struct{} // Se cierra cuando result está listo
+	once   sync.Once     // Garantiza que done se cierre una sola vez
+	result JobResult
+}
+
+// finish publica el resultado y despierta a todos los waiters.
+func (c *jobCall) finish(result JobResult) {
+	c.once.Do(func() {
+		c.result = result
+		close(c.done)
+	})
+}
+
+// ServiceStats resume cuántos cálculos evitó el servicio.
+type ServiceStats struct {
+	Calls       int           // Llamadas totales a Work/WorkCtx
+	Executions  int           // Veces que realmente se ejecutó el cálculo
+	Shared      int           // Llamadas que esperaron el cálculo de otra goroutine
+	CacheHits   int           // Llamadas servidas desde el cache de resultados
+	AverageWait time.Duration // Espera promedio de las llamadas compartidas
+}
+
+// Avoided retorna cuántos cálculos se evitaron (por compartir o por cache).
+func (st ServiceStats) Avoided() int {
+	return st.Shared + st.CacheHits
+}
+
+// serviceShard agrupa los trabajos que comparten lock (lock striping): dos trabajos
+// en particiones distintas nunca se bloquean entre sí.
+type serviceShard struct {
+	mu      sync.Mutex
+	calls   map[int]*jobCall     // Cálculos en curso por trabajo
+	results map[int]CachedResult // Resultados de trabajos ya terminados
+}
+
+type Service struct {
+	work      func(job int) (int, error) // Cálculo costoso a deduplicar
+	shards    []*serviceShard            // Locks, cálculos en curso y resultados, repartidos por trabajo
+	resultTTL time.Duration              // Tiempo de vida de cada resultado (0 = nunca expira)
+	quiet     bool                       // Si es true no se imprime cada paso
+
+	// coordinator, si no es nil, extiende la deduplicación a otros procesos (ver newDistributedService)
+	coordinator *redisCoordinator
+
+	// Las estadísticas son atómicas para no volver a tener un lock global
+	calls, executions, shared, cacheHits atomic.Int64
+	totalWait                            atomic.Int64 // Nanosegundos esperados por las llamadas compartidas
+}
+
+// newService crea el servicio que deduplica las llamadas a work. resultTTL indica
+// cuánto tiempo se reutiliza un resultado después de calcularlo; con 0 se reutiliza
+// para siempre.
+func newService(work func(job int) (int, error), resultTTL time.Duration) *Service {
+	return newShardedService(work, resultTTL, defaultShards)
+}
+
+// newShardedService es como newService pero permite elegir en cuántas particiones se
+// reparten los locks. Con shards = 1 todos los trabajos comparten un único lock.
+func newShardedService(work func(job int) (int, error), resultTTL time.Duration, shards int) *Service {
+	s := &Service{
+		work:      work,
+		shards:    make([]*serviceShard, max(shards, 1)),
+		resultTTL: resultTTL,
+	}
+	for i := range s.shards {
+		s.shards[i] = &serviceShard{
+			calls:   make(map[int]*jobCall),
+			results: make(map[int]CachedResult),
+		}
+	}
+	return s
+}
+
+// shardFor retorna la partición de un trabajo.
+func (s *Service) shardFor(job int) *serviceShard {
+	return s.shards[uint(job)%uint(len(s.shards))]
+}
+
+// Work calcula el resultado de job. Si ya se calculó antes (y no expiró) lo retorna
+// del cache; si otra goroutine lo está calculando, espera y retorna ese mismo resultado
+// en lugar de repetir el cálculo.
+func (s *Service) Work(job int) (int, error) {
+	return s.WorkCtx(context.Background(), job)
+}
+
+// WorkCtx es como Work, pero si hay que esperar el cálculo de otra goroutine, la espera
+// se abandona cuando ctx termina y se retorna ctx.Err().
+func (s *Service) WorkCtx(ctx context.Context, job int) (int, error) {
+	s.calls.Add(1)
+	shard := s.shardFor(job)
+
+	shard.mu.Lock()
+	// Primero el cache de resultados: evita incluso esperar a otro cálculo
+	if cached, exists := shard.results[job]; exists && !cached.IsExpired() {
+		shard.mu.Unlock()
+		s.cacheHits.Add(1)
+		s.logf("💾 Resultado cacheado de Fibonacci de %d: %d\n", job, cached.Value)
+		return cached.Value, nil
+	}
+	// Comprobar y registrar en la misma sección crítica elimina el check-then-act
+	call, isInProgress := shard.calls[job]
+	if !isInProgress {
+		call = &jobCall{done: make(chan struct{})}
+		shard.calls[job] = call
+	}
+	shard.mu.Unlock()
+
+	if !isInProgress {
+		s.executions.Add(1)
+		return s.execute(shard, job, call)
+	}
+
+	s.shared.Add(1)
+	s.logf("⏳ Esperando resultado de Fibonacci de %d\n", job)
+	defer s.recordWait(time.Now())
+	select {
+	case <-call.done:
+		if call.result.Err != nil {
+			s.logf("❌ Error recibido de Fibonacci de %d: %v\n", job, call.result.Err)
+			return 0, call.result.Err
+		}
+		s.logf("✅ Resultado recibido de Fibonacci de %d: %d\n", job, call.result.Value)
+		return call.result.Value, nil
+	case <-ctx.Done():
+		// No hay nada que deshacer: done se cierra igual aunque ya nadie espere
+		s.logf("⌛ Se dejó de esperar Fibonacci de %d: %v\n", job, ctx.Err())
+		return 0, ctx.Err()
+	}
+}
+
+// execute hace el cálculo de job, guarda el resultado y notifica a los waiters.
+func (s *Service) execute(shard *serviceShard, job int, call *jobCall) (int, error) {
+	// Un panic se convierte en error para que llegue a todos los waiters
+	// en lugar de dejarlos esperando un done que nunca se cerraría
+	result, err := singleflight.Recover(func() (int, error) {
+		if s.coordinator != nil {
+			return s.coordinator.run(job, s.work)
+		}
+		return s.work(job)
+	})
+
+	// El resultado se cachea y el cálculo se da de baja en la misma sección crítica:
+	// quien llegue después lo encuentra en results o, si llegó antes, ya espera en done
+	shard.mu.Lock()
+	// Si se llamó a Forget durante el cálculo, este resultado ya es viejo: se entrega
+	// a quienes lo esperaban, pero no se cachea ni se toca el registro del cálculo nuevo
+	if shard.calls[job] == call {
+		if err == nil { // Los errores no se cachean: el siguiente Work lo vuelve a intentar
+			cached := CachedResult{Value: result}
+			if s.resultTTL > 0 {
+				cached.ExpiresAt = time.Now().Add(s.resultTTL)
+			}
+			shard.results[job] = cached
+		}
+		delete(shard.calls, job)
+	}
+	shard.mu.Unlock()
+
+	call.finish(JobResult{Value: result, Err: err}) // El error también llega a todos los waiters
+	s.logf("🔔 Notificados a todos los pendientes de Fibonacci de %d\n", job)
+	return result, err
+}
+
+// recordWait suma a las estadísticas el tiempo esperado desde start.
+func (s *Service) recordWait(start time.Time) {
+	s.totalWait.Add(int64(time.Since(start)))
+}
+
+// Stats retorna una copia de las estadísticas del servicio.
+func (s *Service) Stats() ServiceStats {
+	stats := ServiceStats{
+		Calls:      int(s.calls.Load()),
+		Executions: int(s.executions.Load()),
+		Shared:     int(s.shared.Load()),
+		CacheHits:  int(s.cacheHits.Load()),
+	}
+	if stats.Shared > 0 {
+		stats.AverageWait = time.Duration(s.totalWait.Load()) / time.Duration(stats.Shared)
+	}
+	return stats
+}
+
+// Forget descarta el cálculo en curso y el resultado cacheado de job, para que el
+// siguiente Work lo calcule de nuevo. Sirve cuando se sabe que los datos cambiaron
+// a mitad del cálculo. Quienes ya esperaban el cálculo anterior reciben su resultado.
+func (s *Service) Forget(job int) {
+	shard := s.shardFor(job)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	delete(shard.calls, job)
+	delete(shard.results, job)
+}
+
+// logf imprime un paso del servicio salvo que esté en modo silencioso.
+func (s *Service) logf(format string, args ...any) {
+	if !s.quiet {
+		fmt.Printf(format, args...)
+	}
+}
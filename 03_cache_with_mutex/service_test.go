@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/singleflight"
+)
+
+// newQuietService crea un Service de prueba que no imprime cada paso.
+func newQuietService(work func(job int) (int, error)) *Service {
+	s := newService(work, 0)
+	s.quiet = true
+	return s
+}
+
+// waitFor espera hasta que cond se cumpla o falla el test tras un segundo.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("la condición no se cumplió a tiempo")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWorkRunsOncePerJob(t *testing.T) {
+	const callers = 100
+	var executions atomic.Int64
+	release := make(chan struct{})
+	s := newQuietService(func(job int) (int, error) {
+		executions.Add(1)
+		<-release
+		return job * 10, nil
+	})
+
+	results := make([]int, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	for n := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[n], errs[n] = s.Work(7)
+		}()
+	}
+	waitFor(t, func() bool { return s.Stats().Calls == callers })
+	close(release)
+	wg.Wait()
+
+	if got := executions.Load(); got != 1 {
+		t.Errorf("el trabajo se ejecutó %d veces, quiero 1", got)
+	}
+	for n := range callers {
+		if results[n] != 70 || errs[n] != nil {
+			t.Errorf("llamada %d = (%d, %v), quiero (70, <nil>)", n, results[n], errs[n])
+		}
+	}
+	if stats := s.Stats(); stats.Executions != 1 || stats.Avoided() != callers-1 {
+		t.Errorf("Stats = %+v, quiero 1 ejecución y %d evitadas", stats, callers-1)
+	}
+
+	// Una vez calculado, el resultado sale del cache sin volver a ejecutar el trabajo
+	if got, err := s.Work(7); got != 70 || err != nil || executions.Load() != 1 {
+		t.Errorf("Work(7) tras el cálculo = (%d, %v) con %d ejecuciones, quiero (70, <nil>) con 1", got, err, executions.Load())
+	}
+}
+
+func TestStripedLocks(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inFlight = make(map[int]int) // Cálculos en curso por trabajo
+		maxSame  int                 // Máximo de cálculos simultáneos de un mismo trabajo
+		running  atomic.Int64        // Cálculos en curso en total
+	)
+	release := make(chan struct{})
+	s := newQuietService(func(job int) (int, error) {
+		mu.Lock()
+		inFlight[job]++
+		maxSame = max(maxSame, inFlight[job])
+		mu.Unlock()
+		running.Add(1)
+
+		<-release
+
+		mu.Lock()
+		inFlight[job]--
+		mu.Unlock()
+		return job, nil
+	})
+	if s.shardFor(1) == s.shardFor(2) {
+		t.Fatal("los trabajos 1 y 2 deberían caer en particiones distintas")
+	}
+
+	jobs := []int{1, 2, 3, 4, 1, 2, 3, 4, 1, 2, 3, 4}
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Work(job)
+		}()
+	}
+	// Los cuatro trabajos distintos calculan a la vez: ninguno espera el lock de otro
+	waitFor(t, func() bool { return running.Load() == 4 && s.Stats().Calls == len(jobs) })
+	close(release)
+	wg.Wait()
+
+	if maxSame != 1 {
+		t.Errorf("un mismo trabajo llegó a tener %d cálculos simultáneos, quiero 1", maxSame)
+	}
+	if got := running.Load(); got != 4 {
+		t.Errorf("se ejecutaron %d cálculos, quiero 4 (uno por trabajo)", got)
+	}
+}
+
+// BenchmarkStripedLocks compara un Service con un único lock contra uno con locks
+// repartidos por trabajo, con muchas goroutines pidiendo muchos trabajos distintos.
+// La diferencia solo aparece con varias CPUs: con una sola no hay contención real.
+func BenchmarkStripedLocks(b *testing.B) {
+	for _, shards := range []int{1, defaultShards} {
+		b.Run(fmt.Sprintf("%d shards", shards), func(b *testing.B) {
+			service := newShardedService(func(job int) (int, error) { return job, nil }, 0, shards)
+			service.quiet = true
+			b.SetParallelism(8) // 8 goroutines por CPU
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					service.Work(rand.IntN(10_000))
+				}
+			})
+		})
+	}
+}
+
+func TestPanicReachesEveryWaiter(t *testing.T) {
+	const callers = 20
+	var executions atomic.Int64
+	var panicking atomic.Bool
+	panicking.Store(true)
+	release := make(chan struct{})
+	s := newQuietService(func(job int) (int, error) {
+		executions.Add(1)
+		<-release
+		if panicking.Load() {
+			panic("sin memoria")
+		}
+		return job, nil
+	})
+
+	errs := make(chan error, callers)
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.Work(9)
+			errs <- err
+		}()
+	}
+	waitFor(t, func() bool { return s.Stats().Calls == callers })
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		var panicErr *singleflight.PanicError
+		if !errors.As(err, &panicErr) || panicErr.Value != "sin memoria" {
+			t.Errorf("err = %v, quiero un *singleflight.PanicError con el valor del panic", err)
+		}
+	}
+	if got := executions.Load(); got != 1 {
+		t.Errorf("el trabajo se ejecutó %d veces, quiero 1", got)
+	}
+
+	// El panic no se cachea: la siguiente llamada vuelve a calcular
+	panicking.Store(false)
+	if got, err := s.Work(9); got != 9 || err != nil {
+		t.Errorf("Work(9) tras el panic = (%d, %v), quiero (9, <nil>)", got, err)
+	}
+	if got := executions.Load(); got != 2 {
+		t.Errorf("tras el panic: %d ejecuciones, quiero 2", got)
+	}
+}
+
+func TestWaiterCancellationDoesNotStopOwner(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s := newQuietService(func(job int) (int, error) {
+		close(started)
+		<-release
+		return job * 2, nil
+	})
+
+	owner := make(chan JobResult, 1)
+	go func() {
+		value, err := s.Work(4)
+		owner <- JobResult{Value: value, Err: err}
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.WorkCtx(ctx, 4); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WorkCtx con el contexto vencido: err = %v, quiero context.DeadlineExceeded", err)
+	}
+
+	// El dueño sigue calculando y su resultado queda en el cache
+	close(release)
+	if result := <-owner; result.Value != 8 || result.Err != nil {
+		t.Errorf("dueño = (%d, %v), quiero (8, <nil>)", result.Value, result.Err)
+	}
+	if got, err := s.Work(4); got != 8 || err != nil {
+		t.Errorf("Work(4) tras el cálculo = (%d, %v), quiero (8, <nil>)", got, err)
+	}
+	if stats := s.Stats(); stats.Executions != 1 {
+		t.Errorf("Executions = %d, quiero 1", stats.Executions)
+	}
+}
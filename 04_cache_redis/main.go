@@ -4,139 +4,16 @@ import (
 	"fmt"
 	"sync"
 	"time"
-)
-
-// CacheItem representa un elemento en el cache con su valor y tiempo de expiración
-// Esta estructura encapsula el valor almacenado junto con metadatos básicos
-type CacheItem struct {
-	Value      any   // El valor que se almacena (puede ser cualquier tipo de dato)
-	Expiration int64 // Timestamp de cuando expira (0 significa que nunca expira)
-}
-
-// IsExpired verifica si el elemento del cache ha expirado
-// Retorna true si el elemento debe considerarse como eliminado
-func (item *CacheItem) IsExpired() bool {
-	if item.Expiration == 0 {
-		return false // Si es 0, nunca expira
-	}
-	return time.Now().UnixNano() > item.Expiration
-}
-
-// SimpleRedisCache implementa un cache básico en memoria similar a Redis
-// Usa un mapa simple para almacenar los datos y un mutex para thread-safety
-type SimpleRedisCache struct {
-	data  map[string]*CacheItem // Mapa que contiene todos los elementos del cache
-	mutex sync.RWMutex          // Mutex para permitir acceso concurrente seguro
-}
-
-// NewSimpleRedisCache crea y retorna una nueva instancia del cache
-// Inicializa el mapa interno para almacenar los datos
-func NewSimpleRedisCache() *SimpleRedisCache {
-	return &SimpleRedisCache{
-		data: make(map[string]*CacheItem),
-	}
-}
-
-// Set almacena un valor en el cache con una clave específica
-// Parámetros:
-//   - key: la clave para identificar el elemento
-//   - value: el valor a almacenar (puede ser cualquier tipo)
-//   - ttl: tiempo de vida del elemento (time.Duration, 0 = nunca expira)
-func (c *SimpleRedisCache) Set(key string, value any, ttl time.Duration) {
-	// Bloquear para escritura (exclusivo)
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	var expiration int64
-	if ttl > 0 {
-		// Calcular el timestamp de expiración
-		expiration = time.Now().Add(ttl).UnixNano()
-	}
-
-	// Crear el elemento y almacenarlo en el mapa
-	c.data[key] = &CacheItem{
-		Value:      value,
-		Expiration: expiration,
-	}
-
-	fmt.Printf("✅ SET '%s' = '%v'", key, value)
-	if ttl > 0 {
-		fmt.Printf(" (expira en %v)", ttl)
-	}
-	fmt.Println()
-}
-
-// Get recupera un valor del cache usando su clave
-// Retorna:
-//   - any: el valor almacenado
-//   - bool: true si la clave existe y no ha expirado, false en caso contrario
-func (c *SimpleRedisCache) Get(key string) (any, bool) {
-	// Bloquear para lectura (permite múltiples lectores concurrentes)
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
-
-	// Buscar el elemento en el mapa
-	item, exists := c.data[key]
-	if !exists {
-		fmt.Printf("❌ GET '%s' - Clave no encontrada\n", key)
-		return nil, false
-	}
 
-	// Verificar si el elemento ha expirado
-	if item.IsExpired() {
-		fmt.Printf("⏰ GET '%s' - Clave expirada\n", key)
-		return nil, false
-	}
-
-	fmt.Printf("✅ GET '%s' = '%v'\n", key, item.Value)
-	return item.Value, true
-}
-
-// Delete elimina un elemento del cache
-// Retorna true si el elemento existía y fue eliminado, false si no existía
-func (c *SimpleRedisCache) Delete(key string) bool {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	// Verificar si la clave existe antes de eliminarla
-	if _, exists := c.data[key]; exists {
-		delete(c.data, key)
-		fmt.Printf("🗑️ DELETE '%s' - Eliminado exitosamente\n", key)
-		return true
-	}
-
-	fmt.Printf("❌ DELETE '%s' - Clave no encontrada\n", key)
-	return false
-}
-
-// Exists verifica si una clave existe en el cache y no ha expirado
-func (c *SimpleRedisCache) Exists(key string) bool {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
-
-	item, exists := c.data[key]
-	if !exists || item.IsExpired() {
-		fmt.Printf("❌ EXISTS '%s' - No existe o expiró\n", key)
-		return false
-	}
-
-	fmt.Printf("✅ EXISTS '%s' - Existe\n", key)
-	return true
-}
-
-// Size retorna el número de elementos actualmente en el cache
-func (c *SimpleRedisCache) Size() int {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
-	return len(c.data)
-}
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/rediscache"
+)
 
 // demonstrateBasicOperations muestra las operaciones básicas del cache
 func demonstrateBasicOperations() {
 	fmt.Println("🚀 === DEMOSTRACIÓN BÁSICA DEL CACHE REDIS === 🚀")
 
 	// Crear una nueva instancia del cache
-	cache := NewSimpleRedisCache()
+	cache := rediscache.NewSimpleRedisCache()
 
 	fmt.Println("📝 1. Operaciones de ESCRITURA (SET):")
 	fmt.Println("   - Almacenar datos con y sin expiración")
@@ -192,7 +69,7 @@ func demonstrateBasicOperations() {
 func demonstrateConcurrency() {
 	fmt.Println("\n🔄 === DEMOSTRACIÓN DE CONCURRENCIA === 🔄")
 
-	cache := NewSimpleRedisCache()
+	cache := rediscache.NewSimpleRedisCache()
 	var wg sync.WaitGroup
 
 	// Función que simula escrituras concurrentes
@@ -239,6 +116,25 @@ func demonstrateConcurrency() {
 	fmt.Printf("\n✅ Operaciones concurrentes completadas. Tamaño final: %d elementos\n", cache.Size())
 }
 
+// demonstrateLockAndPubSub muestra SETNX como lock (solo un cliente lo obtiene)
+// y PUBLISH/SUBSCRIBE para avisar a otros clientes
+func demonstrateLockAndPubSub() {
+	fmt.Println("\n🔒 === DEMOSTRACIÓN DE SETNX Y PUB/SUB === 🔒")
+
+	cache := rediscache.NewSimpleRedisCache()
+
+	// Solo el primer SETNX obtiene el lock; el segundo falla mientras no expire
+	cache.SetNX("lock:reporte", "cliente-1", 2*time.Second)
+	cache.SetNX("lock:reporte", "cliente-2", 2*time.Second)
+
+	// Un suscriptor recibe lo que se publique en el canal mientras esté suscrito
+	messages, unsubscribe := cache.Subscribe("reportes")
+	cache.Publish("reportes", "reporte listo")
+	fmt.Printf("   📬 Mensaje recibido: %v\n", <-messages)
+	unsubscribe()
+	cache.Publish("reportes", "nadie escucha este mensaje")
+}
+
 // main función principal que ejecuta todas las demostraciones
 func main() {
 	fmt.Println("🎯 Sistema de Cache Estilo Redis - Versión Educativa")
@@ -249,6 +145,7 @@ func main() {
 	fmt.Println("   • Thread-safety con sync.RWMutex")
 	fmt.Println("   • Expiración automática de elementos (TTL)")
 	fmt.Println("   • Operaciones básicas: SET, GET, DELETE, EXISTS")
+	fmt.Println("   • Lock distribuido con SETNX y mensajería con PUBLISH/SUBSCRIBE")
 	fmt.Println()
 
 	// Ejecutar demostración básica
@@ -257,6 +154,9 @@ func main() {
 	// Ejecutar demostración de concurrencia
 	demonstrateConcurrency()
 
+	// Ejecutar demostración de lock y pub/sub
+	demonstrateLockAndPubSub()
+
 	fmt.Println("\n🎉 ¡Demostración completada!")
 	fmt.Println("\n💡 PUNTOS CLAVE APRENDIDOS:")
 	fmt.Println("   1. Un cache es un almacén temporal de datos en memoria")
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// batchWorkers es la cantidad de goroutines que usa CreateBatch.
+const batchWorkers = 8
+
+// CreateBatch crea los productos de specs en paralelo con un pool de batchWorkers
+// goroutines. Los resultados respetan el orden de specs: products[i] y errs[i]
+// corresponden a specs[i], y exactamente uno de los dos es nil.
+func CreateBatch(specs []ProductSpec) ([]factory.IProduct, []error) {
+	products := make([]factory.IProduct, len(specs))
+	errs := make([]error, len(specs))
+
+	jobs := make(chan int) // Índices de specs pendientes
+	var wg sync.WaitGroup
+	for range min(batchWorkers, len(specs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// Cada worker escribe solo en las posiciones que recibe: no hace falta lock
+				products[i], errs[i] = specs[i].build()
+			}
+		}()
+	}
+	for i := range specs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return products, errs
+}
+
+// demonstrateBatch crea un lote grande de productos de un tipo cuyo constructor es
+// lento, primero uno por uno y luego con CreateBatch, e informa los que fallaron.
+func demonstrateBatch() {
+	fmt.Printf("\n🏭 Creación por lotes con %d workers:\n", batchWorkers)
+	// Los equipos reacondicionados pasan una revisión de 20ms antes de entrar al catálogo
+	factory.RegisterProduct("refurbished", func(name string, stock int, price float64) (factory.IProduct, error) {
+		time.Sleep(20 * time.Millisecond)
+		return factory.NewLaptop(name+" (reacondicionada)", stock, price)
+	})
+
+	specs := make([]ProductSpec, 100)
+	for i := range specs {
+		specs[i] = ProductSpec{Type: "refurbished", Name: fmt.Sprintf("Laptop %03d", i+1), Stock: 1, Price: 399}
+	}
+	specs[17].Stock = -1
+	specs[58].Type = "smartwatch"
+
+	start := time.Now()
+	for _, spec := range specs {
+		spec.build()
+	}
+	sequential := time.Since(start)
+
+	start = time.Now()
+	products, errs := CreateBatch(specs)
+	batch := time.Since(start)
+
+	created := 0
+	for i, err := range errs {
+		if err != nil {
+			fmt.Printf("   ❌ spec %d: %v\n", i, err)
+			continue
+		}
+		created++
+	}
+	fmt.Printf("✅ %d de %d creados; el primero es %v\n", created, len(specs), products[0])
+	fmt.Printf("⏱️ Uno por uno: %v | CreateBatch: %v\n", sequential.Round(time.Millisecond), batch.Round(time.Millisecond))
+}
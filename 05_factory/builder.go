@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// demonstrateBuilder arma productos complejos de dos formas: pidiendo un modelo
+// del catálogo (la factory usa el Builder por dentro) y armando uno a medida.
+func demonstrateBuilder() {
+	fmt.Println("\n🧱 Factory + Builder para modelos complejos:")
+	for _, model := range factory.Models() {
+		product, err := factory.CreateModel(model, 2)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println("🖥️", product)
+	}
+
+	custom, err := factory.NewComputerBuilder("laptop", "A medida").
+		CPU("Core i7").RAM(32).GPU("RTX 4060").Storage(1024).Stock(1).Price(2199).
+		Build()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("🛠️", custom)
+
+	_, err = factory.NewComputerBuilder("server", "Servidor barato").
+		RAM(12).Storage(64).Price(499).
+		Build()
+	fmt.Println(err)
+}
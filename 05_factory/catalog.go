@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// demonstrateCatalog junta en un Catalog los productos de catalog.json y los modelos
+// armados con el Builder, y los lista ordenados, filtrados y en JSON.
+func demonstrateCatalog() {
+	fmt.Println("\n🛍️ Catálogo de la tienda:")
+	products, err := LoadCatalog(bytes.NewReader(defaultCatalog))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	catalog := factory.NewCatalog(products...)
+	for _, model := range factory.Models() {
+		product, err := factory.CreateModel(model, 0) // Modelos por encargo: sin stock
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		catalog.Add(product)
+	}
+
+	fmt.Println("\n🔤 Ordenado por nombre:")
+	catalog.SortBy(factory.ByName).Print(os.Stdout)
+
+	fmt.Println("\n📊 Con stock, de mayor a menor:")
+	catalog.Filter(factory.InStock).SortBy(factory.Desc(factory.ByStock)).Print(os.Stdout)
+
+	fmt.Println("\n💻 Laptops por precio:")
+	catalog.Filter(factory.OfType("laptop")).SortBy(factory.ByPrice).Print(os.Stdout)
+
+	fmt.Println("\n🧾 JSON de los modelos por encargo:")
+	onOrder := catalog.Filter(func(p factory.IProduct) bool { return !factory.InStock(p) })
+	data, err := json.MarshalIndent(onOrder, "", "  ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(string(data))
+}
@@ -0,0 +1,7 @@
+//go:build !enterprise
+
+package main
+
+// La edición por defecto. Con -tags enterprise se compila workstation_enterprise.go
+// en lugar de este archivo.
+const edition = "community"
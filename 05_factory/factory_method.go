@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// Factory Method clásico (GoF)
+//
+// En lugar de una función que retorna constructores, cada tipo de producto tiene un
+// ConcreteCreator que implementa el FactoryMethod de la interfaz Creator. La lógica
+// común (PublishProduct) trabaja con cualquier Creator sin conocer el producto concreto.
+// Como Go no tiene herencia, la "operación del Creator" es una función que recibe
+// la interfaz, en lugar de un método de una clase base abstracta.
+
+// Creator declara el factory method que implementan los ConcreteCreators.
+type Creator interface {
+	FactoryMethod(name string, stock int, price float64) (factory.IProduct, error)
+}
+
+// LaptopCreator es el ConcreteCreator de laptops.
+type LaptopCreator struct{}
+
+func (LaptopCreator) FactoryMethod(name string, stock int, price float64) (factory.IProduct, error) {
+	return factory.NewLaptop(name, stock, price)
+}
+
+// DesktopCreator es el ConcreteCreator de computadoras de escritorio.
+type DesktopCreator struct{}
+
+func (DesktopCreator) FactoryMethod(name string, stock int, price float64) (factory.IProduct, error) {
+	return factory.NewDesktop(name, stock, price)
+}
+
+// OutletCreator es un ConcreteCreator con estado: envuelve a otro Creator y crea sus
+// productos con descuento. Es algo que una simple función constructora no puede
+// configurar sin cambiar su firma.
+type OutletCreator struct {
+	Creator
+	Discount float64 // Porcentaje, por ejemplo 0.2 para 20%
+}
+
+func (o OutletCreator) FactoryMethod(name string, stock int, price float64) (factory.IProduct, error) {
+	return o.Creator.FactoryMethod(name+" (outlet)", stock, price*(1-o.Discount))
+}
+
+// PublishProduct es la operación del Creator: crea el producto con el factory method
+// y aplica la lógica común de publicación, sea cual sea el producto concreto.
+func PublishProduct(creator Creator, name string, stock int, price float64) (factory.IProduct, error) {
+	product, err := creator.FactoryMethod(name, stock, price)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("🆕 Publicado:", product)
+	return product, nil
+}
+
+// demonstrateFactoryMethod crea los mismos productos con ambos enfoques:
+// la función factory de pkg/factory y los Creators del Factory Method clásico.
+func demonstrateFactoryMethod() {
+	fmt.Println("\n🏗️ Función factory vs Factory Method clásico:")
+
+	ctor, _ := factory.GetComputerFactory("laptop")
+	product, _ := ctor("Dell XPS", 4, 1499)
+	fmt.Println("🔧 Función factory:", product)
+
+	for _, creator := range []Creator{
+		LaptopCreator{},
+		DesktopCreator{},
+		OutletCreator{Creator: LaptopCreator{}, Discount: 0.2},
+	} {
+		PublishProduct(creator, "Dell XPS", 4, 1499)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// fullUnit es una unidad sin Flyweight: cada una lleva su propia copia de todo el modelo.
+type fullUnit struct {
+	Serial string
+	Model  factory.Model
+}
+
+// heapAlloc retorna los bytes en uso en el heap después de forzar una recolección.
+func heapAlloc() int64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return int64(stats.HeapAlloc)
+}
+
+// demonstrateFlyweight crea muchas unidades de pocos modelos, primero compartiendo
+// el Model con UnitFactory y luego copiándolo en cada unidad, y compara la memoria.
+func demonstrateFlyweight() {
+	const units = 10_000
+	fmt.Printf("\n🪶 Factory + Flyweight: %d unidades de 3 modelos\n", units)
+	models := factory.Models()
+	unitFactory := factory.NewUnitFactory()
+
+	before := heapAlloc()
+	shared := make([]factory.Unit, 0, units)
+	for i := range units {
+		unit, err := unitFactory.NewUnit(models[i%len(models)])
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		shared = append(shared, unit)
+	}
+	sharedBytes := heapAlloc() - before
+
+	before = heapAlloc()
+	copied := make([]fullUnit, 0, units)
+	for _, unit := range shared {
+		model := *unit.Model
+		model.Description = strings.Clone(model.Description) // Cada unidad con su propia ficha
+		copied = append(copied, fullUnit{Serial: unit.Serial, Model: model})
+	}
+	copiedBytes := heapAlloc() - before
+
+	fmt.Println("🔢 Primera y última unidad:", shared[0], "|", shared[len(shared)-1])
+	fmt.Printf("📦 Modelos cargados: %d (las %d unidades apuntan a ellos)\n", unitFactory.LoadedModels(), len(shared))
+	fmt.Printf("🧠 Con Flyweight: %6.2f MB | Sin Flyweight: %6.2f MB\n",
+		float64(sharedBytes)/(1<<20), float64(copiedBytes)/(1<<20))
+	runtime.KeepAlive(shared)
+	runtime.KeepAlive(copied)
+
+	if _, err := unitFactory.NewUnit("smartwatch"); err != nil {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// ShippingMethod es un método de envío de la tienda. No tiene nada que ver con
+// IProduct: factory.Factory sirve para cualquier interfaz.
+type ShippingMethod interface {
+	Cost(weightKg float64) float64
+	Days() int
+}
+
+type standardShipping struct{}
+
+func (standardShipping) Cost(weightKg float64) float64 { return 5 + 1.5*weightKg }
+func (standardShipping) Days() int                     { return 5 }
+
+type expressShipping struct{ surcharge float64 }
+
+func (e expressShipping) Cost(weightKg float64) float64 { return 15 + 3*weightKg + e.surcharge }
+func (expressShipping) Days() int                       { return 1 }
+
+// demonstrateGenericFactory usa factory.Factory con dos tipos distintos: productos
+// y métodos de envío, registrando constructores con y sin error.
+func demonstrateGenericFactory() {
+	fmt.Println("\n🧰 Factory genérica:")
+
+	var shipping factory.Factory[ShippingMethod]
+	factory.Register(&shipping, "estándar", func() ShippingMethod { return standardShipping{} })
+	factory.Register(&shipping, "exprés", func() (ShippingMethod, error) { return expressShipping{surcharge: 4.99}, nil })
+	if err := factory.Register(&shipping, "estándar", func() ShippingMethod { return standardShipping{} }); err != nil {
+		fmt.Println(err)
+	}
+	for _, key := range shipping.Keys() {
+		method, _ := shipping.Create(key)
+		fmt.Printf("🚚 Envío %-8s 2.5kg: $%.2f en %d día(s)\n", key, method.Cost(2.5), method.Days())
+	}
+	if _, err := shipping.Create("drone"); err != nil {
+		fmt.Println(err)
+	}
+
+	// El mismo tipo genérico con productos: cada clave es un producto preconfigurado
+	var bundles factory.Factory[factory.IProduct]
+	factory.Register(&bundles, "estudiante", func() (factory.IProduct, error) {
+		return factory.NewLaptop("Laptop Estudiante", 50, 599)
+	})
+	factory.Register(&bundles, "oficina", func() (factory.IProduct, error) {
+		return factory.NewDesktop("Desktop Oficina", 20, 749)
+	})
+	for _, key := range bundles.Keys() {
+		product, _ := bundles.Create(key)
+		fmt.Printf("🎁 Paquete %-10s %v\n", key, product)
+	}
+}
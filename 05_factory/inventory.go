@@ -0,0 +1,197 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+var (
+	// ErrInsufficientStock indica que no hay unidades disponibles suficientes para reservar.
+	ErrInsufficientStock = errors.New("stock insuficiente")
+	// ErrInvalidQuantity indica una cantidad que no es positiva, o una liberación
+	// de más unidades de las reservadas.
+	ErrInvalidQuantity = errors.New("cantidad inválida")
+	// ErrDuplicateSKU indica que el repositorio ya tiene un producto con el SKU nuevo.
+	ErrDuplicateSKU = errors.New("SKU duplicado")
+)
+
+// Inventory combina Factory y Repository: la factory crea y valida los productos
+// y el repositorio guarda su estado. Todas las operaciones son seguras para uso
+// concurrente: leer, modificar y guardar un registro ocurre bajo el mismo lock, así
+// que dos reservas simultáneas nunca venden la misma unidad.
+type Inventory struct {
+	repository ProductRepository
+	mu         sync.Mutex
+}
+
+// NewInventory crea un inventario sobre el repositorio dado.
+func NewInventory(repository ProductRepository) *Inventory {
+	return &Inventory{repository: repository}
+}
+
+// Create crea un producto con la factory y lo agrega al inventario. Los SKU salen de
+// un contador del proceso, que vuelve a empezar en cada ejecución: si el repositorio
+// ya guarda un producto con ese SKU (por ejemplo, un archivo de una ejecución
+// anterior), falla con ErrDuplicateSKU en lugar de sobrescribirlo.
+func (inv *Inventory) Create(productType, name string, opts ...factory.ProductOption) (InventoryItem, error) {
+	ctor, err := factory.GetProductFactory(productType)
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	product, err := ctor(name, opts...)
+	if err != nil {
+		return InventoryItem{}, err
+	}
+
+	item := InventoryItem{
+		SKU:   product.SKU(),
+		Type:  product.Type(),
+		Name:  product.Name(),
+		Price: product.Price(),
+		Stock: product.Stock(),
+	}
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	if _, err := inv.repository.FindBySKU(item.SKU); err == nil {
+		return InventoryItem{}, fmt.Errorf("❌ crear %s: %w", item.SKU, ErrDuplicateSKU)
+	} else if !errors.Is(err, ErrProductNotFound) {
+		return InventoryItem{}, err
+	}
+	if err := inv.repository.Save(item); err != nil {
+		return InventoryItem{}, err
+	}
+	return item, nil
+}
+
+// Get retorna el registro del producto sku.
+func (inv *Inventory) Get(sku string) (InventoryItem, error) {
+	return inv.repository.FindBySKU(sku)
+}
+
+// List retorna todos los registros, ordenados por SKU.
+func (inv *Inventory) List() ([]InventoryItem, error) {
+	return inv.repository.List()
+}
+
+// AddStock suma quantity unidades al stock de sku.
+func (inv *Inventory) AddStock(sku string, quantity int) (InventoryItem, error) {
+	return inv.update(sku, quantity, func(item *InventoryItem) error {
+		item.Stock += quantity
+		return nil
+	})
+}
+
+// Reserve aparta quantity unidades de sku, por ejemplo mientras se paga un pedido.
+// Falla con ErrInsufficientStock si no hay tantas disponibles.
+func (inv *Inventory) Reserve(sku string, quantity int) (InventoryItem, error) {
+	return inv.update(sku, quantity, func(item *InventoryItem) error {
+		if quantity > item.Available() {
+			return fmt.Errorf("❌ reservar %d de %s con %d disponibles: %w", quantity, sku, item.Available(), ErrInsufficientStock)
+		}
+		item.Reserved += quantity
+		return nil
+	})
+}
+
+// Release devuelve quantity unidades reservadas de sku, por ejemplo si se cancela el pedido.
+func (inv *Inventory) Release(sku string, quantity int) (InventoryItem, error) {
+	return inv.update(sku, quantity, func(item *InventoryItem) error {
+		if quantity > item.Reserved {
+			return fmt.Errorf("❌ liberar %d de %s con %d reservadas: %w", quantity, sku, item.Reserved, ErrInvalidQuantity)
+		}
+		item.Reserved -= quantity
+		return nil
+	})
+}
+
+// update lee el registro de sku, le aplica change y lo guarda, todo bajo inv.mu.
+func (inv *Inventory) update(sku string, quantity int, change func(*InventoryItem) error) (InventoryItem, error) {
+	if quantity <= 0 {
+		return InventoryItem{}, fmt.Errorf("❌ cantidad %d: %w", quantity, ErrInvalidQuantity)
+	}
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	item, err := inv.repository.FindBySKU(sku)
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	if err := change(&item); err != nil {
+		return InventoryItem{}, err
+	}
+	if err := inv.repository.Save(item); err != nil {
+		return InventoryItem{}, err
+	}
+	return item, nil
+}
+
+// demonstrateInventory muestra el inventario con ambos repositorios: reservas
+// concurrentes en memoria, y un inventario en archivo que sobrevive a reabrirse.
+func demonstrateInventory() {
+	fmt.Println("\n🏬 Inventario con Factory + Repository:")
+	inventory := NewInventory(&MemoryRepository{})
+	item, err := inventory.Create("laptop", "MacBook Air", factory.WithStock(10), factory.WithPrice(1299))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// 25 clientes intentan reservar una unidad a la vez: solo 10 lo consiguen
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved, rejected := 0, 0
+	for range 25 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := inventory.Reserve(item.SKU, 1)
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, ErrInsufficientStock) {
+				rejected++
+			} else if err == nil {
+				reserved++
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("🛒 Reservas concurrentes: %d aceptadas, %d rechazadas por falta de stock\n", reserved, rejected)
+
+	inventory.Release(item.SKU, 3) // Tres pedidos cancelados
+	item, _ = inventory.AddStock(item.SKU, 5)
+	fmt.Printf("📦 %s: stock %d, reservadas %d, disponibles %d\n", item.Name, item.Stock, item.Reserved, item.Available())
+	if _, err := inventory.Release(item.SKU, 50); err != nil {
+		fmt.Println(err)
+	}
+	if _, err := inventory.Reserve("LAP-9999", 1); err != nil {
+		fmt.Println(err)
+	}
+
+	// Mismo Inventory, otro repositorio: los datos sobreviven a reabrir el archivo
+	path := filepath.Join(os.TempDir(), "factory_inventory.json")
+	os.Remove(path)
+	defer os.Remove(path)
+	repository, err := NewFileRepository(path)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fileInventory := NewInventory(repository)
+	server, _ := fileInventory.Create("server", "PowerEdge R760", factory.WithStock(4), factory.WithPrice(8999))
+	fileInventory.Reserve(server.SKU, 2)
+
+	reopened, err := NewFileRepository(path)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	items, _ := NewInventory(reopened).List()
+	for _, item := range items {
+		fmt.Printf("💾 Desde %s: %s %s, stock %d, reservadas %d\n", filepath.Base(path), item.SKU, item.Name, item.Stock, item.Reserved)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// TestCreateKeepsPersistedItems simula una ejecución anterior que dejó en el archivo
+// el producto con el próximo SKU: como el contador de SKU es del proceso, el nuevo
+// Create lo repite y debe fallar en lugar de sobrescribirlo.
+func TestCreateKeepsPersistedItems(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.json")
+	repository, err := NewFileRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileRepository: %v", err)
+	}
+	first, err := NewInventory(repository).Create("laptop", "MacBook Air", factory.WithStock(3))
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var sequence int
+	if _, err := fmt.Sscanf(first.SKU, "LAP-%d", &sequence); err != nil {
+		t.Fatalf("SKU %q: %v", first.SKU, err)
+	}
+	persisted := InventoryItem{SKU: fmt.Sprintf("LAP-%04d", sequence+1), Type: "laptop", Name: "ThinkPad X1", Stock: 7, Reserved: 2}
+	if err := repository.Save(persisted); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	reopened, err := NewFileRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileRepository al reabrir: %v", err)
+	}
+	inventory := NewInventory(reopened)
+	if _, err := inventory.Create("laptop", "Dell XPS 13"); !errors.Is(err, ErrDuplicateSKU) {
+		t.Errorf("Create con un SKU ya guardado: err = %v, quiero ErrDuplicateSKU", err)
+	}
+	if got, err := inventory.Get(persisted.SKU); err != nil || got != persisted {
+		t.Errorf("Get(%s) = (%+v, %v), quiero (%+v, <nil>)", persisted.SKU, got, err, persisted)
+	}
+
+	// El siguiente SKU está libre: Create vuelve a funcionar
+	if item, err := inventory.Create("laptop", "Dell XPS 13"); err != nil {
+		t.Errorf("Create con un SKU libre: %v", err)
+	} else if want := fmt.Sprintf("LAP-%04d", sequence+2); item.SKU != want {
+		t.Errorf("SKU = %s, quiero %s", item.SKU, want)
+	}
+}
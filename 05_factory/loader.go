@@ -0,0 +1,71 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// defaultCatalog es el catálogo de ejemplo, embebido en el binario para que el demo
+// funcione sin importar desde qué directorio se ejecute.
+//
+//go:embed catalog.json
+var defaultCatalog []byte
+
+// ProductSpec describe un producto en un archivo de catálogo.
+type ProductSpec struct {
+	Type  string  `json:"type"`
+	Name  string  `json:"name"`
+	Stock int     `json:"stock"`
+	Price float64 `json:"price"`
+}
+
+// LoadCatalog lee una lista de ProductSpec en JSON y crea cada producto con la factory.
+// El loader no conoce los tipos concretos: cualquier tipo registrado con RegisterProduct
+// se puede usar en el archivo. Los productos se crean en paralelo con CreateBatch, se
+// validan todas las entradas y se reportan todos los errores juntos, para que se puedan
+// corregir de una sola vez.
+func LoadCatalog(r io.Reader) ([]factory.IProduct, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields() // Un campo mal escrito es un error, no se ignora
+	var specs []ProductSpec
+	if err := decoder.Decode(&specs); err != nil {
+		return nil, fmt.Errorf("❌ catálogo inválido: %w", err)
+	}
+
+	created, createErrs := CreateBatch(specs)
+	var products []factory.IProduct
+	var errs []error
+	for i, err := range createErrs {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("producto %d (%q): %w", i+1, specs[i].Name, err))
+			continue
+		}
+		products = append(products, created[i])
+	}
+	return products, errors.Join(errs...)
+}
+
+// LoadCatalogFile es LoadCatalog sobre un archivo.
+func LoadCatalogFile(path string) ([]factory.IProduct, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("❌ no se pudo abrir el catálogo: %w", err)
+	}
+	defer file.Close()
+	return LoadCatalog(file)
+}
+
+// build crea el producto con la factory, que valida la especificación.
+func (s ProductSpec) build() (factory.IProduct, error) {
+	ctor, err := factory.GetComputerFactory(s.Type)
+	if err != nil {
+		return nil, err
+	}
+	return ctor(s.Name, s.Stock, s.Price) // El constructor valida nombre, stock y precio
+}
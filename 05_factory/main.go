@@ -12,128 +12,127 @@ Ventajas:
 - Permite la reutilización de código
 
 En este ejemplo:
-- IProduct es la interfaz común para todos los productos
-- Computer es la estructura base que implementa la funcionalidad común
-- Laptop y Desktop son productos concretos que extienden Computer
-- GetComputerFactory es la función factory que retorna constructores específicos
+  - IProduct es la interfaz común para todos los productos (en pkg/factory)
+  - Computer es la estructura base que implementa la funcionalidad común
+  - Laptop, Desktop, Tablet y Server son productos concretos que extienden Computer
+  - Cada producto se registra a sí mismo con RegisterProduct en su propio archivo
+  - GetComputerFactory es la función factory que retorna constructores específicos
+    buscándolos en el registro, sin conocer los productos concretos
 */
 package main
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
 
-// IProduct define la interfaz común para todos los productos que puede crear la factory
-// Establece el contrato que deben cumplir todos los productos concretos
-type IProduct interface {
-	setStock(stock int)
-	getStock() int
-	setName(name string)
-	getName() string
-}
-
-// Computer es la estructura base que contiene los campos comunes
-// para todos los tipos de computadoras (Laptop y Desktop)
-type Computer struct {
-	name  string
-	stock int
-}
-
-func (c *Computer) setStock(stock int) {
-	c.stock = stock
-}
-
-func (c *Computer) getStock() int {
-	return c.stock
-}
-
-func (c *Computer) setName(name string) {
-	c.name = name
-}
-
-func (c *Computer) getName() string {
-	return c.name
-}
-
-// Laptop representa un producto concreto de tipo laptop
-// Utiliza composición para heredar funcionalidad de Computer
-type Laptop struct {
-	Computer
-}
-
-// NewLaptop es el constructor para crear instancias de Laptop
-// Retorna una interfaz IProduct para mantener el polimorfismo
-func NewLaptop(name string, stock int) IProduct {
-	return &Laptop{
-		Computer: Computer{
-			name:  name,
-			stock: stock,
-		},
-	}
-}
-
-// Desktop representa un producto concreto de tipo computadora de escritorio
-// Utiliza composición para heredar funcionalidad de Computer
-type Desktop struct {
-	Computer
-}
-
-// NewDesktop es el constructor para crear instancias de Desktop
-// Retorna una interfaz IProduct para mantener el polimorfismo
-func NewDesktop(name string, stock int) IProduct {
-	return &Desktop{
-		Computer: Computer{
-			name:  name,
-			stock: stock,
-		},
-	}
-}
-
-// GetComputerFactory es la función factory principal del patrón
-// Retorna una función constructora específica basada en el tipo solicitado
-// Parámetros:
-//   - ComputerType: string que especifica el tipo de computadora ("laptop" o "desktop")
-// Retorna:
-//   - Una función constructora específica para el tipo solicitado
-//   - Un error si el tipo no es válido
-func GetComputerFactory(ComputerType string) (func(name string, stock int) IProduct, error) {
-	if ComputerType == "laptop" {
-		return NewLaptop, nil
-	}
-	if ComputerType == "desktop" {
-		return NewDesktop, nil
-	}
-	return nil, fmt.Errorf("❌ Invalid computer type: %s", ComputerType)
-}
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
 
 // printNameAndStock es una función auxiliar para mostrar información del producto
 // Demuestra el polimorfismo al trabajar con la interfaz IProduct
-func printNameAndStock(product IProduct) {
-	fmt.Printf("📦 Product Name: %s, 📊 Stock: %d\n", product.getName(), product.getStock())
+func printNameAndStock(product factory.IProduct) {
+	fmt.Printf("📦 Product Name: %s, 📊 Stock: %d\n", product.Name(), product.Stock())
 }
 
 // main demuestra el uso del patrón Factory
 func main() {
 	// 1. Obtener la función factory para laptops
-	laptopFactory, err := GetComputerFactory("laptop")
+	laptopFactory, err := factory.GetComputerFactory("laptop")
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
 	// 2. Crear un producto laptop usando la factory
-	laptop := laptopFactory("MacBook Pro", 10)
+	laptop, err := laptopFactory("MacBook Pro", 10, 2499.99)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
 	printNameAndStock(laptop)
 
 	// 3. Obtener la función factory para computadoras de escritorio
-	desktopFactory, err := GetComputerFactory("desktop")
+	desktopFactory, err := factory.GetComputerFactory("desktop")
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
 	// 4. Crear productos desktop usando la misma factory
-	iMacDesktop := desktopFactory("iMac", 5)
+	iMacDesktop, err := desktopFactory("iMac", 5, 1799)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
 	printNameAndStock(iMacDesktop)
 
-	legionDesktop := desktopFactory("Lenovo Legion", 8)
+	legionDesktop, err := desktopFactory("Lenovo Legion", 8, 1599.50)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
 	printNameAndStock(legionDesktop)
+
+	demonstrateRegistry()
+	demonstrateCatalogLoader()
+	demonstrateFactoryMethod()
+	demonstratePrototype()
+	demonstrateBuilder()
+	demonstrateValidation()
+	demonstrateOptions()
+	demonstrateInventory()
+	demonstrateGenericFactory()
+	demonstrateFlyweight()
+	demonstrateCatalog()
+	demonstrateBatch()
+}
+
+// demonstrateRegistry recorre todos los tipos registrados, incluidos Tablet y Server,
+// que se agregaron sin tocar GetComputerFactory. Con go run -tags enterprise ./05_factory
+// aparece además Workstation, que solo se compila con esa etiqueta.
+func demonstrateRegistry() {
+	fmt.Printf("\n🗂️ Tipos registrados en la edición %s: %v\n", edition, factory.RegisteredProducts())
+	for _, productType := range append(factory.RegisteredProducts(), "smartwatch") {
+		ctor, err := factory.GetComputerFactory(productType)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		product, err := ctor("Modelo "+productType, 1, 100)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println("📦", product)
+	}
+}
+
+// demonstrateCatalogLoader crea los productos descritos en catalog.json (o en el
+// archivo pasado como argumento: go run ./05_factory mi_catalogo.json), y luego
+// intenta cargar un catálogo con errores para mostrar la validación.
+func demonstrateCatalogLoader() {
+	fmt.Println("\n📄 Catálogo desde JSON:")
+	products, err := LoadCatalog(bytes.NewReader(defaultCatalog))
+	if len(os.Args) > 1 {
+		products, err = LoadCatalogFile(os.Args[1])
+	}
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, product := range products {
+		fmt.Println("📦", product)
+	}
+
+	fmt.Println("\n📄 Catálogo con errores:")
+	invalid := `[
+		{"type": "laptop", "name": "XPS 13", "stock": 3, "price": 1299},
+		{"type": "smartwatch", "name": "Watch", "stock": 1, "price": 399},
+		{"type": "desktop", "name": "", "stock": 2, "price": 999},
+		{"type": "tablet", "name": "Galaxy Tab", "stock": -4, "price": 499}
+	]`
+	products, err = LoadCatalog(strings.NewReader(invalid))
+	fmt.Printf("✅ %d producto(s) válido(s)\n%v\n", len(products), err)
 }
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// demonstrateOptions crea productos con los constructores con opciones funcionales
+// que retorna GetProductFactory: cada llamada indica solo lo que necesita.
+func demonstrateOptions() {
+	fmt.Println("\n⚙️ Factory con opciones funcionales:")
+	newLaptop, err := factory.GetProductFactory("laptop")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	products := []struct {
+		name string
+		opts []factory.ProductOption
+	}{
+		{"Chromebook", nil}, // Todo por defecto
+		{"MacBook Pro", []factory.ProductOption{factory.WithStock(10), factory.WithPrice(2499.99)}},
+		{"ThinkPad X1", []factory.ProductOption{
+			factory.WithStock(3),
+			factory.WithPrice(1899),
+			factory.WithWarranty(36),
+			factory.WithAttribute("teclado", "español"),
+		}},
+	}
+	for _, p := range products {
+		product, err := newLaptop(p.name, p.opts...)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Printf("💻 %v 🛡️ %d meses\n", product, product.Warranty())
+	}
+
+	_, err = newLaptop("Sin garantía", factory.WithStock(-2), factory.WithWarranty(-6))
+	fmt.Printf("🚫 errors.Is(ErrInvalidWarranty): %t, errors.Is(ErrInvalidStock): %t\n",
+		errors.Is(err, factory.ErrInvalidWarranty), errors.Is(err, factory.ErrInvalidStock))
+	fmt.Println(err)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// demonstratePrototype registra plantillas preconfiguradas y crea productos
+// clonándolas. Los clones son copias profundas: cambiar los atributos de uno no
+// afecta a la plantilla ni a los demás clones.
+func demonstratePrototype() {
+	fmt.Println("\n🧬 Factory basada en prototipos:")
+
+	macbook, _ := factory.NewLaptop("MacBook Air M3", 20, 1299)
+	macbook.SetAttribute("color", "medianoche")
+	macbook.SetAttribute("teclado", "español")
+	factory.RegisterPrototype("macbook-base", macbook)
+
+	gaming, _ := factory.NewDesktop("Gaming Tower", 3, 2199)
+	gaming.SetAttribute("gpu", "RTX 4080")
+	factory.RegisterPrototype("gaming-base", gaming)
+	fmt.Println("🗂️ Plantillas:", factory.RegisteredPrototypes())
+
+	// Con un constructor hay que repetir toda la configuración; con la plantilla, no
+	first, _ := factory.FromPrototype("macbook-base")
+	second, _ := factory.FromPrototype("macbook-base")
+	second.SetAttribute("color", "plata")
+	second.SetAttribute("teclado", "inglés")
+	fmt.Println("🐑 Clon 1:", first)
+	fmt.Println("🐑 Clon 2:", second)
+	tower, _ := factory.FromPrototype("gaming-base")
+	fmt.Println("🐑 Clon 3:", tower)
+
+	template, _ := factory.FromPrototype("macbook-base")
+	fmt.Println("🧾 La plantilla sigue igual:", template.Attribute("color"), "/", template.Attribute("teclado"))
+
+	if _, err := factory.FromPrototype("tablet-base"); err != nil {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// ErrProductNotFound indica que no hay ningún producto con el SKU pedido.
+var ErrProductNotFound = errors.New("producto no encontrado")
+
+// InventoryItem es el registro que guarda el repositorio por cada producto: los datos
+// del producto más las unidades reservadas. Es un struct simple para que cualquier
+// implementación del repositorio pueda guardarlo (en memoria, en un archivo, en una BD).
+type InventoryItem struct {
+	SKU      string  `json:"sku"`
+	Type     string  `json:"type"`
+	Name     string  `json:"name"`
+	Price    float64 `json:"price"`
+	Stock    int     `json:"stock"`
+	Reserved int     `json:"reserved"`
+}
+
+// Available retorna las unidades que se pueden reservar.
+func (i InventoryItem) Available() int {
+	return i.Stock - i.Reserved
+}
+
+// ProductRepository guarda y recupera los registros del inventario (patrón Repository).
+// Inventory no sabe dónde quedan guardados: cambiar de almacenamiento es cambiar de
+// implementación.
+type ProductRepository interface {
+	Save(item InventoryItem) error
+	FindBySKU(sku string) (InventoryItem, error) // ErrProductNotFound si no existe
+	List() ([]InventoryItem, error)              // Ordenados por SKU
+}
+
+// MemoryRepository guarda los registros en memoria.
+type MemoryRepository struct {
+	items syncutil.SafeMap[string, InventoryItem]
+}
+
+func (r *MemoryRepository) Save(item InventoryItem) error {
+	r.items.Set(item.SKU, item)
+	return nil
+}
+
+func (r *MemoryRepository) FindBySKU(sku string) (InventoryItem, error) {
+	item, ok := r.items.Get(sku)
+	if !ok {
+		return InventoryItem{}, fmt.Errorf("❌ SKU %s: %w", sku, ErrProductNotFound)
+	}
+	return item, nil
+}
+
+func (r *MemoryRepository) List() ([]InventoryItem, error) {
+	var items []InventoryItem
+	r.items.Range(func(_ string, item InventoryItem) bool {
+		items = append(items, item)
+		return true
+	})
+	slices.SortFunc(items, func(a, b InventoryItem) int { return strings.Compare(a.SKU, b.SKU) })
+	return items, nil
+}
+
+// FileRepository guarda los registros en un archivo JSON. Mantiene una copia en
+// memoria y reescribe el archivo completo en cada Save, primero en un archivo temporal
+// que luego se renombra, para que un corte a mitad de escritura no lo deje corrupto.
+type FileRepository struct {
+	path  string
+	mu    sync.RWMutex
+	items map[string]InventoryItem
+}
+
+// NewFileRepository abre el repositorio de path, cargando los registros que ya tenga.
+func NewFileRepository(path string) (*FileRepository, error) {
+	r := &FileRepository{path: path, items: make(map[string]InventoryItem)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil // Repositorio nuevo
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ no se pudo leer el inventario: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.items); err != nil {
+		return nil, fmt.Errorf("❌ inventario corrupto en %s: %w", path, err)
+	}
+	return r, nil
+}
+
+func (r *FileRepository) Save(item InventoryItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	previous, existed := r.items[item.SKU]
+	r.items[item.SKU] = item
+	if err := r.write(); err != nil {
+		// Si no se pudo escribir, la memoria vuelve a coincidir con el archivo
+		if existed {
+			r.items[item.SKU] = previous
+		} else {
+			delete(r.items, item.SKU)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *FileRepository) FindBySKU(sku string) (InventoryItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	item, ok := r.items[sku]
+	if !ok {
+		return InventoryItem{}, fmt.Errorf("❌ SKU %s: %w", sku, ErrProductNotFound)
+	}
+	return item, nil
+}
+
+func (r *FileRepository) List() ([]InventoryItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	items := make([]InventoryItem, 0, len(r.items))
+	for _, sku := range slices.Sorted(maps.Keys(r.items)) {
+		items = append(items, r.items[sku])
+	}
+	return items, nil
+}
+
+// write guarda todos los registros en el archivo. Se llama con r.mu tomado.
+func (r *FileRepository) write() error {
+	data, err := json.MarshalIndent(r.items, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("❌ no se pudo guardar el inventario: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No hace nada si el rename funcionó
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("❌ no se pudo guardar el inventario: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("❌ no se pudo guardar el inventario: %w", err)
+	}
+	return os.Rename(tmp.Name(), r.path)
+}
@@ -0,0 +1,32 @@
+package main
+
+import "github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+
+// Server representa un producto concreto de tipo servidor.
+// Vive fuera de pkg/factory y se registra solo, junto con su modelo rack-server:
+// la factory no sabe que existe.
+type Server struct {
+	factory.Computer
+}
+
+func init() {
+	factory.RegisterProduct("server", NewServer)
+	// El modelo usa el tipo "server", así que se registra junto a él y no en pkg/factory
+	factory.RegisterModel("rack-server", func() *factory.ComputerBuilder {
+		return factory.NewComputerBuilder("server", "Rack 2U").
+			CPU("2x Xeon Gold").RAM(256).Storage(8192).Price(11999)
+	})
+}
+
+// NewServer es el constructor para crear instancias de Server
+func NewServer(name string, stock int, price float64) (factory.IProduct, error) {
+	computer, err := factory.NewComputer("server", "SRV", name, stock, price)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{Computer: computer}, nil
+}
+
+func (s *Server) Clone() factory.IProduct {
+	return &Server{Computer: s.CloneComputer()}
+}
@@ -0,0 +1,26 @@
+package main
+
+import "github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+
+// Tablet representa un producto concreto de tipo tablet.
+// Vive fuera de pkg/factory y se registra solo: la factory no sabe que existe.
+type Tablet struct {
+	factory.Computer
+}
+
+func init() {
+	factory.RegisterProduct("tablet", NewTablet)
+}
+
+// NewTablet es el constructor para crear instancias de Tablet
+func NewTablet(name string, stock int, price float64) (factory.IProduct, error) {
+	computer, err := factory.NewComputer("tablet", "TAB", name, stock, price)
+	if err != nil {
+		return nil, err
+	}
+	return &Tablet{Computer: computer}, nil
+}
+
+func (t *Tablet) Clone() factory.IProduct {
+	return &Tablet{Computer: t.CloneComputer()}
+}
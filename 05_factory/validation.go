@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// demonstrateValidation recorre una tabla de casos de creación, válidos e inválidos,
+// y comprueba cada error con errors.Is y errors.As, como lo haría un test table-driven.
+func demonstrateValidation() {
+	fmt.Println("\n🧪 Validación y errores tipados de la factory:")
+	cases := []struct {
+		description string
+		productType string
+		name        string
+		stock       int
+		price       float64
+		wantErr     error // nil si la creación debe funcionar
+	}{
+		{"producto válido", "laptop", "MacBook Pro", 10, 2499, nil},
+		{"stock cero es válido", "desktop", "iMac", 0, 1799, nil},
+		{"tipo desconocido", "smartwatch", "Watch", 1, 399, factory.ErrUnknownProductType},
+		{"nombre vacío", "tablet", "", 5, 599, factory.ErrInvalidName},
+		{"stock negativo", "server", "PowerEdge", -1, 8999, factory.ErrInvalidStock},
+		{"precio negativo", "laptop", "ThinkPad", 3, -10, factory.ErrInvalidPrice},
+	}
+
+	failures := 0
+	for _, tc := range cases {
+		err := createProduct(tc.productType, tc.name, tc.stock, tc.price)
+		if !errors.Is(err, tc.wantErr) { // errors.Is(nil, nil) es true
+			failures++
+			fmt.Printf("   ❌ %s: se esperaba %v, se obtuvo %v\n", tc.description, tc.wantErr, err)
+			continue
+		}
+
+		detail := "creado"
+		var validationErr *factory.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			detail = fmt.Sprintf("campo %q con valor %#v", validationErr.Field, validationErr.Value)
+		case err != nil:
+			detail = err.Error()
+		}
+		fmt.Printf("   ✅ %-22s %s\n", tc.description+":", detail)
+	}
+	fmt.Printf("📊 %d de %d casos correctos\n", len(cases)-failures, len(cases))
+}
+
+// createProduct busca el constructor del tipo y crea el producto, retornando solo el error.
+func createProduct(productType, name string, stock int, price float64) error {
+	ctor, err := factory.GetComputerFactory(productType)
+	if err != nil {
+		return err
+	}
+	_, err = ctor(name, stock, price)
+	return err
+}
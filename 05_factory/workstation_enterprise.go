@@ -0,0 +1,33 @@
+//go:build enterprise
+
+package main
+
+import "github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+
+// Este archivo solo se compila con go run -tags enterprise ./05_factory.
+// Sin la etiqueta, el tipo "workstation" no existe en el catálogo, y ni la factory
+// ni main necesitan saberlo.
+
+const edition = "enterprise"
+
+// Workstation representa una estación de trabajo, disponible solo en la edición enterprise.
+type Workstation struct {
+	factory.Computer
+}
+
+func init() {
+	factory.RegisterProduct("workstation", NewWorkstation)
+}
+
+// NewWorkstation es el constructor para crear instancias de Workstation
+func NewWorkstation(name string, stock int, price float64) (factory.IProduct, error) {
+	computer, err := factory.NewComputer("workstation", "WKS", name, stock, price)
+	if err != nil {
+		return nil, err
+	}
+	return &Workstation{Computer: computer}, nil
+}
+
+func (w *Workstation) Clone() factory.IProduct {
+	return &Workstation{Computer: w.CloneComputer()}
+}
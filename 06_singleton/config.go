@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// AppConfig es la configuración de la aplicación leída de un archivo JSON. Una vez
+// publicada nunca se modifica: cada recarga crea una nueva.
+type AppConfig struct {
+	Version        int             `json:"version"`
+	DatabaseURL    string          `json:"database_url"`
+	MaxConnections int             `json:"max_connections"`
+	Features       map[string]bool `json:"features"`
+}
+
+// ConfigListener se notifica con la configuración anterior y la nueva tras cada recarga.
+type ConfigListener func(old, updated *AppConfig)
+
+// ConfigManager es el singleton de configuración. Las lecturas son una carga atómica
+// sin locks, así que muchas goroutines pueden leer mientras otra recarga el archivo.
+// Quien quiera reaccionar a los cambios se suscribe (patrón observer).
+type ConfigManager struct {
+	path    string
+	current atomic.Pointer[AppConfig]
+
+	mu        sync.Mutex // Serializa las recargas y protege listeners y modTime
+	listeners []ConfigListener
+	modTime   time.Time
+}
+
+// configInstance crea el ConfigManager la primera vez que se pide, leyendo el archivo
+// indicado en la variable de entorno CONFIG_FILE (config.json por defecto).
+var configInstance = syncutil.NewLazy(func() (*ConfigManager, error) {
+	path := os.Getenv("CONFIG_FILE")
+	if path == "" {
+		path = "config.json"
+	}
+	m := &ConfigManager{path: path}
+	if err := m.Reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
+})
+
+// GetConfig retorna el singleton de configuración.
+func GetConfig() (*ConfigManager, error) {
+	return configInstance.Get()
+}
+
+// Get retorna la configuración actual. No se debe modificar.
+func (m *ConfigManager) Get() *AppConfig {
+	return m.current.Load()
+}
+
+// Subscribe registra un listener que se llamará tras cada recarga exitosa.
+func (m *ConfigManager) Subscribe(listener ConfigListener) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+// Reload vuelve a leer el archivo y, si es válido, reemplaza la configuración de una
+// vez y notifica a los suscriptores. Si el archivo no se puede leer o es inválido, se
+// conserva la configuración anterior y se retorna el error. Los suscriptores se llaman
+// sin el lock tomado, así que pueden llamar a Subscribe o Reload.
+func (m *ConfigManager) Reload() error {
+	m.mu.Lock()
+	old, updated, err := m.load()
+	listeners := slices.Clone(m.listeners)
+	m.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if old != nil {
+		for _, listener := range listeners {
+			listener(old, updated)
+		}
+	}
+	return nil
+}
+
+// load lee y valida el archivo y publica la nueva configuración. Retorna la anterior
+// y la nueva. Se llama con m.mu tomado.
+func (m *ConfigManager) load() (old, updated *AppConfig, err error) {
+	info, err := os.Stat(m.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("❌ no se pudo leer la configuración: %w", err)
+	}
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("❌ no se pudo leer la configuración: %w", err)
+	}
+	updated = new(AppConfig)
+	if err := json.Unmarshal(data, updated); err != nil {
+		return nil, nil, fmt.Errorf("❌ configuración inválida en %s: %w", m.path, err)
+	}
+	if updated.MaxConnections <= 0 {
+		return nil, nil, fmt.Errorf("❌ configuración inválida en %s: max_connections debe ser positivo", m.path)
+	}
+
+	old = m.current.Swap(updated)
+	m.modTime = info.ModTime()
+	return old, updated, nil
+}
+
+// Watch revisa el archivo cada interval y lo recarga cuando cambia su fecha de
+// modificación, hasta que se cancela ctx. Es un sondeo simple: la librería estándar
+// no tiene notificaciones del sistema de archivos.
+func (m *ConfigManager) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		info, err := os.Stat(m.path)
+		if err != nil {
+			continue
+		}
+		m.mu.Lock()
+		changed := !info.ModTime().Equal(m.modTime)
+		m.mu.Unlock()
+		if changed {
+			if err := m.Reload(); err != nil {
+				fmt.Println("⚠️ Recarga automática fallida:", err)
+			}
+		}
+	}
+}
+
+// writeConfigFile escribe una configuración de ejemplo en path.
+func writeConfigFile(path string, config AppConfig) error {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// demonstrateHotReload lanza lectores que consultan la configuración sin parar mientras
+// el archivo cambia: el watcher la recarga, los suscriptores reciben el cambio y un
+// archivo inválido no reemplaza la configuración vigente.
+func demonstrateHotReload() {
+	fmt.Println("\n🔥 Configuración con recarga en caliente:")
+	path := filepath.Join(os.TempDir(), "singleton_config.json")
+	defer os.Remove(path)
+	if err := writeConfigFile(path, AppConfig{Version: 1, DatabaseURL: defaultConnectionString, MaxConnections: 10,
+		Features: map[string]bool{"beta": false}}); err != nil {
+		fmt.Println(err)
+		return
+	}
+	os.Setenv("CONFIG_FILE", path)
+	defer os.Unsetenv("CONFIG_FILE")
+
+	config, err := GetConfig()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("📄 Versión %d: max_connections=%d\n", config.Get().Version, config.Get().MaxConnections)
+
+	reloaded := make(chan struct{}, 1)
+	config.Subscribe(func(old, updated *AppConfig) {
+		fmt.Printf("🔔 Configuración %d -> %d: max_connections %d -> %d, beta %t -> %t\n",
+			old.Version, updated.Version, old.MaxConnections, updated.MaxConnections,
+			old.Features["beta"], updated.Features["beta"])
+		select {
+		case reloaded <- struct{}{}:
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchCtx, stopWatching := context.WithCancel(ctx)
+	watching := make(chan struct{})
+	go func() {
+		defer close(watching)
+		config.Watch(watchCtx, 20*time.Millisecond)
+	}()
+
+	// Lectores concurrentes: cada uno ve una foto completa de alguna versión
+	var wg sync.WaitGroup
+	var reads atomic.Int64
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				if config.Get().MaxConnections > 0 {
+					reads.Add(1)
+				}
+			}
+		}()
+	}
+
+	// La versión nueva se escribe aparte y se renombra, para que el watcher nunca lea
+	// un archivo a medio escribir. La fecha se adelanta por si el sistema de archivos
+	// tiene poca precisión.
+	time.Sleep(50 * time.Millisecond)
+	writeConfigFile(path+".tmp", AppConfig{Version: 2, DatabaseURL: defaultConnectionString, MaxConnections: 25,
+		Features: map[string]bool{"beta": true}})
+	os.Chtimes(path+".tmp", time.Now(), time.Now().Add(time.Second))
+	os.Rename(path+".tmp", path)
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		fmt.Println("❌ El watcher no detectó el cambio")
+	}
+	stopWatching()
+	<-watching
+
+	os.WriteFile(path, []byte("{ esto no es JSON"), 0o644)
+	fmt.Println("🛡️ Reload con archivo inválido:", config.Reload())
+	fmt.Printf("📄 Sigue vigente la versión %d\n", config.Get().Version)
+
+	cancel()
+	wg.Wait()
+	fmt.Printf("✅ %d lecturas concurrentes sin locks durante las recargas\n", reads.Load())
+}
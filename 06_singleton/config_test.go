@@ -0,0 +1,54 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestListenersCanSubscribeAndReload comprueba que un suscriptor puede reaccionar a un
+// cambio registrando otro suscriptor o recargando, sin quedar bloqueado por Reload.
+func TestListenersCanSubscribeAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := writeConfigFile(path, AppConfig{Version: 1, MaxConnections: 10}); err != nil {
+		t.Fatal(err)
+	}
+	m := &ConfigManager{path: path}
+	if err := m.Reload(); err != nil {
+		t.Fatalf("Reload inicial: %v", err)
+	}
+
+	var versions []int
+	reloadedAgain := false
+	m.Subscribe(func(old, updated *AppConfig) {
+		versions = append(versions, updated.Version)
+		if !reloadedAgain {
+			reloadedAgain = true
+			m.Subscribe(func(old, updated *AppConfig) {})
+			if err := m.Reload(); err != nil {
+				t.Errorf("Reload desde un suscriptor: %v", err)
+			}
+		}
+	})
+
+	if err := writeConfigFile(path, AppConfig{Version: 2, MaxConnections: 20}); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- m.Reload() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Reload: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Reload quedó bloqueado: los suscriptores se llaman con el lock tomado")
+	}
+
+	if got := m.Get().Version; got != 2 {
+		t.Errorf("versión = %d, quiero 2", got)
+	}
+	if len(versions) != 2 || len(m.listeners) != 2 {
+		t.Errorf("el suscriptor recibió las versiones %v y hay %d suscriptores, quiero 2 avisos y 2 suscriptores", versions, len(m.listeners))
+	}
+}
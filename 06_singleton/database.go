@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// defaultConnectionString se usa si la variable de entorno DATABASE_URL no está definida.
+const defaultConnectionString = "mockdb://admin@localhost:5432/tienda?latency=2s"
+
+// DataBase es el recurso compartido que el singleton protege: envuelve un *sql.DB,
+// que ya es un pool de conexiones seguro para uso concurrente.
+type DataBase struct {
+	connectionString string
+
+	mu              sync.RWMutex // Protege db, que Reconnect reemplaza, y el health check
+	db              *sql.DB
+	healthy         atomic.Bool
+	stopHealthCheck context.CancelFunc
+	healthCheckDone chan struct{}
+}
+
+// Database es lo que la aplicación necesita de la base de datos. La implementan el
+// singleton real (*DataBase) y MockDatabase.
+type Database interface {
+	Products(ctx context.Context) ([]Product, error)
+	ProductByID(ctx context.Context, id int64) (Product, error)
+	Ping(timeout time.Duration) error
+	Close() error
+}
+
+// Product es una fila de la tabla products.
+type Product struct {
+	ID   int64
+	Name string
+}
+
+// connectionString lee el DSN de la variable de entorno DATABASE_URL.
+func connectionString() string {
+	if dsn := os.Getenv("DATABASE_URL"); dsn != "" {
+		return dsn
+	}
+	return defaultConnectionString
+}
+
+// defaultConnectTimeout se usa si la variable de entorno DATABASE_CONNECT_TIMEOUT no está
+// definida o no es una duración válida.
+const defaultConnectTimeout = 5 * time.Second
+
+// connectTimeout lee de DATABASE_CONNECT_TIMEOUT cuánto puede tardar una conexión.
+func connectTimeout() time.Duration {
+	if timeout, err := time.ParseDuration(os.Getenv("DATABASE_CONNECT_TIMEOUT")); err == nil && timeout > 0 {
+		return timeout
+	}
+	return defaultConnectTimeout
+}
+
+// Connect abre la base de datos y comprueba que responde antes de que venza ctx. sql.Open
+// no se conecta (solo valida el driver), así que el Ping es lo que detecta un servidor
+// caído o demasiado lento.
+func (db *DataBase) Connect(ctx context.Context) error {
+	connects.Add(1)
+	logf("🔗 Connecting to database...\n")
+	conn, err := sql.Open("mockdb", db.connectionString)
+	if err != nil {
+		return fmt.Errorf("❌ no se pudo abrir la base de datos: %w", err)
+	}
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
+		return fmt.Errorf("❌ no se pudo conectar a la base de datos: %w", err)
+	}
+	db.mu.Lock()
+	old := db.db
+	db.db = conn
+	db.mu.Unlock()
+	if old != nil {
+		old.Close()
+	}
+	db.healthy.Store(true)
+	logf("✅ Connected to database!\n")
+	return nil
+}
+
+// sqlDB retorna el *sql.DB actual.
+func (db *DataBase) sqlDB() *sql.DB {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.db
+}
+
+// Query ejecuta una consulta que retorna filas.
+func (db *DataBase) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return db.sqlDB().QueryContext(ctx, query, args...)
+}
+
+// QueryRow ejecuta una consulta que retorna como máximo una fila.
+func (db *DataBase) QueryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	return db.sqlDB().QueryRowContext(ctx, query, args...)
+}
+
+// Products retorna todos los productos.
+func (db *DataBase) Products(ctx context.Context) ([]Product, error) {
+	rows, err := db.Query(ctx, "SELECT id, name FROM products")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []Product
+	for rows.Next() {
+		var p Product
+		if err := rows.Scan(&p.ID, &p.Name); err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	return products, rows.Err()
+}
+
+// ProductByID retorna el producto con ese id, o sql.ErrNoRows si no existe.
+func (db *DataBase) ProductByID(ctx context.Context, id int64) (Product, error) {
+	var p Product
+	err := db.QueryRow(ctx, "SELECT id, name FROM products WHERE id = ?", id).Scan(&p.ID, &p.Name)
+	return p, err
+}
+
+// Close detiene el health check, si está activo, y cierra la base de datos.
+func (db *DataBase) Close() error {
+	db.mu.Lock()
+	stop, done := db.stopHealthCheck, db.healthCheckDone
+	db.stopHealthCheck = nil
+	db.mu.Unlock()
+	if stop != nil {
+		stop()
+		<-done
+	}
+	return db.sqlDB().Close()
+}
+
+// database guarda el Lazy que crea la instancia. Es un atomic.Pointer para que
+// ResetInstanceForTesting pueda reemplazarlo mientras otras goroutines lo leen.
+var database atomic.Pointer[syncutil.Lazy[*DataBase]]
+
+func init() {
+	database.Store(newLazyDataBase())
+}
+
+// newLazyDataBase prepara (sin conectar) la creación de la instancia.
+func newLazyDataBase() *syncutil.Lazy[*DataBase] {
+	return syncutil.NewLazy(func() (*DataBase, error) {
+		metrics.inits.Add(1)
+		start := time.Now()
+		defer func() { metrics.initDuration.Store(int64(time.Since(start))) }()
+		return newDataBase()
+	})
+}
+
+// newDataBase crea una instancia y la conecta.
+func newDataBase() (*DataBase, error) {
+	return openDataBase(connectionString())
+}
+
+// openDataBase crea una instancia para connectionString y la conecta.
+func openDataBase(connectionString string) (*DataBase, error) {
+	logf("🧪 Creating new database instance...\n")
+	db := &DataBase{connectionString: connectionString}
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout())
+	defer cancel()
+	if err := db.Connect(ctx); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// quiet silencia los mensajes del singleton en las demostraciones con muchas goroutines.
+var quiet bool
+
+func logf(format string, args ...any) {
+	if !quiet {
+		fmt.Printf(format, args...)
+	}
+}
+
+// connects cuenta las llamadas a Connect, para comprobar que el singleton conecta una sola vez.
+var connects atomic.Int32
+
+// SingletonStats respalda con números la afirmación de que el singleton es seguro
+// con muchas goroutines.
+type SingletonStats struct {
+	Requests     int64         // Llamadas a GetDataBaseInstance
+	Waited       int64         // Llamadas que esperaron a que otra goroutine terminara de inicializar
+	Inits        int64         // Veces que se ejecutó la inicialización (debe ser 1)
+	InitDuration time.Duration // Lo que tardó la inicialización
+}
+
+// metrics son los contadores de GetDataBaseInstance desde el último reinicio.
+var metrics struct {
+	requests     atomic.Int64
+	pending      atomic.Int64 // Llamadas que encontraron la instancia sin inicializar
+	inits        atomic.Int64
+	initDuration atomic.Int64
+}
+
+// InstanceStats retorna las estadísticas del singleton. Las llamadas que llegaron antes
+// de que la instancia existiera, salvo la que la inicializó, esperaron en el Lazy.
+func InstanceStats() SingletonStats {
+	inits := metrics.inits.Load()
+	return SingletonStats{
+		Requests:     metrics.requests.Load(),
+		Waited:       max(metrics.pending.Load()-inits, 0),
+		Inits:        inits,
+		InitDuration: time.Duration(metrics.initDuration.Load()),
+	}
+}
+
+// override es la instancia que SetInstanceForTesting pone en lugar del singleton real.
+var override atomic.Pointer[Database]
+
+// GetDataBaseInstance retorna la única instancia de la base de datos, creándola y
+// conectándola la primera vez (con el timeout de DATABASE_CONNECT_TIMEOUT). Retorna la
+// interfaz Database para que el código que la usa no dependa del tipo concreto y las
+// pruebas puedan sustituirla con SetInstanceForTesting.
+func GetDataBaseInstance() (Database, error) {
+	if db := override.Load(); db != nil {
+		return *db, nil
+	}
+	db, err := instance()
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// SetInstanceForTesting hace que GetDataBaseInstance retorne db (por ejemplo un
+// MockDatabase) hasta que se llame a la función retornada, que restaura el singleton real.
+func SetInstanceForTesting(db Database) (restore func()) {
+	override.Store(&db)
+	return func() { override.Store(nil) }
+}
+
+// instance retorna el singleton real. syncutil.Lazy garantiza que las goroutines que
+// llegan a la vez compartan un solo Connect. Si falla, el error se retorna a todas ellas
+// pero no queda guardado: la siguiente llamada reintenta.
+func instance() (*DataBase, error) {
+	metrics.requests.Add(1)
+	lazy := database.Load()
+	if lazy.Initialized() {
+		logf("🔍 Reusing existing database instance...\n")
+	} else {
+		metrics.pending.Add(1)
+	}
+	return lazy.Get()
+}
+
+// ResetInstanceForTesting cierra y descarta la instancia actual (y reinicia los contadores)
+// para que cada caso de prueba empiece sin singleton. Solo debe usarse en pruebas: el
+// código que aún tenga la instancia anterior queda con un *sql.DB cerrado.
+func ResetInstanceForTesting() {
+	old := database.Swap(newLazyDataBase())
+	if old.Initialized() {
+		if db, err := old.Get(); err == nil {
+			db.Close()
+		}
+	}
+	connects.Store(0)
+	metrics.requests.Store(0)
+	metrics.pending.Store(0)
+	metrics.inits.Store(0)
+	metrics.initDuration.Store(0)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+// setupSingleton deja el singleton sin instancia y en silencio, y lo vuelve a dejar
+// así al terminar el test.
+func setupSingleton(t *testing.T) {
+	t.Helper()
+	t.Setenv("DATABASE_URL", "mockdb://admin@localhost:5432/tienda?latency=50ms")
+	quiet = true
+	ResetInstanceForTesting()
+	t.Cleanup(func() {
+		ResetInstanceForTesting()
+		quiet = false
+	})
+}
+
+func TestGetDataBaseInstanceConcurrent(t *testing.T) {
+	setupSingleton(t)
+	const callers = 100
+
+	instances := make([]Database, callers)
+	var wg sync.WaitGroup
+	for n := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			db, err := GetDataBaseInstance()
+			if err != nil {
+				t.Errorf("GetDataBaseInstance: %v", err)
+			}
+			instances[n] = db
+		}()
+	}
+	wg.Wait()
+
+	for n, db := range instances {
+		if db != instances[0] {
+			t.Fatalf("la llamada %d recibió otra instancia", n)
+		}
+	}
+	if got := connects.Load(); got != 1 {
+		t.Errorf("Connect se llamó %d veces, quiero 1", got)
+	}
+	if stats := InstanceStats(); stats.Requests != callers || stats.Inits != 1 {
+		t.Errorf("InstanceStats = %+v, quiero %d llamadas y 1 inicialización", stats, callers)
+	}
+}
+
+func TestResetInstanceForTesting(t *testing.T) {
+	setupSingleton(t)
+	first, err := GetDataBaseInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ResetInstanceForTesting()
+	second, err := GetDataBaseInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Error("tras ResetInstanceForTesting se recibió la misma instancia")
+	}
+	if got := connects.Load(); got != 1 {
+		t.Errorf("Connect tras el reinicio: %d llamadas, quiero 1", got)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// mockDriver es un driver de database/sql que simula un servidor de base de datos en
+// memoria, para que el ejemplo funcione sin instalar nada. Entiende DSNs de la forma
+//
+//	mockdb://usuario@host/base?latency=2s
+//
+// donde latency simula lo que tarda en establecerse una conexión. El host
+// "unreachable" simula un servidor caído.
+type mockDriver struct{}
+
+func init() {
+	sql.Register("mockdb", mockDriver{})
+}
+
+// mockTables son los datos de la base simulada: id y nombre por tabla.
+var mockTables = map[string][][2]any{
+	"products": {{int64(1), "MacBook Pro"}, {int64(2), "iMac"}, {int64(3), "Lenovo Legion"}},
+	"users":    {{int64(1), "ana"}, {int64(2), "luis"}},
+}
+
+// mockQuery son las únicas consultas que entiende la base simulada.
+var mockQuery = regexp.MustCompile(`^SELECT id, name FROM (\w+)( WHERE id = \?)?$`)
+
+// ErrUnreachable es el error de conexión que devuelve el host "unreachable".
+var ErrUnreachable = errors.New("no se pudo conectar con el servidor")
+
+// downHosts son los servidores simulados que están caídos en este momento: rechazan
+// conexiones nuevas y las conexiones abiertas dejan de responder.
+var downHosts syncutil.SafeSet[string]
+
+func (d mockDriver) Open(dsn string) (driver.Conn, error) {
+	return mockConnector{dsn: dsn}.Connect(context.Background())
+}
+
+// OpenConnector permite que database/sql pase el contexto de PingContext, QueryContext,
+// etc. a la conexión, así que un timeout también corta un handshake lento.
+func (d mockDriver) OpenConnector(dsn string) (driver.Connector, error) {
+	return mockConnector{dsn: dsn}, nil
+}
+
+// mockConnector abre conexiones a un DSN.
+type mockConnector struct {
+	dsn string
+}
+
+func (c mockConnector) Driver() driver.Driver { return mockDriver{} }
+
+// Connect simula el handshake con el servidor respetando la cancelación de ctx.
+func (c mockConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dsn := c.dsn
+	parsed, err := url.Parse(dsn)
+	if err != nil || parsed.Scheme != "mockdb" {
+		return nil, fmt.Errorf("mockdb: DSN inválido %q", dsn)
+	}
+	latency, _ := time.ParseDuration(parsed.Query().Get("latency"))
+
+	select {
+	case <-time.After(latency):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if parsed.Hostname() == "unreachable" || downHosts.Contains(parsed.Hostname()) {
+		return nil, fmt.Errorf("mockdb: %s: %w", parsed.Host, ErrUnreachable)
+	}
+	return &mockConn{host: parsed.Hostname()}, nil
+}
+
+// mockConn es una conexión a la base simulada.
+type mockConn struct {
+	host string
+}
+
+// Ping implementa driver.Pinger: falla si el servidor se cayó después de conectar.
+func (c *mockConn) Ping(ctx context.Context) error {
+	if downHosts.Contains(c.host) {
+		return fmt.Errorf("mockdb: %s: %w", c.host, ErrUnreachable)
+	}
+	return ctx.Err()
+}
+
+func (c *mockConn) Prepare(query string) (driver.Stmt, error) {
+	if downHosts.Contains(c.host) {
+		return nil, fmt.Errorf("mockdb: %s: %w", c.host, ErrUnreachable)
+	}
+	match := mockQuery.FindStringSubmatch(query)
+	if match == nil {
+		return nil, fmt.Errorf("mockdb: consulta no soportada: %s", query)
+	}
+	rows, ok := mockTables[match[1]]
+	if !ok {
+		return nil, fmt.Errorf("mockdb: la tabla %s no existe", match[1])
+	}
+	return &mockStmt{rows: rows, byID: match[2] != ""}, nil
+}
+
+func (c *mockConn) Close() error { return nil }
+func (c *mockConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("mockdb: transacciones no soportadas")
+}
+
+// mockStmt es una consulta preparada sobre una tabla.
+type mockStmt struct {
+	rows [][2]any
+	byID bool // Si filtra por id (un argumento)
+}
+
+func (s *mockStmt) Close() error { return nil }
+
+func (s *mockStmt) NumInput() int {
+	if s.byID {
+		return 1
+	}
+	return 0
+}
+
+func (s *mockStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("mockdb: la base es de solo lectura")
+}
+
+func (s *mockStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if !s.byID {
+		return &mockRows{rows: s.rows}, nil
+	}
+	var filtered [][2]any
+	for _, row := range s.rows {
+		if row[0] == args[0] {
+			filtered = append(filtered, row)
+		}
+	}
+	return &mockRows{rows: filtered}, nil
+}
+
+// mockRows recorre el resultado de una consulta.
+type mockRows struct {
+	rows [][2]any
+	next int
+}
+
+func (r *mockRows) Columns() []string { return []string{"id", "name"} }
+func (r *mockRows) Close() error      { return nil }
+
+func (r *mockRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	dest[0], dest[1] = r.rows[r.next][0], r.rows[r.next][1]
+	r.next++
+	return nil
+}
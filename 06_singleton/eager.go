@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Inicialización ansiosa: la instancia se crea al arrancar el programa, antes de que
+// nadie la pida. También podría hacerse en un func init(), pero entonces el arranque
+// no tiene forma de retornar el error ni de elegir la configuración, así que se usa
+// un Bootstrap explícito que main llama antes de lanzar goroutines.
+var (
+	eagerInstance *DataBase
+	eagerErr      error
+)
+
+// Bootstrap crea y conecta la instancia ansiosa. Debe llamarse una sola vez al arrancar,
+// antes de que otras goroutines usen GetEagerDataBase; después de eso la instancia solo
+// se lee, así que no hace falta ningún lock.
+func Bootstrap() error {
+	eagerInstance, eagerErr = newDataBase()
+	return eagerErr
+}
+
+// GetEagerDataBase retorna la instancia creada por Bootstrap, o el error de arranque.
+func GetEagerDataBase() (*DataBase, error) {
+	if eagerInstance == nil && eagerErr == nil {
+		return nil, fmt.Errorf("❌ la base de datos no se inicializó: falta llamar a Bootstrap")
+	}
+	return eagerInstance, eagerErr
+}
+
+// demonstrateEagerVsLazy mide dónde se paga el costo de conectar con cada estrategia:
+// la ansiosa lo paga en el arranque y la perezosa en la primera petición.
+func demonstrateEagerVsLazy() {
+	fmt.Println("\n⏱️ Inicialización ansiosa vs perezosa (conexión de 300ms):")
+	os.Setenv("DATABASE_URL", "mockdb://admin@localhost:5432/tienda?latency=300ms")
+	defer os.Unsetenv("DATABASE_URL")
+	quiet = true
+	defer func() { quiet = false }()
+
+	measure := func(f func() error) time.Duration {
+		start := time.Now()
+		if err := f(); err != nil {
+			fmt.Println(err)
+		}
+		return time.Since(start).Round(time.Millisecond)
+	}
+	get := func(getter func() (*DataBase, error)) func() error {
+		return func() error {
+			_, err := getter()
+			return err
+		}
+	}
+
+	eagerStartup := measure(Bootstrap)
+	eagerFirst := measure(get(GetEagerDataBase))
+	eagerNext := measure(get(GetEagerDataBase))
+
+	lazyStartup := measure(func() error { ResetInstanceForTesting(); return nil })
+	lazyFirst := measure(get(instance))
+	lazyNext := measure(get(instance))
+
+	fmt.Printf("   %-10s %12s %16s %16s\n", "Estrategia", "Arranque", "1ª petición", "Siguientes")
+	fmt.Printf("   %-10s %12v %16v %16v\n", "Ansiosa", eagerStartup, eagerFirst, eagerNext)
+	fmt.Printf("   %-10s %12v %16v %16v\n", "Perezosa", lazyStartup, lazyFirst, lazyNext)
+
+	fmt.Println("💡 Ansiosa: cuando el recurso siempre se usa y conviene fallar al arrancar")
+	fmt.Println("   (un servidor que no sirve sin base de datos) y la primera petición no debe esperar.")
+	fmt.Println("💡 Perezosa: cuando el recurso es costoso y puede no usarse nunca (comandos de CLI,")
+	fmt.Println("   pruebas), o cuando arrancar rápido importa más que la latencia de la primera petición.")
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Parámetros de la reconexión con backoff exponencial: la espera entre intentos se
+// duplica (100ms, 200ms, 400ms...) hasta reconnectMaxDelay.
+const (
+	reconnectBaseDelay = 100 * time.Millisecond
+	reconnectMaxDelay  = 2 * time.Second
+	reconnectAttempts  = 6
+)
+
+// Ping comprueba que la base de datos responde en como máximo timeout.
+func (db *DataBase) Ping(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := db.sqlDB().PingContext(ctx); err != nil {
+		return fmt.Errorf("❌ la base de datos no responde: %w", err)
+	}
+	return nil
+}
+
+// Healthy indica si el último Ping o Connect tuvo éxito.
+func (db *DataBase) Healthy() bool {
+	return db.healthy.Load()
+}
+
+// Reconnect vuelve a conectar con backoff exponencial: reintenta hasta
+// reconnectAttempts veces, esperando el doble entre cada intento, o hasta que se
+// cancele ctx.
+func (db *DataBase) Reconnect(ctx context.Context) error {
+	delay := reconnectBaseDelay
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, connectTimeout())
+		err := db.Connect(attemptCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt == reconnectAttempts {
+			return fmt.Errorf("❌ reconexión abandonada tras %d intentos: %w", attempt, err)
+		}
+		logf("🔁 Intento %d fallido, reintentando en %v\n", attempt, delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay = min(delay*2, reconnectMaxDelay)
+	}
+}
+
+// StartHealthCheck lanza una goroutine que hace Ping cada interval. Si la base de datos
+// no responde en timeout, la marca como no saludable y reconecta con backoff. Close
+// detiene la goroutine.
+func (db *DataBase) StartHealthCheck(interval, timeout time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	db.mu.Lock()
+	db.stopHealthCheck, db.healthCheckDone = cancel, done
+	db.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+
+			err := db.Ping(timeout)
+			if err == nil {
+				continue
+			}
+			db.healthy.Store(false)
+			logf("💔 Health check: %v\n", err)
+			if err := db.Reconnect(ctx); err != nil {
+				logf("%v\n", err)
+				continue
+			}
+			logf("💚 Health check: conexión recuperada\n")
+		}
+	}()
+}
+
+// demonstrateHealthCheck tumba el servidor mientras el singleton tiene el health check
+// activo: el Ping falla, la instancia se marca como no saludable y se reconecta con
+// backoff exponencial en cuanto el servidor vuelve.
+func demonstrateHealthCheck() {
+	fmt.Println("\n🩺 Health check y reconexión con backoff:")
+	ResetInstanceForTesting()
+	os.Setenv("DATABASE_URL", "mockdb://admin@db-primary:5432/tienda?latency=20ms")
+	defer os.Unsetenv("DATABASE_URL")
+	defer ResetInstanceForTesting()
+
+	db, err := instance() // El health check es propio del tipo concreto, no de la interfaz
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	db.StartHealthCheck(50*time.Millisecond, 100*time.Millisecond)
+
+	fmt.Println("🔥 El servidor db-primary se cae")
+	downHosts.Add("db-primary")
+	time.Sleep(500 * time.Millisecond)
+	fmt.Println("🩹 El servidor db-primary vuelve")
+	downHosts.Remove("db-primary")
+
+	for deadline := time.Now().Add(2 * time.Second); !db.Healthy() && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+	products, err := db.Products(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("✅ Saludable: %t; la consulta vuelve a funcionar (%d productos)\n", db.Healthy(), len(products))
+}
@@ -1,38 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 )
 
-var mu sync.Mutex
-
-type DataBase struct {
-	connectionString string
-}
-
-func (db *DataBase) Connect() {
-	fmt.Println("🔗 Connecting to database...")
-	time.Sleep(2 * time.Second)
-	fmt.Println("✅ Connected to database!")
-}
-
-var instance *DataBase
-
-func GetDataBaseInstance() *DataBase {
-	mu.Lock()
-	defer mu.Unlock()
-	if instance == nil {
-		fmt.Printf("🧪 Creating new database instance...\n")
-		instance = &DataBase{}
-		instance.Connect()
-	} else {
-		fmt.Printf("🔍 Reusing existing database instance...\n")
-	}
-	return instance
-}
-
 func main() {
 	var wg sync.WaitGroup
 
@@ -40,9 +16,119 @@ func main() {
 		wg.Add(1)
 		go func(i int) {
 			defer wg.Done()
-			GetDataBaseInstance()
+			if _, err := GetDataBaseInstance(); err != nil {
+				fmt.Println(err)
+			}
 		}(i)
 	}
 	wg.Wait()
 	fmt.Println("All goroutines finished.")
+	stats := InstanceStats()
+	fmt.Printf("📊 Pedidos: %d, Esperaron la inicialización: %d, Inicializaciones: %d, Duración: %v\n",
+		stats.Requests, stats.Waited, stats.Inits, stats.InitDuration.Round(time.Millisecond))
+
+	demonstrateQueries()
+	demonstrateSingleConnect()
+	demonstrateEagerVsLazy()
+	demonstrateMultiton()
+	demonstrateHealthCheck()
+	demonstrateConnectionPool()
+	demonstrateHotReload()
+	demonstrateConnectionError()
+	demonstrateDependencyInjection()
+}
+
+// demonstrateSingleConnect reinicia el singleton, lanza 100 goroutines a la vez y
+// muestra cuántas instancias distintas recibieron y cuántas veces se ejecutó Connect.
+// Ejecutar con go run -race para verificar además que no hay condiciones de carrera.
+func demonstrateSingleConnect() {
+	const goroutines = 100
+	fmt.Printf("\n🏋️ %d goroutines sobre un singleton recién reiniciado:\n", goroutines)
+	ResetInstanceForTesting()
+	os.Setenv("DATABASE_URL", "mockdb://admin@localhost:5432/tienda?latency=200ms")
+	defer os.Unsetenv("DATABASE_URL")
+
+	quiet = true
+	defer func() { quiet = false }()
+
+	var wg sync.WaitGroup
+	instances := make([]Database, goroutines)
+	for i := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			instances[i], _ = GetDataBaseInstance()
+		}()
+	}
+	wg.Wait()
+
+	distinct := make(map[Database]bool)
+	for _, db := range instances {
+		distinct[db] = true
+	}
+	fmt.Printf("🔌 Instancias distintas: %d, llamadas a Connect: %d\n", len(distinct), connects.Load())
+
+	stats := InstanceStats()
+	fmt.Printf("📊 Pedidos: %d, Esperaron la inicialización: %d, Inicializaciones: %d, Duración: %v\n",
+		stats.Requests, stats.Waited, stats.Inits, stats.InitDuration.Round(time.Millisecond))
+}
+
+// demonstrateQueries usa la instancia compartida para consultar la base de datos.
+func demonstrateQueries() {
+	fmt.Println("\n🗄️ Consultas sobre la instancia compartida:")
+	db, err := GetDataBaseInstance()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	ctx := context.Background()
+	products, err := db.Products(ctx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, p := range products {
+		fmt.Printf("📦 %d: %s\n", p.ID, p.Name)
+	}
+
+	product, err := db.ProductByID(ctx, 2)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("🔍 Producto 2:", product.Name)
+}
+
+// demonstrateConnectionError apunta DATABASE_URL a un servidor caído y luego a uno
+// demasiado lento: el error llega a quien pidió la instancia en lugar de solo
+// imprimirse, y como no queda guardado, en cuanto la configuración se corrige la
+// siguiente llamada conecta.
+func demonstrateConnectionError() {
+	fmt.Println("\n🚨 Servidor caído:")
+	// La instancia ya existe, así que se descarta para simular un arranque con otra configuración
+	ResetInstanceForTesting()
+	defer os.Unsetenv("DATABASE_URL")
+	defer os.Unsetenv("DATABASE_CONNECT_TIMEOUT")
+
+	os.Setenv("DATABASE_URL", "mockdb://admin@unreachable:5432/tienda")
+	_, err := GetDataBaseInstance()
+	fmt.Println(err)
+	fmt.Println("🔎 errors.Is(err, ErrUnreachable):", errors.Is(err, ErrUnreachable))
+
+	fmt.Println("\n🐢 Servidor lento (tarda 2s) con timeout de 300ms:")
+	os.Setenv("DATABASE_URL", "mockdb://admin@localhost:5432/tienda?latency=2s")
+	os.Setenv("DATABASE_CONNECT_TIMEOUT", "300ms")
+	start := time.Now()
+	_, err = GetDataBaseInstance()
+	fmt.Printf("%v (tras %v)\n", err, time.Since(start).Round(100*time.Millisecond))
+	fmt.Println("🔎 errors.Is(err, context.DeadlineExceeded):", errors.Is(err, context.DeadlineExceeded))
+
+	fmt.Println("\n🔁 Configuración corregida: la siguiente llamada reintenta")
+	os.Setenv("DATABASE_URL", "mockdb://admin@localhost:5432/tienda?latency=100ms")
+	if _, err := GetDataBaseInstance(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("✅ Conectado al tercer intento (conexiones intentadas: %d)\n", connects.Load())
 }
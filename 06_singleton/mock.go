@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MockDatabase es una implementación de Database en memoria para pruebas: no abre
+// conexiones y permite simular fallos.
+type MockDatabase struct {
+	Items []Product
+	Err   error // Si no es nil, todas las operaciones fallan con este error
+	Calls int   // Operaciones recibidas
+}
+
+func (m *MockDatabase) Products(ctx context.Context) ([]Product, error) {
+	m.Calls++
+	return m.Items, m.Err
+}
+
+func (m *MockDatabase) ProductByID(ctx context.Context, id int64) (Product, error) {
+	m.Calls++
+	if m.Err != nil {
+		return Product{}, m.Err
+	}
+	for _, p := range m.Items {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return Product{}, sql.ErrNoRows
+}
+
+func (m *MockDatabase) Ping(timeout time.Duration) error {
+	m.Calls++
+	return m.Err
+}
+
+func (m *MockDatabase) Close() error {
+	return nil
+}
+
+// productReport es código de la aplicación que usa el singleton: no sabe si recibe la
+// base de datos real o un mock.
+func productReport(ctx context.Context) (string, error) {
+	db, err := GetDataBaseInstance()
+	if err != nil {
+		return "", err
+	}
+	products, err := db.Products(ctx)
+	if err != nil {
+		return "", fmt.Errorf("❌ no se pudo generar el reporte: %w", err)
+	}
+	names := make([]string, len(products))
+	for i, p := range products {
+		names[i] = p.Name
+	}
+	return fmt.Sprintf("%d productos: %s", len(products), strings.Join(names, ", ")), nil
+}
+
+// demonstrateDependencyInjection prueba productReport sin base de datos real:
+// SetInstanceForTesting sustituye el singleton por un MockDatabase, también para simular
+// un fallo, y al restaurarlo vuelve a usarse la instancia real.
+func demonstrateDependencyInjection() {
+	fmt.Println("\n🧪 Singleton detrás de una interfaz:")
+	ctx := context.Background()
+
+	mock := &MockDatabase{Items: []Product{{ID: 1, Name: "Teclado"}, {ID: 2, Name: "Mouse"}}}
+	restore := SetInstanceForTesting(mock)
+	report, err := productReport(ctx)
+	fmt.Printf("🎭 Con mock: %s (err: %v, llamadas al mock: %d)\n", report, err, mock.Calls)
+
+	mock.Err = errors.New("disco lleno")
+	_, err = productReport(ctx)
+	fmt.Println("🎭 Con mock que falla:", err)
+	restore()
+
+	report, err = productReport(ctx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("🗄️ Con la instancia real:", report)
+}
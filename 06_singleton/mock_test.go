@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestProductReportWithMock(t *testing.T) {
+	setupSingleton(t)
+	errDisk := errors.New("disco lleno")
+	tests := []struct {
+		name    string
+		mock    *MockDatabase
+		want    string
+		wantErr error
+	}{
+		{"productos", &MockDatabase{Items: []Product{{ID: 1, Name: "Teclado"}, {ID: 2, Name: "Mouse"}}}, "2 productos: Teclado, Mouse", nil},
+		{"sin productos", &MockDatabase{}, "0 productos: ", nil},
+		{"fallo", &MockDatabase{Err: errDisk}, "", errDisk},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restore := SetInstanceForTesting(tt.mock)
+			defer restore()
+
+			got, err := productReport(context.Background())
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("productReport = (%q, %v), quiero (%q, %v)", got, err, tt.want, tt.wantErr)
+			}
+			if tt.mock.Calls != 1 {
+				t.Errorf("el mock recibió %d llamadas, quiero 1", tt.mock.Calls)
+			}
+		})
+	}
+
+	// Sin el mock vuelve a usarse la instancia real, que nunca se tocó durante los casos
+	if got := connects.Load(); got != 0 {
+		t.Fatalf("con el mock se conectó %d veces a la base real", got)
+	}
+	report, err := productReport(context.Background())
+	if err != nil || !strings.Contains(report, "productos") {
+		t.Errorf("productReport con la instancia real = (%q, %v)", report, err)
+	}
+	if _, isMock := mustInstance(t).(*MockDatabase); isMock {
+		t.Error("tras restore GetDataBaseInstance sigue retornando el mock")
+	}
+}
+
+func mustInstance(t *testing.T) Database {
+	t.Helper()
+	db, err := GetDataBaseInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// tenantDatabases guarda una instancia perezosa por tenant (patrón multiton: un
+// singleton por clave en lugar de uno global).
+var tenantDatabases syncutil.SafeMap[string, *syncutil.Lazy[*DataBase]]
+
+// GetDatabaseFor retorna la única instancia de DataBase del tenant, creándola la primera
+// vez. La entrada se crea con doble comprobación: primero una lectura, y solo si falta,
+// un GetOrSet atómico que resuelve la carrera entre goroutines que llegan a la vez. La
+// conexión ocurre fuera del lock del mapa, así que tenants distintos conectan en
+// paralelo y las goroutines del mismo tenant esperan la misma conexión.
+func GetDatabaseFor(tenant string) (*DataBase, error) {
+	lazy, ok := tenantDatabases.Get(tenant)
+	if !ok {
+		lazy, _ = tenantDatabases.GetOrSet(tenant, syncutil.NewLazy(func() (*DataBase, error) {
+			return openDataBase(tenantConnectionString(tenant))
+		}))
+	}
+	return lazy.Get()
+}
+
+// tenantConnectionString usa el DSN general cambiando la base de datos por la del tenant.
+func tenantConnectionString(tenant string) string {
+	dsn, err := url.Parse(connectionString())
+	if err != nil {
+		return connectionString()
+	}
+	dsn.Path = "/" + tenant
+	return dsn.String()
+}
+
+// resetTenantsForTesting cierra y descarta las instancias de todos los tenants.
+func resetTenantsForTesting() {
+	for _, tenant := range tenantDatabases.Keys() {
+		if lazy, ok := tenantDatabases.Get(tenant); ok && lazy.Initialized() {
+			if db, err := lazy.Get(); err == nil {
+				db.Close()
+			}
+		}
+		tenantDatabases.Delete(tenant)
+	}
+}
+
+// demonstrateMultiton lanza 30 goroutines por cada uno de 3 tenants y comprueba que
+// cada tenant tiene exactamente una instancia, distinta de la de los demás.
+func demonstrateMultiton() {
+	tenants := []string{"acme", "globex", "initech"}
+	const perTenant = 30
+	fmt.Printf("\n🏢 Multiton: %d goroutines por tenant (%v)\n", perTenant, tenants)
+	os.Setenv("DATABASE_URL", "mockdb://admin@localhost:5432/tienda?latency=200ms")
+	defer os.Unsetenv("DATABASE_URL")
+	defer resetTenantsForTesting()
+	quiet = true
+	defer func() { quiet = false }()
+	before := connects.Load()
+
+	var wg sync.WaitGroup
+	var instances syncutil.SafeMap[string, *syncutil.SafeSet[*DataBase]]
+	for _, tenant := range tenants {
+		for range perTenant {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				db, err := GetDatabaseFor(tenant)
+				if err != nil {
+					fmt.Println(err)
+					return
+				}
+				seen, _ := instances.GetOrSet(tenant, &syncutil.SafeSet[*DataBase]{})
+				seen.Add(db)
+			}()
+		}
+	}
+	wg.Wait()
+
+	distinct := map[*DataBase]bool{}
+	for _, tenant := range tenants {
+		seen, _ := instances.Get(tenant)
+		if seen.Len() != 1 {
+			fmt.Printf("❌ El tenant %s recibió %d instancias\n", tenant, seen.Len())
+			return
+		}
+		db := seen.Values()[0]
+		distinct[db] = true
+		fmt.Printf("🏢 %s -> %s\n", tenant, db.connectionString)
+	}
+	fmt.Printf("✅ Una instancia por tenant (%d distintas) y %d conexiones en total\n",
+		len(distinct), connects.Load()-before)
+}
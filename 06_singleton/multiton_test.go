@@ -0,0 +1,56 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGetDatabaseForOneInstancePerTenant(t *testing.T) {
+	setupSingleton(t)
+	t.Cleanup(resetTenantsForTesting)
+	tenants := []string{"acme", "globex", "initech"}
+	const perTenant = 20
+
+	var (
+		mu        sync.Mutex
+		instances = make(map[string]map[*DataBase]bool)
+		wg        sync.WaitGroup
+	)
+	for _, tenant := range tenants {
+		instances[tenant] = make(map[*DataBase]bool)
+		for range perTenant {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				db, err := GetDatabaseFor(tenant)
+				if err != nil {
+					t.Errorf("GetDatabaseFor(%q): %v", tenant, err)
+					return
+				}
+				mu.Lock()
+				instances[tenant][db] = true
+				mu.Unlock()
+			}()
+		}
+	}
+	wg.Wait()
+
+	seen := make(map[*DataBase]string)
+	for _, tenant := range tenants {
+		if len(instances[tenant]) != 1 {
+			t.Fatalf("%s tiene %d instancias, quiero 1", tenant, len(instances[tenant]))
+		}
+		for db := range instances[tenant] {
+			if other, exists := seen[db]; exists {
+				t.Errorf("%s y %s comparten instancia", tenant, other)
+			}
+			seen[db] = tenant
+			if want := "mockdb://admin@localhost:5432/" + tenant + "?latency=50ms"; db.connectionString != want {
+				t.Errorf("%s se conectó a %q, quiero %q", tenant, db.connectionString, want)
+			}
+		}
+	}
+	if got := connects.Load(); got != int32(len(tenants)) {
+		t.Errorf("Connect se llamó %d veces, quiero %d (una por tenant)", got, len(tenants))
+	}
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// ErrPoolClosed se retorna al pedir una conexión a un pool cerrado.
+var ErrPoolClosed = errors.New("el pool de conexiones está cerrado")
+
+// ConnectionPool es la evolución natural del singleton DataBase (patrón object pool):
+// en lugar de una única instancia compartida por todos, mantiene hasta maxSize
+// conexiones reutilizables. Quien necesita una la pide con Acquire (esperando si
+// todas están ocupadas) y la devuelve con Release para que otro la reutilice. Las
+// conexiones que pasan demasiado tiempo sin usarse se cierran en segundo plano.
+type ConnectionPool struct {
+	connector   driver.Connector
+	idleTimeout time.Duration
+	slots       *syncutil.Semaphore // Un lugar por conexión abierta (ocupada o no)
+
+	mu     sync.Mutex
+	idle   []*PooledConn // Conexiones libres; la última es la usada más recientemente
+	nextID int
+	closed bool
+	stats  PoolStats
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// PooledConn es una conexión prestada por el pool.
+type PooledConn struct {
+	ID       int
+	raw      driver.Conn
+	lastUsed time.Time
+}
+
+// PoolStats resume el uso del pool.
+type PoolStats struct {
+	Created  int // Conexiones abiertas
+	Reused   int // Acquire servidos con una conexión libre
+	Timeouts int // Acquire que se rindieron esperando un lugar
+	Reaped   int // Conexiones cerradas por inactividad
+}
+
+// PoolOption configura un ConnectionPool.
+type PoolOption func(*ConnectionPool)
+
+// WithIdleTimeout define cuánto puede estar libre una conexión antes de cerrarse. Con
+// d <= 0 las conexiones libres nunca se cierran por inactividad.
+func WithIdleTimeout(d time.Duration) PoolOption {
+	return func(p *ConnectionPool) {
+		p.idleTimeout = d
+	}
+}
+
+// NewConnectionPool crea un pool de como máximo maxSize conexiones abiertas con
+// connector, y arranca la goroutine que cierra las conexiones inactivas (salvo que
+// WithIdleTimeout la desactive).
+func NewConnectionPool(connector driver.Connector, maxSize int, opts ...PoolOption) *ConnectionPool {
+	p := &ConnectionPool{
+		connector:   connector,
+		idleTimeout: 30 * time.Second,
+		slots:       syncutil.NewSemaphore(maxSize),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.idleTimeout <= 0 {
+		close(p.done) // Sin goroutine que esperar en Close
+		return p
+	}
+	go p.reap()
+	return p
+}
+
+// Acquire presta una conexión: reutiliza una libre o abre una nueva si hay lugar. Si
+// el pool está lleno espera hasta que alguien haga Release o hasta que venza ctx.
+func (p *ConnectionPool) Acquire(ctx context.Context) (*PooledConn, error) {
+	if err := p.slots.Acquire(ctx, 1); err != nil {
+		p.mu.Lock()
+		p.stats.Timeouts++
+		p.mu.Unlock()
+		return nil, fmt.Errorf("❌ no hay conexiones disponibles: %w", err)
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		p.slots.Release(1)
+		return nil, ErrPoolClosed
+	}
+	if n := len(p.idle); n > 0 {
+		conn := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.stats.Reused++
+		p.mu.Unlock()
+		return conn, nil
+	}
+	p.nextID++
+	id := p.nextID
+	p.mu.Unlock()
+
+	// La conexión se abre fuera del lock: otras goroutines pueden seguir usando el pool
+	raw, err := p.connector.Connect(ctx)
+	if err != nil {
+		p.slots.Release(1)
+		return nil, fmt.Errorf("❌ no se pudo abrir la conexión %d: %w", id, err)
+	}
+	p.mu.Lock()
+	p.stats.Created++
+	p.mu.Unlock()
+	return &PooledConn{ID: id, raw: raw}, nil
+}
+
+// Release devuelve una conexión al pool para que otro la reutilice.
+func (p *ConnectionPool) Release(conn *PooledConn) {
+	conn.lastUsed = time.Now()
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		conn.raw.Close()
+	} else {
+		p.idle = append(p.idle, conn)
+		p.mu.Unlock()
+	}
+	p.slots.Release(1)
+}
+
+// reap cierra periódicamente las conexiones libres que superaron idleTimeout.
+func (p *ConnectionPool) reap() {
+	defer close(p.done)
+	ticker := time.NewTicker(max(p.idleTimeout/2, time.Nanosecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-p.stop:
+			return
+		}
+
+		p.mu.Lock()
+		var stale []*PooledConn
+		fresh := p.idle[:0]
+		for _, conn := range p.idle {
+			if time.Since(conn.lastUsed) > p.idleTimeout {
+				stale = append(stale, conn)
+			} else {
+				fresh = append(fresh, conn)
+			}
+		}
+		p.idle = fresh
+		p.stats.Reaped += len(stale)
+		p.mu.Unlock()
+
+		for _, conn := range stale {
+			conn.raw.Close()
+		}
+	}
+}
+
+// Idle retorna cuántas conexiones libres hay en el pool.
+func (p *ConnectionPool) Idle() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.idle)
+}
+
+// Stats retorna una copia de las estadísticas del pool.
+func (p *ConnectionPool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// Close detiene la limpieza y cierra las conexiones libres. Las conexiones prestadas
+// se cierran cuando se devuelven con Release.
+func (p *ConnectionPool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	close(p.stop)
+	<-p.done
+	var errs []error
+	for _, conn := range idle {
+		errs = append(errs, conn.raw.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// demonstrateConnectionPool reparte 6 trabajos entre un pool de 2 conexiones: solo se
+// abren 2 y el resto de trabajos las reutiliza. Después muestra un Acquire que se
+// rinde por timeout y la limpieza de conexiones inactivas.
+func demonstrateConnectionPool() {
+	fmt.Println("\n🏊 Pool de conexiones (máximo 2):")
+	pool := NewConnectionPool(mockConnector{dsn: "mockdb://admin@localhost:5432/tienda?latency=100ms"}, 2,
+		WithIdleTimeout(300*time.Millisecond))
+	defer pool.Close()
+
+	var wg sync.WaitGroup
+	for job := range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			conn, err := pool.Acquire(ctx)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			defer pool.Release(conn)
+			fmt.Printf("🔌 Trabajo %d usa la conexión %d\n", job, conn.ID)
+			time.Sleep(150 * time.Millisecond)
+		}()
+	}
+	wg.Wait()
+
+	// Con las dos conexiones prestadas, un tercer Acquire espera y se rinde
+	first, _ := pool.Acquire(context.Background())
+	second, _ := pool.Acquire(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	_, err := pool.Acquire(ctx)
+	cancel()
+	fmt.Println("⌛", err)
+	pool.Release(first)
+	pool.Release(second)
+
+	fmt.Printf("💤 Libres: %d; esperando a que venza el tiempo de inactividad...\n", pool.Idle())
+	time.Sleep(500 * time.Millisecond)
+	fmt.Printf("🧹 Libres tras la limpieza: %d\n", pool.Idle())
+
+	stats := pool.Stats()
+	fmt.Printf("📊 Abiertas: %d, Reutilizadas: %d, Timeouts: %d, Cerradas por inactividad: %d\n",
+		stats.Created, stats.Reused, stats.Timeouts, stats.Reaped)
+	fmt.Println("💡 Singleton: una instancia compartida por todos a la vez.")
+	fmt.Println("💡 Pool: varias instancias reutilizables, cada una usada por un solo cliente a la vez.")
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestIdleTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		idleTimeout time.Duration
+		wantReaped  bool
+	}{
+		{"cero desactiva la limpieza", 0, false},
+		{"negativo desactiva la limpieza", -time.Second, false},
+		{"menos de 2ns no hace fallar el ticker", time.Nanosecond, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewConnectionPool(mockConnector{dsn: "mockdb://admin@localhost:5432/tienda"}, 1, WithIdleTimeout(tt.idleTimeout))
+			conn, err := pool.Acquire(context.Background())
+			if err != nil {
+				t.Fatalf("Acquire: %v", err)
+			}
+			pool.Release(conn)
+
+			deadline := time.Now().Add(100 * time.Millisecond)
+			for pool.Stats().Reaped == 0 && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			if reaped := pool.Stats().Reaped > 0; reaped != tt.wantReaped {
+				t.Errorf("conexión cerrada por inactividad: %t, quiero %t", reaped, tt.wantReaped)
+			}
+			if err := pool.Close(); err != nil {
+				t.Errorf("Close: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// 3. Demostración adicional con otro método de pago incompatible
+
+// accountNumberPattern son los números de cuenta que acepta el banco: 9 dígitos.
+var accountNumberPattern = regexp.MustCompile(`^\d{9}$`)
+
+// BankPayment es un sistema bancario antiguo: no usa errores de Go, sino que indica
+// si la transferencia se hizo con un bool y explica el fallo con un mensaje
+type BankPayment struct{}
+
+func (b BankPayment) Pay(accountNumber string, amount float64) (ok bool, message string) {
+	if !accountNumberPattern.MatchString(accountNumber) {
+		return false, fmt.Sprintf("la cuenta %q no existe", accountNumber)
+	}
+	logf("🏦 Pagando $%.2f desde la cuenta bancaria %s\n", amount, accountNumber)
+	return true, "transferencia aprobada"
+}
+
+// BankPaymentAdapter traduce el resultado (ok, mensaje) del banco a un Receipt o a un error
+type BankPaymentAdapter struct {
+	BankPayment   *BankPayment
+	AccountNumber string
+}
+
+func (ba BankPaymentAdapter) Pay(amount float64) (Receipt, error) {
+	if ok, message := ba.BankPayment.Pay(ba.AccountNumber, amount); !ok {
+		return Receipt{}, fmt.Errorf("%w: %s", ErrPaymentDeclined, message)
+	}
+	return newReceipt("bank", amount), nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// paymentWorkers es la cantidad de goroutines que usa ProcessPayments.
+const paymentWorkers = 8
+
+// PaymentOrder es un pago pendiente: con qué método y cuánto.
+type PaymentOrder struct {
+	Payment IPayment
+	Amount  float64
+}
+
+// PaymentFailure es un pago del lote que falló.
+type PaymentFailure struct {
+	Index int // Posición en el lote
+	Order PaymentOrder
+	Err   error
+}
+
+// MethodSummary resume los pagos exitosos de un método.
+type MethodSummary struct {
+	Count  int
+	Amount float64
+}
+
+// Report es el resultado de ProcessPayments.
+type Report struct {
+	Total     int
+	Succeeded int
+	Collected float64 // Suma de los pagos exitosos
+	ByMethod  map[string]MethodSummary
+	Receipts  []Receipt // En el orden del lote (solo los exitosos)
+	Failures  []PaymentFailure
+	Duration  time.Duration
+}
+
+// Failed retorna cuántos pagos fallaron.
+func (r Report) Failed() int {
+	return len(r.Failures)
+}
+
+// ProcessPayments cobra un lote de pagos heterogéneos (efectivo, tarjetas, bancos...)
+// en paralelo con un pool de paymentWorkers goroutines, y resume el resultado. Todos
+// pasan por ProcessPayment, así que se validan igual que uno suelto.
+func ProcessPayments(orders []PaymentOrder) Report {
+	start := time.Now()
+	receipts := make([]Receipt, len(orders))
+	errs := make([]error, len(orders))
+
+	jobs := make(chan int) // Índices de orders pendientes
+	var wg sync.WaitGroup
+	for range min(paymentWorkers, len(orders)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// Cada worker escribe solo en las posiciones que recibe: no hace falta lock
+				receipts[i], errs[i] = ProcessPayment(orders[i].Payment, orders[i].Amount)
+			}
+		}()
+	}
+	for i := range orders {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	report := Report{Total: len(orders), ByMethod: map[string]MethodSummary{}}
+	for i, err := range errs {
+		if err != nil {
+			report.Failures = append(report.Failures, PaymentFailure{Index: i, Order: orders[i], Err: err})
+			continue
+		}
+		receipt := receipts[i]
+		report.Succeeded++
+		report.Collected += receipt.Amount
+		report.Receipts = append(report.Receipts, receipt)
+		summary := report.ByMethod[receipt.Method]
+		summary.Count++
+		summary.Amount += receipt.Amount
+		report.ByMethod[receipt.Method] = summary
+	}
+	report.Duration = time.Since(start)
+	return report
+}
+
+// Print muestra el resumen del reporte.
+func (r Report) Print() {
+	fmt.Printf("📊 %d pagos: %d exitosos, %d fallidos, $%.2f cobrados en %v\n",
+		r.Total, r.Succeeded, r.Failed(), r.Collected, r.Duration.Round(time.Millisecond))
+	for _, method := range slices.Sorted(maps.Keys(r.ByMethod)) {
+		summary := r.ByMethod[method]
+		fmt.Printf("   %-7s %3d pagos  $%10.2f\n", method, summary.Count, summary.Amount)
+	}
+	for _, failure := range r.Failures {
+		fmt.Printf("   ❌ #%d: %v\n", failure.Index, failure.Err)
+	}
+}
+
+// slowPayment simula la latencia de red de un procesador de pagos real.
+type slowPayment struct {
+	IPayment
+	delay time.Duration
+}
+
+func (s slowPayment) Pay(amount float64) (Receipt, error) {
+	time.Sleep(s.delay)
+	return s.IPayment.Pay(amount)
+}
+
+func (s slowPayment) Unwrap() IPayment { return s.IPayment }
+
+// demonstrateBatchPayments cobra 100 pagos mezclando todos los adaptadores, cada uno con
+// 20ms de latencia simulada, primero uno por uno y luego con ProcessPayments.
+func demonstrateBatchPayments() {
+	fmt.Printf("\n📦 Lote de pagos con %d workers:\n", paymentWorkers)
+	methods := []IPayment{
+		CashPayment{},
+		CreditCardPaymentAdapter{CreditCardPayment: &CreditCardPayment{}, UserAccountID: 12345},
+		BankPaymentAdapter{BankPayment: &BankPayment{}, AccountNumber: "987654324"},
+		CryptoPaymentAdapter{CryptoPayment: &CryptoPayment{}, Wallet: "bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh"},
+	}
+	orders := make([]PaymentOrder, 100)
+	for i := range orders {
+		orders[i] = PaymentOrder{Payment: slowPayment{methods[i%len(methods)], 20 * time.Millisecond}, Amount: float64(10 + i)}
+	}
+	orders[13].Amount = 20_000 // Supera el cupo de la tarjeta
+	orders[42].Payment = slowPayment{BankPaymentAdapter{BankPayment: &BankPayment{}, AccountNumber: "000"}, 0}
+	orders[77].Amount = 0
+
+	quiet.Store(true)
+	defer quiet.Store(false)
+
+	start := time.Now()
+	for _, order := range orders {
+		ProcessPayment(order.Payment, order.Amount)
+	}
+	sequential := time.Since(start)
+
+	report := ProcessPayments(orders)
+	report.Print()
+	fmt.Printf("⏱️ Uno por uno: %v | ProcessPayments: %v\n", sequential.Round(time.Millisecond), report.Duration.Round(time.Millisecond))
+}
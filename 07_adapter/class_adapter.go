@@ -0,0 +1,72 @@
+package main
+
+import "fmt"
+
+// PayInstallments es otro método de CreditCardPayment: divide el cobro en cuotas
+// llamando a su propio Pay para cada una
+func (c CreditCardPayment) PayInstallments(userAccountID int, amountCents int64, installments int) error {
+	for range installments {
+		if _, err := c.Pay(userAccountID, amountCents/int64(installments)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreditCardClassAdapter es la versión "adaptador de clase" de CreditCardPaymentAdapter.
+// Go no tiene herencia, pero al incrustar (embedding) CreditCardPayment se obtiene algo
+// parecido: todos sus métodos se promueven al adaptador, salvo los que el adaptador
+// vuelve a declarar con el mismo nombre, que los ocultan (como Pay).
+//
+// CreditCardPaymentAdapter, en cambio, es un "adaptador de objeto": guarda el objeto
+// incompatible en un campo y solo expone lo que declara.
+type CreditCardClassAdapter struct {
+	CreditCardPayment // Incrustado: sus métodos se promueven
+	UserAccountID     int
+}
+
+// Pay oculta el CreditCardPayment.Pay promovido y cumple IPayment
+func (a CreditCardClassAdapter) Pay(amount float64) (Receipt, error) {
+	authorizationCode, err := a.CreditCardPayment.Pay(a.UserAccountID, toCents(amount))
+	if err != nil {
+		return Receipt{}, fmt.Errorf("%w: %w", ErrPaymentDeclined, err)
+	}
+	receipt := newReceipt("credit", amount)
+	receipt.Reference = authorizationCode
+	return receipt, nil
+}
+
+// demonstrateClassVsObjectAdapter compara las dos formas de adaptador con salidas
+// ejecutables en lugar de solo describirlas.
+func demonstrateClassVsObjectAdapter() {
+	fmt.Println("\n🧬 Adaptador de clase (embedding) vs adaptador de objeto (composición):")
+	objectAdapter := CreditCardPaymentAdapter{CreditCardPayment: &CreditCardPayment{}, UserAccountID: 12345}
+	classAdapter := CreditCardClassAdapter{UserAccountID: 12345} // El valor cero del incrustado ya sirve
+
+	// 1. Los dos cumplen IPayment y se usan igual
+	fmt.Println("1️⃣ Ambos cumplen IPayment:")
+	printResult(ProcessPayment(objectAdapter, 10))
+	printResult(ProcessPayment(classAdapter, 10))
+
+	// 2. Promoción: el adaptador de clase expone también la API incompatible
+	type reverser interface {
+		Reverse(authorizationCode string, amountCents int64) error
+	}
+	_, objectReverses := any(objectAdapter).(reverser)
+	_, classReverses := any(classAdapter).(reverser)
+	fmt.Printf("2️⃣ ¿Expone Reverse? objeto: %t, clase: %t (promovido desde CreditCardPayment)\n", objectReverses, classReverses)
+	classAdapter.Reverse("AUTH-12345-1000", 1000) // Se puede llamar sin pasar por el adaptador
+
+	// 3. Ocultar no es sobrescribir: el método promovido PayInstallments llama al Pay de
+	// CreditCardPayment, no al del adaptador, porque en Go no hay despacho virtual
+	fmt.Println("3️⃣ PayInstallments promovido, en 2 cuotas:")
+	classAdapter.PayInstallments(12345, 5000, 2)
+	fmt.Println("   ↳ No se generó ningún Receipt: el Pay del adaptador no participó")
+
+	// 4. El método original sigue accesible con el nombre del tipo incrustado
+	code, _ := classAdapter.CreditCardPayment.Pay(12345, 100)
+	fmt.Println("4️⃣ Pay original vía classAdapter.CreditCardPayment.Pay:", code)
+
+	fmt.Println("💡 Composición: encapsula el objeto adaptado y permite cambiarlo (o compartirlo) en tiempo de ejecución.")
+	fmt.Println("💡 Embedding: menos código, pero filtra la API incompatible y puede confundir con herencia.")
+}
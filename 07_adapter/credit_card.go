@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// 2. Definición de la clase incompatible y el adaptador
+
+// ErrCardDeclined es el error propio del sistema de tarjetas cuando la compra supera el cupo.
+var ErrCardDeclined = errors.New("tarjeta rechazada: cupo insuficiente")
+
+// creditLimitCents es el cupo de las tarjetas del sistema simulado.
+const creditLimitCents = 1_000_000
+
+// CreditCardPayment representa un sistema de pago con tarjeta de crédito INCOMPATIBLE
+// Esta clase tiene una interfaz diferente (requiere userAccountID y cobra en centavos)
+// NO puede ser usado directamente con IPayment - necesita un adaptador
+type CreditCardPayment struct{}
+
+// Pay es el método original de CreditCardPayment que NO es compatible con IPayment
+// Requiere un userAccountID y el monto en centavos, y retorna un código de autorización
+// en lugar de un Receipt
+func (CreditCardPayment) Pay(userAccountID int, amountCents int64) (authorizationCode string, err error) {
+	if amountCents > creditLimitCents {
+		return "", ErrCardDeclined
+	}
+	logf("💳 Pagando %d centavos desde la cuenta de usuario %d usando tarjeta de crédito\n", amountCents, userAccountID)
+	return fmt.Sprintf("AUTH-%d-%d", userAccountID, amountCents), nil
+}
+
+// CreditCardPaymentAdapter es el ADAPTADOR que hace compatible CreditCardPayment con IPayment
+// Implementa el patrón Adapter siguiendo estos principios:
+// 1. Implementa la interfaz objetivo (IPayment)
+// 2. Contiene una instancia del objeto incompatible (CreditCardPayment)
+// 3. Almacena los datos necesarios para la adaptación (UserAccountID)
+type CreditCardPaymentAdapter struct {
+	CreditCardPayment *CreditCardPayment // La clase incompatible que queremos adaptar
+	UserAccountID     int                // Datos adicionales necesarios para la adaptación
+}
+
+// Pay implementa la interfaz IPayment en el adaptador
+// Esta es la "traducción" que hace que CreditCardPayment sea compatible con IPayment:
+// convierte el monto a centavos, agrega el userAccountID, traduce el rechazo de la
+// tarjeta a ErrPaymentDeclined y construye el Receipt a partir del resultado
+func (cca CreditCardPaymentAdapter) Pay(amount float64) (Receipt, error) {
+	authorizationCode, err := cca.CreditCardPayment.Pay(cca.UserAccountID, toCents(amount))
+	if err != nil {
+		return Receipt{}, fmt.Errorf("%w: %w", ErrPaymentDeclined, err)
+	}
+	receipt := newReceipt("credit", amount)
+	receipt.Reference = authorizationCode // Hace falta para reversar el cobro
+	return receipt, nil
+}
+
+// toCents convierte dólares a centavos redondeando al centavo más cercano.
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
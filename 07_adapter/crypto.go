@@ -0,0 +1,41 @@
+package main
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidWallet es el error del sistema de criptomonedas para direcciones mal formadas.
+var ErrInvalidWallet = errors.New("dirección de billetera inválida")
+
+// btcPriceUSD es el precio fijo de 1 BTC en dólares que usa el ejemplo.
+const btcPriceUSD = 60_000.0
+
+// CryptoPayment es otro sistema INCOMPATIBLE: envía bitcoins (no dólares) a una
+// dirección y retorna el hash de la transacción
+type CryptoPayment struct{}
+
+func (CryptoPayment) Send(toWallet string, amountBTC float64) (txHash string, err error) {
+	if !strings.HasPrefix(toWallet, "bc1") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidWallet, toWallet)
+	}
+	logf("🪙 Enviando %.8f BTC a la billetera %s\n", amountBTC, toWallet)
+	hash := sha256.Sum256(fmt.Appendf(nil, "%s:%.8f", toWallet, amountBTC))
+	return fmt.Sprintf("%x", hash[:8]), nil
+}
+
+// CryptoPaymentAdapter convierte el monto en dólares a BTC y el hash de la
+// transacción en un Receipt
+type CryptoPaymentAdapter struct {
+	CryptoPayment *CryptoPayment
+	Wallet        string
+}
+
+func (ca CryptoPaymentAdapter) Pay(amount float64) (Receipt, error) {
+	if _, err := ca.CryptoPayment.Send(ca.Wallet, amount/btcPriceUSD); err != nil {
+		return Receipt{}, fmt.Errorf("%w: %w", ErrPaymentDeclined, err)
+	}
+	return newReceipt("crypto", amount), nil
+}
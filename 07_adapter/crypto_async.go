@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrConfirmationTimeout indica que la red no confirmó la transacción a tiempo.
+var ErrConfirmationTimeout = errors.New("la transacción no se confirmó a tiempo")
+
+// maxTransferBTC es el saldo de la billetera de origen del ejemplo.
+const maxTransferBTC = 1.0
+
+// Confirmation es el aviso de la red de que una transacción quedó (o no) en un bloque.
+type Confirmation struct {
+	TxHash string
+	Block  int64
+	Err    error // Si la red rechazó la transacción
+}
+
+// SendAsync es la API asíncrona del SDK de criptomonedas: publica la transacción,
+// retorna su hash de inmediato y envía la confirmación por el canal cuando se mina el
+// siguiente bloque (tras blockTime). El canal tiene buffer, así que el SDK no se bloquea
+// si nadie espera la confirmación.
+func (c CryptoPayment) SendAsync(toWallet string, amountBTC float64, blockTime time.Duration) (txHash string, confirmed <-chan Confirmation, err error) {
+	txHash, err = c.Send(toWallet, amountBTC)
+	if err != nil {
+		return "", nil, err
+	}
+	confirmations := make(chan Confirmation, 1)
+	go func() {
+		time.Sleep(blockTime)
+		if amountBTC > maxTransferBTC {
+			confirmations <- Confirmation{TxHash: txHash, Err: fmt.Errorf("la red rechazó %s: fondos insuficientes", txHash)}
+			return
+		}
+		confirmations <- Confirmation{TxHash: txHash, Block: time.Now().Unix() / 600}
+	}()
+	return txHash, confirmations, nil
+}
+
+// AsyncCryptoPaymentAdapter traduce el modelo asíncrono del SDK (publicar y esperar la
+// confirmación en un canal) a la interfaz síncrona IPayment: Pay bloquea hasta que
+// llega la confirmación, vence Timeout o se cancela el contexto.
+type AsyncCryptoPaymentAdapter struct {
+	CryptoPayment *CryptoPayment
+	Wallet        string
+	BlockTime     time.Duration // Lo que tarda la red simulada en confirmar
+	Timeout       time.Duration
+}
+
+func (a AsyncCryptoPaymentAdapter) Pay(amount float64) (Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.Timeout)
+	defer cancel()
+	return a.PayContext(ctx, amount)
+}
+
+// PayContext publica la transacción y espera su confirmación hasta que se cancele ctx.
+func (a AsyncCryptoPaymentAdapter) PayContext(ctx context.Context, amount float64) (Receipt, error) {
+	txHash, confirmed, err := a.CryptoPayment.SendAsync(a.Wallet, amount/btcPriceUSD, a.BlockTime)
+	if err != nil {
+		return Receipt{}, fmt.Errorf("%w: %w", ErrPaymentDeclined, err)
+	}
+
+	select {
+	case confirmation := <-confirmed:
+		if confirmation.Err != nil {
+			return Receipt{}, fmt.Errorf("%w: %w", ErrPaymentDeclined, confirmation.Err)
+		}
+		receipt := newReceipt("crypto", amount)
+		receipt.Reference = fmt.Sprintf("%s@%d", confirmation.TxHash, confirmation.Block)
+		return receipt, nil
+	case <-ctx.Done():
+		// La transacción ya se publicó: el hash permite consultarla después
+		return Receipt{}, fmt.Errorf("%w (tx %s): %w", ErrConfirmationTimeout, txHash, ctx.Err())
+	}
+}
+
+// demonstrateAsyncCrypto espera confirmaciones que llegan a tiempo, tarde y con rechazo.
+func demonstrateAsyncCrypto() {
+	fmt.Println("\n⛓️ Adaptador síncrono sobre confirmaciones asíncronas:")
+	wallet := "bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh"
+	fast := AsyncCryptoPaymentAdapter{CryptoPayment: &CryptoPayment{}, Wallet: wallet, BlockTime: 200 * time.Millisecond, Timeout: time.Second}
+	slow := AsyncCryptoPaymentAdapter{CryptoPayment: &CryptoPayment{}, Wallet: wallet, BlockTime: 2 * time.Second, Timeout: 300 * time.Millisecond}
+
+	start := time.Now()
+	printResult(ProcessPayment(fast, 300))
+	fmt.Printf("   ⏱️ Pay bloqueó %v esperando el bloque\n", time.Since(start).Round(100*time.Millisecond))
+	printResult(ProcessPayment(slow, 300))
+	printResult(ProcessPayment(fast, 90_000)) // 1.5 BTC: la red la rechaza al confirmar
+
+	// Con PayContext el cliente puede cancelar la espera por su cuenta
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err := fast.PayContext(ctx, 300)
+	fmt.Println("🛑 Cancelado por el cliente:", err)
+}
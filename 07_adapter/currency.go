@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ErrUnsupportedCurrency indica que no hay tasa de cambio para la moneda.
+var ErrUnsupportedCurrency = errors.New("moneda no soportada")
+
+// Money es un monto en una moneda (código ISO 4217: USD, EUR, JPY...).
+type Money struct {
+	Amount   float64
+	Currency string
+}
+
+func (m Money) String() string {
+	return strconv.FormatFloat(m.Amount, 'f', -1, 64) + " " + m.Currency
+}
+
+// ExchangeRateProvider da la tasa para convertir de una moneda a otra. Se inyecta en
+// el adaptador para poder usar tasas fijas en el ejemplo y un servicio real en producción.
+type ExchangeRateProvider interface {
+	Rate(from, to string) (float64, error)
+}
+
+// StaticRates son tasas fijas: cuántos dólares vale una unidad de cada moneda.
+type StaticRates map[string]float64
+
+func (r StaticRates) Rate(from, to string) (float64, error) {
+	if to != "USD" {
+		return 0, fmt.Errorf("%w: solo se convierte a USD, no a %s", ErrUnsupportedCurrency, to)
+	}
+	if from == "USD" {
+		return 1, nil
+	}
+	rate, ok := r[from]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, from)
+	}
+	return rate, nil
+}
+
+// LegacyUSDPayment es un sistema de cobro antiguo que solo entiende centavos de dólar
+// como int64, y retorna un número de confirmación
+type LegacyUSDPayment struct {
+	confirmations int
+}
+
+func (l *LegacyUSDPayment) Charge(amountCents int64) (confirmation int, err error) {
+	if amountCents <= 0 {
+		return 0, fmt.Errorf("monto inválido: %d centavos", amountCents)
+	}
+	l.confirmations++
+	return 70000 + l.confirmations, nil
+}
+
+// CurrencyAdapter adapta LegacyUSDPayment a montos en cualquier moneda: convierte a
+// dólares con Rates y redondea a centavos. También implementa IPayment para montos
+// en Currency.
+type CurrencyAdapter struct {
+	Legacy   *LegacyUSDPayment
+	Rates    ExchangeRateProvider
+	Currency string // Moneda de los montos que recibe Pay
+}
+
+func (ca CurrencyAdapter) Pay(amount float64) (Receipt, error) {
+	return ca.PayMoney(Money{Amount: amount, Currency: ca.Currency})
+}
+
+// PayMoney convierte money a centavos de dólar y lo cobra con el sistema antiguo. El
+// recibo queda en dólares, con el monto original como referencia.
+func (ca CurrencyAdapter) PayMoney(money Money) (Receipt, error) {
+	rate, err := ca.Rates.Rate(money.Currency, "USD")
+	if err != nil {
+		return Receipt{}, err
+	}
+	cents := roundCents(money.Amount * rate)
+	if cents <= 0 {
+		return Receipt{}, fmt.Errorf("%v equivale a %d centavos: %w", money, cents, ErrInvalidAmount)
+	}
+	confirmation, err := ca.Legacy.Charge(cents)
+	if err != nil {
+		return Receipt{}, fmt.Errorf("%w: %w", ErrPaymentDeclined, err)
+	}
+	receipt := newReceipt("legacy", float64(cents)/100)
+	receipt.Reference = fmt.Sprintf("%v #%d", money, confirmation)
+	return receipt, nil
+}
+
+// roundCents convierte dólares a centavos con las reglas de redondeo del ejemplo:
+//   - se convierte primero y se redondea una sola vez, al final, para no acumular errores;
+//   - las mitades exactas van al par más cercano (redondeo bancario), así que en muchos
+//     cobros no se favorece ni al cliente ni al comercio;
+//   - antes se descarta el ruido de float64 (0.145*100 = 14.499999999999998) redondeando
+//     a 6 decimales, para que 0.145 cuente como la mitad exacta que es.
+func roundCents(dollars float64) int64 {
+	cents := math.Round(dollars*100*1e6) / 1e6
+	return int64(math.RoundToEven(cents))
+}
+
+// demonstrateCurrencyAdapter muestra los centavos que produce la conversión y el
+// redondeo en varios montos y monedas, y los errores del dominio.
+func demonstrateCurrencyAdapter() {
+	fmt.Println("\n💱 Adaptador de monedas sobre un sistema que solo acepta centavos de USD:")
+	adapter := CurrencyAdapter{
+		Legacy: &LegacyUSDPayment{},
+		Rates:  StaticRates{"EUR": 1.08, "JPY": 0.0067, "COP": 0.00025},
+	}
+
+	amounts := []Money{
+		{10, "USD"},
+		{10, "EUR"},
+		{1500, "JPY"},
+		{250_000, "COP"},
+		{0.125, "USD"}, // Mitad exacta: al par (12)
+		{0.145, "USD"}, // Mitad exacta pese al ruido de float64: al par (14)
+		{0.135, "USD"}, // Mitad exacta: al par (14)
+		{0.004, "USD"}, // Menos de un centavo
+		{10, "GBP"},    // Moneda sin tasa
+	}
+	for _, money := range amounts {
+		receipt, err := adapter.PayMoney(money)
+		if err != nil {
+			fmt.Printf("❌ %-14v -> %v\n", money, err)
+		} else {
+			fmt.Printf("💵 %-14v -> %d centavos (%v)\n", money, toCents(receipt.Amount), receipt.Reference)
+		}
+	}
+
+	// Como también es un IPayment, funciona con el flujo normal de pagos
+	euros := CurrencyAdapter{Legacy: adapter.Legacy, Rates: adapter.Rates, Currency: "EUR"}
+	printResult(ProcessPayment(euros, 49.99))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRoundCents(t *testing.T) {
+	tests := []struct {
+		dollars float64
+		want    int64
+	}{
+		{10, 1000},
+		{0.011, 1},
+		{0.019, 2},
+		{0.125, 12}, // Mitad exacta: al par
+		{0.135, 14}, // Mitad exacta: al par
+		{0.145, 14}, // Mitad exacta pese al ruido de float64 (14.499999999999998)
+		{0.155, 16},
+		{0.004, 0},
+		{-0.125, -12},
+	}
+	for _, tt := range tests {
+		if got := roundCents(tt.dollars); got != tt.want {
+			t.Errorf("roundCents(%v) = %d, quiero %d", tt.dollars, got, tt.want)
+		}
+	}
+}
+
+func TestCurrencyAdapter(t *testing.T) {
+	rates := StaticRates{"EUR": 1.08, "JPY": 0.0067, "COP": 0.00025}
+	tests := []struct {
+		money     Money
+		wantCents int64
+		wantErr   error
+	}{
+		{Money{10, "USD"}, 1000, nil},
+		{Money{10, "EUR"}, 1080, nil},
+		{Money{1500, "JPY"}, 1005, nil},
+		{Money{250_000, "COP"}, 6250, nil},
+		{Money{0.125, "USD"}, 12, nil},
+		{Money{0.145, "USD"}, 14, nil},
+		{Money{0.004, "USD"}, 0, ErrInvalidAmount},
+		{Money{-10, "EUR"}, 0, ErrInvalidAmount},
+		{Money{10, "GBP"}, 0, ErrUnsupportedCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.money.String(), func(t *testing.T) {
+			adapter := CurrencyAdapter{Legacy: &LegacyUSDPayment{}, Rates: rates}
+			receipt, err := adapter.PayMoney(tt.money)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, quiero %v", err, tt.wantErr)
+			}
+			if got := toCents(receipt.Amount); got != tt.wantCents {
+				t.Errorf("centavos cobrados = %d, quiero %d", got, tt.wantCents)
+			}
+			if err == nil && receipt.Method != "legacy" {
+				t.Errorf("método = %q, quiero legacy", receipt.Method)
+			}
+		})
+	}
+}
+
+func TestStaticRatesOnlyConvertToUSD(t *testing.T) {
+	if _, err := (StaticRates{"EUR": 1.08}).Rate("USD", "EUR"); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("Rate(USD, EUR): err = %v, quiero ErrUnsupportedCurrency", err)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// Los decoradores de este archivo envuelven cualquier IPayment (un pago directo o un
+// adaptador) y también cumplen IPayment, así que se pueden apilar y el cliente no nota
+// la diferencia: Decorator agrega comportamiento, Adapter cambia la interfaz.
+
+// unwrapper lo cumplen los decoradores, para poder llegar al pago que envuelven.
+type unwrapper interface {
+	Unwrap() IPayment
+}
+
+// paymentName retorna el nombre del tipo del pago de más adentro, atravesando los
+// decoradores: "CreditCardPaymentAdapter", "CashPayment"...
+func paymentName(p IPayment) string {
+	for {
+		wrapper, ok := p.(unwrapper)
+		if !ok {
+			break
+		}
+		p = wrapper.Unwrap()
+	}
+	name := fmt.Sprintf("%T", p)
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// loggingPayment registra cada cobro y su resultado.
+type loggingPayment struct {
+	payment IPayment
+}
+
+// NewLoggingPayment envuelve p para mostrar cada cobro, su resultado y cuánto tardó.
+func NewLoggingPayment(p IPayment) IPayment {
+	return loggingPayment{payment: p}
+}
+
+func (l loggingPayment) Pay(amount float64) (Receipt, error) {
+	name := paymentName(l.payment)
+	fmt.Printf("📝 → %s.Pay($%.2f)\n", name, amount)
+	start := time.Now()
+	receipt, err := l.payment.Pay(amount)
+	elapsed := time.Since(start).Round(time.Microsecond)
+	if err != nil {
+		fmt.Printf("📝 ← %s falló en %v: %v\n", name, elapsed, err)
+	} else {
+		fmt.Printf("📝 ← %s ok en %v: %s\n", name, elapsed, receipt.ID)
+	}
+	return receipt, err
+}
+
+func (l loggingPayment) Unwrap() IPayment { return l.payment }
+
+// MethodStats son las métricas acumuladas de un método de pago.
+type MethodStats struct {
+	Calls        int
+	Errors       int
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// AverageLatency retorna la latencia promedio por cobro.
+func (s MethodStats) AverageLatency() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Calls)
+}
+
+// methodMetrics son las métricas de un método, con su propio lock para que los cobros
+// de métodos distintos no compitan entre sí.
+type methodMetrics struct {
+	mu    sync.Mutex
+	stats MethodStats
+}
+
+// paymentMetrics guarda las métricas por nombre de método (ver paymentName).
+var paymentMetrics syncutil.SafeMap[string, *methodMetrics]
+
+// meteredPayment mide cada cobro.
+type meteredPayment struct {
+	payment IPayment
+	metrics *methodMetrics
+}
+
+// NewMeteredPayment envuelve p para contar sus cobros, errores y latencias. Los pagos del
+// mismo tipo comparten contadores; se consultan con PaymentMetrics.
+func NewMeteredPayment(p IPayment) IPayment {
+	metrics, _ := paymentMetrics.GetOrSet(paymentName(p), &methodMetrics{})
+	return meteredPayment{payment: p, metrics: metrics}
+}
+
+func (m meteredPayment) Pay(amount float64) (Receipt, error) {
+	start := time.Now()
+	receipt, err := m.payment.Pay(amount)
+	elapsed := time.Since(start)
+
+	m.metrics.mu.Lock()
+	defer m.metrics.mu.Unlock()
+	m.metrics.stats.Calls++
+	if err != nil {
+		m.metrics.stats.Errors++
+	}
+	m.metrics.stats.TotalLatency += elapsed
+	m.metrics.stats.MaxLatency = max(m.metrics.stats.MaxLatency, elapsed)
+	return receipt, err
+}
+
+func (m meteredPayment) Unwrap() IPayment { return m.payment }
+
+// PaymentMetrics retorna una copia de las métricas de cada método de pago.
+func PaymentMetrics() map[string]MethodStats {
+	snapshot := map[string]MethodStats{}
+	paymentMetrics.Range(func(name string, metrics *methodMetrics) bool {
+		metrics.mu.Lock()
+		snapshot[name] = metrics.stats
+		metrics.mu.Unlock()
+		return true
+	})
+	return snapshot
+}
+
+// demonstrateDecorators apila los decoradores sobre adaptadores: un cobro con logging, y
+// un lote medido para obtener la latencia de cada método.
+func demonstrateDecorators() {
+	fmt.Println("\n🎀 Decoradores sobre adaptadores:")
+	card := CreditCardPaymentAdapter{CreditCardPayment: &CreditCardPayment{}, UserAccountID: 12345}
+	printResult(ProcessPayment(NewLoggingPayment(NewMeteredPayment(card)), 75))
+	printResult(ProcessPayment(NewLoggingPayment(card), 50_000))
+
+	latencies := map[IPayment]time.Duration{
+		CashPayment{}: 0,
+		BankPaymentAdapter{BankPayment: &BankPayment{}, AccountNumber: "987654324"}:                                 30 * time.Millisecond,
+		CryptoPaymentAdapter{CryptoPayment: &CryptoPayment{}, Wallet: "bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh"}: 10 * time.Millisecond,
+	}
+	var orders []PaymentOrder
+	for payment, latency := range latencies {
+		metered := NewMeteredPayment(slowPayment{payment, latency})
+		for i := range 10 {
+			// El primero es de $0: ProcessPayment lo rechaza antes de llegar al decorador
+			orders = append(orders, PaymentOrder{Payment: metered, Amount: float64(i * 10)})
+		}
+	}
+	// Comparte las métricas de BankPaymentAdapter, pero la cuenta no existe
+	badAccount := NewMeteredPayment(BankPaymentAdapter{BankPayment: &BankPayment{}, AccountNumber: "000"})
+	orders = append(orders, PaymentOrder{Payment: badAccount, Amount: 10})
+	quiet.Store(true)
+	ProcessPayments(orders)
+	quiet.Store(false)
+
+	metrics := PaymentMetrics()
+	fmt.Printf("   %-26s %6s %7s %10s %10s\n", "Método", "Cobros", "Errores", "Promedio", "Máxima")
+	for _, name := range slices.Sorted(maps.Keys(metrics)) {
+		stats := metrics[name]
+		fmt.Printf("   %-26s %6d %7d %10v %10v\n", name, stats.Calls, stats.Errors,
+			stats.AverageLatency().Round(time.Microsecond), stats.MaxLatency.Round(time.Microsecond))
+	}
+}
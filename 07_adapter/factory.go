@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// Configuración de cada método de pago. Cada adaptador necesita datos distintos, así
+// que cada uno tiene su propio struct en lugar de un map genérico.
+type (
+	CreditCardConfig struct {
+		UserAccountID int `json:"user_account_id"`
+	}
+	BankConfig struct {
+		AccountNumber string `json:"account_number"`
+	}
+	CryptoConfig struct {
+		Wallet string `json:"wallet"`
+	}
+)
+
+// PaymentConfig agrupa la configuración de todos los métodos de pago.
+type PaymentConfig struct {
+	Credit CreditCardConfig `json:"credit"`
+	Bank   BankConfig       `json:"bank"`
+	Crypto CryptoConfig     `json:"crypto"`
+}
+
+// ErrInvalidPaymentConfig indica que la configuración de un método de pago está incompleta.
+var ErrInvalidPaymentConfig = errors.New("configuración de pago inválida")
+
+// NewPaymentFactory registra un constructor por método de pago (Factory + Adapter): el
+// cliente pide "credit" o "bank" y recibe un IPayment listo para usar, sin saber qué
+// adaptador y qué sistema incompatible hay detrás. Cada constructor valida su parte de
+// config al crear el pago: los datos que faltan son ErrInvalidPaymentConfig y los mal
+// formados, además, ErrInvalidAccount.
+func NewPaymentFactory(config PaymentConfig) *factory.Factory[IPayment] {
+	payments := &factory.Factory[IPayment]{}
+	must := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+	must(factory.Register(payments, "cash", func() IPayment {
+		return CashPayment{}
+	}))
+	must(factory.Register(payments, "credit", func() (IPayment, error) {
+		if config.Credit.UserAccountID <= 0 {
+			return nil, fmt.Errorf("❌ credit: falta user_account_id: %w", ErrInvalidPaymentConfig)
+		}
+		return validated(NewCreditCardPaymentAdapter(config.Credit.UserAccountID))
+	}))
+	must(factory.Register(payments, "bank", func() (IPayment, error) {
+		if config.Bank.AccountNumber == "" {
+			return nil, fmt.Errorf("❌ bank: falta account_number: %w", ErrInvalidPaymentConfig)
+		}
+		return validated(NewBankPaymentAdapter(config.Bank.AccountNumber))
+	}))
+	must(factory.Register(payments, "crypto", func() (IPayment, error) {
+		if config.Crypto.Wallet == "" {
+			return nil, fmt.Errorf("❌ crypto: falta wallet: %w", ErrInvalidPaymentConfig)
+		}
+		return validated(NewCryptoPaymentAdapter(config.Crypto.Wallet))
+	}))
+	return payments
+}
+
+// validated convierte el resultado de un constructor de adaptador al de un constructor
+// de la fábrica, marcando los errores de validación como ErrInvalidPaymentConfig.
+func validated[P IPayment](payment P, err error) (IPayment, error) {
+	if err != nil {
+		return nil, fmt.Errorf("❌ %w: %w", ErrInvalidPaymentConfig, err)
+	}
+	return payment, nil
+}
+
+// demonstratePaymentFactory elige el método de pago a partir de un texto, como lo haría
+// una aplicación con lo que escribió el usuario. Si se ejecuta con un argumento
+// (go run ./07_adapter crypto) se usa solo ese método.
+func demonstratePaymentFactory(input []string) {
+	fmt.Println("\n🏭 Métodos de pago elegidos en tiempo de ejecución:")
+	payments := NewPaymentFactory(PaymentConfig{
+		Credit: CreditCardConfig{UserAccountID: 12345},
+		Bank:   BankConfig{AccountNumber: "987654324"},
+		Crypto: CryptoConfig{Wallet: "bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh"},
+	})
+	fmt.Println("📋 Métodos disponibles:", payments.Keys())
+
+	methods := append(payments.Keys(), "paypal")
+	if len(input) > 0 {
+		methods = input
+	}
+	for _, method := range methods {
+		payment, err := payments.Create(method)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		printResult(ProcessPayment(payment, 250))
+	}
+
+	// Un método registrado pero sin configurar falla al crearse, no al cobrar
+	_, err := NewPaymentFactory(PaymentConfig{}).Create("bank")
+	fmt.Println("🔎", err, "| errors.Is(err, ErrInvalidPaymentConfig):", errors.Is(err, ErrInvalidPaymentConfig))
+	_, err = NewPaymentFactory(PaymentConfig{Bank: BankConfig{AccountNumber: "987654321"}}).Create("bank")
+	fmt.Println("🔎", err, "| errors.Is(err, ErrInvalidAccount):", errors.Is(err, ErrInvalidAccount))
+}
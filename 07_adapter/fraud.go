@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/rediscache"
+)
+
+// Los controles antifraude forman una cadena de responsabilidad (Chain of
+// Responsibility) que ProcessPayment recorre antes de llamar al adaptador: cada
+// eslabón revisa el pago y decide si lo rechaza, si lo aprueba sin consultar a los
+// demás o si se lo pasa al siguiente. Como los controles solo ven un PaymentRequest,
+// sirven igual para cualquier método de pago, adaptado o no.
+
+// ErrFraudSuspected indica que un control antifraude bloqueó el pago.
+var ErrFraudSuspected = errors.New("pago bloqueado por prevención de fraude")
+
+// PaymentRequest es lo que revisan los controles antifraude.
+type PaymentRequest struct {
+	Payment IPayment
+	Amount  float64
+	Account string // Cuenta que paga, ej. "bank:987654324"; vacío si el método no tiene cuenta
+}
+
+// FraudCheck es un eslabón de la cadena. Para dejar seguir el pago llama a next;
+// retornar sin llamarlo corta la cadena (con un error lo rechaza, con nil lo aprueba).
+type FraudCheck func(req PaymentRequest, next func(PaymentRequest) error) error
+
+// runFraudChecks enlaza checks en orden y recorre la cadena con req.
+func runFraudChecks(req PaymentRequest, checks []FraudCheck) error {
+	var next func(PaymentRequest) error
+	next = func(PaymentRequest) error { return nil } // Final de la cadena: nadie rechazó el pago
+	for _, check := range slices.Backward(checks) {
+		rest := next
+		next = func(req PaymentRequest) error { return check(req, rest) }
+	}
+	return next(req)
+}
+
+// accountOwner lo cumplen los pagos que se hacen desde una cuenta identificable.
+type accountOwner interface {
+	Account() string
+}
+
+// paymentAccount retorna la cuenta del pago de más adentro, atravesando los decoradores.
+func paymentAccount(p IPayment) string {
+	for {
+		if owner, ok := p.(accountOwner); ok {
+			return owner.Account()
+		}
+		wrapper, ok := p.(unwrapper)
+		if !ok {
+			return ""
+		}
+		p = wrapper.Unwrap()
+	}
+}
+
+func (cca CreditCardPaymentAdapter) Account() string {
+	return fmt.Sprintf("credit:%d", cca.UserAccountID)
+}
+
+func (ba BankPaymentAdapter) Account() string {
+	return "bank:" + ba.AccountNumber
+}
+
+func (ca CryptoPaymentAdapter) Account() string {
+	return "crypto:" + ca.Wallet
+}
+
+func (ga GatewayPaymentAdapter) Account() string {
+	return "stripe:" + ga.Source
+}
+
+// MaxAmount rechaza los pagos que superan limit.
+func MaxAmount(limit float64) FraudCheck {
+	return func(req PaymentRequest, next func(PaymentRequest) error) error {
+		if req.Amount > limit {
+			return fmt.Errorf("%w: $%.2f supera el límite de $%.2f", ErrFraudSuspected, req.Amount, limit)
+		}
+		return next(req)
+	}
+}
+
+// Blocklist rechaza los pagos desde las cuentas indicadas.
+func Blocklist(accounts ...string) FraudCheck {
+	return func(req PaymentRequest, next func(PaymentRequest) error) error {
+		if slices.Contains(accounts, req.Account) {
+			return fmt.Errorf("%w: la cuenta %s está bloqueada", ErrFraudSuspected, req.Account)
+		}
+		return next(req)
+	}
+}
+
+// TrustedAccounts aprueba directamente los pagos de las cuentas indicadas: los
+// controles que vienen después en la cadena no se ejecutan para ellas.
+func TrustedAccounts(accounts ...string) FraudCheck {
+	return func(req PaymentRequest, next func(PaymentRequest) error) error {
+		if slices.Contains(accounts, req.Account) {
+			logf("🤝 %s es de confianza, se omiten los demás controles\n", req.Account)
+			return nil
+		}
+		return next(req)
+	}
+}
+
+// Velocity rechaza una cuenta que intenta más de limit pagos dentro de window. Los
+// intentos se cuentan con INCR en store, así que varios procesos que comparten el
+// store comparten también el límite.
+func Velocity(store *rediscache.SimpleRedisCache, limit int, window time.Duration) FraudCheck {
+	return func(req PaymentRequest, next func(PaymentRequest) error) error {
+		if req.Account == "" {
+			return next(req)
+		}
+		if attempts := store.Incr("velocity:"+req.Account, window); attempts > int64(limit) {
+			return fmt.Errorf("%w: %s hizo %d intentos en menos de %v", ErrFraudSuspected, req.Account, attempts, window)
+		}
+		return next(req)
+	}
+}
+
+// demonstrateFraudChecks cobra varios pagos pasando por una cadena de controles
+// antifraude y muestra qué eslabón detuvo cada uno.
+func demonstrateFraudChecks() {
+	fmt.Println("\n🕵️ Controles antifraude antes del adaptador (Chain of Responsibility):")
+	store := rediscache.NewSimpleRedisCache()
+	store.SetLogging(false)
+	checks := []FraudCheck{
+		MaxAmount(5_000),
+		TrustedAccounts("credit:1"),
+		Blocklist("bank:123456782"),
+		Velocity(store, 3, time.Second),
+	}
+
+	card, _ := NewCreditCardPaymentAdapter(12345)
+	trusted, _ := NewCreditCardPaymentAdapter(1)
+	blocked, _ := NewBankPaymentAdapter("123456782")
+	payments := []struct {
+		label   string
+		payment IPayment
+		amount  float64
+	}{
+		{"efectivo", CashPayment{}, 80},
+		{"monto excesivo", card, 9_000},
+		{"cuenta bloqueada", blocked, 50},
+		{"cuenta bloqueada con logging", NewLoggingPayment(blocked), 50},
+		{"cuenta de confianza", trusted, 20},
+	}
+	for _, p := range payments {
+		fmt.Printf("▶️ %s:\n", p.label)
+		printResult(ProcessPayment(p.payment, p.amount, checks...))
+	}
+
+	fmt.Println("▶️ Cinco pagos seguidos con la misma tarjeta (máximo 3 por segundo):")
+	quiet.Store(true)
+	for i := range 5 {
+		_, err := ProcessPayment(card, 10, checks...)
+		fmt.Printf("   intento %d: errors.Is(err, ErrFraudSuspected) = %t\n", i+1, errors.Is(err, ErrFraudSuspected))
+	}
+	quiet.Store(false)
+}
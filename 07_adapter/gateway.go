@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Este archivo simula el SDK de una pasarela de pagos externa estilo Stripe: tiene sus
+// propios tipos de petición y respuesta, cobra en centavos y reporta los fallos con
+// GatewayError. Como es código de un tercero, no se puede cambiar para que cumpla IPayment.
+
+// ChargeRequest es el cuerpo de POST /v1/charges.
+type ChargeRequest struct {
+	Amount      int64  `json:"amount"` // En centavos
+	Currency    string `json:"currency"`
+	Source      string `json:"source"` // Token de la tarjeta
+	Description string `json:"description,omitempty"`
+
+	// IdempotencyKey viaja en el header Idempotency-Key. Si la pasarela ya procesó un
+	// cobro con esa clave, retorna el mismo cobro en lugar de crear otro.
+	IdempotencyKey string `json:"-"`
+}
+
+// Charge es un cobro creado por la pasarela.
+type Charge struct {
+	ID       string `json:"id"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Status   string `json:"status"`
+	Created  int64  `json:"created"` // Unix timestamp
+}
+
+// GatewayError es el error que la pasarela retorna en el cuerpo de las respuestas fallidas.
+type GatewayError struct {
+	StatusCode int    `json:"-"`
+	Type       string `json:"type"` // card_error, api_error, authentication_error...
+	Code       string `json:"code"` // card_declined, insufficient_funds...
+	Message    string `json:"message"`
+}
+
+func (e *GatewayError) Error() string {
+	return fmt.Sprintf("gateway %d %s/%s: %s", e.StatusCode, e.Type, e.Code, e.Message)
+}
+
+// GatewayClient es el cliente HTTP del SDK.
+type GatewayClient struct {
+	BaseURL    string
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+// CreateCharge crea un cobro. Retorna *GatewayError si la pasarela respondió con un
+// error, o el error de red del cliente HTTP si no se pudo hablar con ella.
+func (c *GatewayClient) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/v1/charges", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+c.APIKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+	if req.IdempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", req.IdempotencyKey)
+	}
+
+	resp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var envelope struct {
+			Error *GatewayError `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil || envelope.Error == nil {
+			return nil, &GatewayError{StatusCode: resp.StatusCode, Type: "api_error", Message: "respuesta inválida"}
+		}
+		envelope.Error.StatusCode = resp.StatusCode
+		return nil, envelope.Error
+	}
+	var charge Charge
+	if err := json.NewDecoder(resp.Body).Decode(&charge); err != nil {
+		return nil, fmt.Errorf("respuesta inválida de la pasarela: %w", err)
+	}
+	return &charge, nil
+}
+
+// fakeGateway es el servidor de la pasarela simulada, para usar con httptest. Según el
+// token de la tarjeta responde distinto:
+//   - tok_visa: cobro exitoso
+//   - tok_declined: tarjeta rechazada (402)
+//   - tok_error: error interno de la pasarela (500)
+//   - tok_slow: tarda 2 segundos en responder
+//   - tok_flaky: falla con 503 los dos primeros intentos de cada clave de idempotencia
+//   - tok_lost: crea el cobro, pero en el primer intento tarda 2 segundos en responder,
+//     como si la respuesta se perdiera en la red
+//
+// Igual que Stripe, si recibe una Idempotency-Key ya usada retorna el cobro original.
+type fakeGateway struct {
+	apiKey   string
+	charges  atomic.Int64 // Cobros creados
+	requests atomic.Int64 // Peticiones recibidas
+
+	mu       sync.Mutex
+	byKey    map[string]Charge // Cobros por clave de idempotencia
+	attempts map[string]int    // Intentos por clave de idempotencia
+}
+
+// attempt registra un intento con key y retorna el cobro previo con esa clave, si existe.
+func (g *fakeGateway) attempt(key string) (previous Charge, found bool, attempt int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.byKey == nil {
+		g.byKey, g.attempts = map[string]Charge{}, map[string]int{}
+	}
+	g.attempts[key]++
+	previous, found = g.byKey[key]
+	return previous, found, g.attempts[key]
+}
+
+// remember guarda el cobro creado con key.
+func (g *fakeGateway) remember(key string, charge Charge) {
+	if key == "" {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.byKey[key] = charge
+}
+
+func (g *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.requests.Add(1)
+	if r.Method != http.MethodPost || r.URL.Path != "/v1/charges" {
+		writeGatewayError(w, http.StatusNotFound, "invalid_request_error", "not_found", "ruta desconocida")
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+g.apiKey {
+		writeGatewayError(w, http.StatusUnauthorized, "authentication_error", "invalid_api_key", "API key inválida")
+		return
+	}
+	var req ChargeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Amount <= 0 {
+		writeGatewayError(w, http.StatusBadRequest, "invalid_request_error", "invalid_amount", "petición inválida")
+		return
+	}
+
+	key := r.Header.Get("Idempotency-Key")
+	previous, found, attempt := g.attempt(key)
+	if key != "" && found {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(previous)
+		return
+	}
+
+	switch req.Source {
+	case "tok_flaky":
+		if attempt <= 2 {
+			writeGatewayError(w, http.StatusServiceUnavailable, "api_error", "unavailable", "servicio temporalmente no disponible")
+			return
+		}
+	case "tok_declined":
+		writeGatewayError(w, http.StatusPaymentRequired, "card_error", "card_declined", "la tarjeta fue rechazada")
+		return
+	case "tok_error":
+		writeGatewayError(w, http.StatusInternalServerError, "api_error", "internal", "error interno")
+		return
+	case "tok_slow":
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	charge := Charge{
+		ID:       fmt.Sprintf("ch_%06d", g.charges.Add(1)),
+		Amount:   req.Amount,
+		Currency: strings.ToLower(req.Currency),
+		Status:   "succeeded",
+		Created:  time.Now().Unix(),
+	}
+	g.remember(key, charge)
+	if req.Source == "tok_lost" && attempt == 1 {
+		select { // El cobro ya existe, pero el cliente no recibirá la respuesta a tiempo
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(charge)
+}
+
+// writeGatewayError escribe un error con el formato de la pasarela.
+func writeGatewayError(w http.ResponseWriter, status int, errType, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]*GatewayError{"error": {Type: errType, Code: code, Message: message}})
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/rediscache"
+)
+
+// Errores a los que GatewayPaymentAdapter traduce los fallos de la pasarela.
+var (
+	ErrGatewayUnavailable = errors.New("pasarela de pagos no disponible")
+	ErrGatewayAuth        = errors.New("credenciales de la pasarela inválidas")
+)
+
+// GatewayPaymentAdapter adapta el SDK de la pasarela externa a IPayment: convierte el
+// monto a centavos, arma el ChargeRequest, aplica un timeout y traduce los errores
+// HTTP y de red del SDK a los errores del dominio de pagos.
+//
+// Con Retries > 0 reintenta los fallos transitorios (ErrGatewayUnavailable) con
+// backoff exponencial. Todos los intentos de un pago usan la misma clave de
+// idempotencia, así que aunque un intento haya cobrado y su respuesta se perdiera, el
+// reintento recibe el mismo cobro y la tarjeta no se cobra dos veces.
+type GatewayPaymentAdapter struct {
+	Client  *GatewayClient
+	Source  string        // Token de la tarjeta
+	Timeout time.Duration // Tiempo máximo por intento
+	Retries int           // Reintentos ante fallos transitorios
+
+	// Store guarda los pagos completados por clave de idempotencia, para responder los
+	// pagos repetidos sin volver a llamar a la pasarela. Es opcional.
+	Store *rediscache.SimpleRedisCache
+}
+
+// Parámetros de los reintentos y de la deduplicación.
+const (
+	retryBaseDelay = 100 * time.Millisecond
+	idempotencyTTL = 24 * time.Hour
+)
+
+// ErrPaymentInProgress indica que ya hay un pago en curso con la misma clave de idempotencia.
+var ErrPaymentInProgress = errors.New("ya hay un pago en curso con esa clave de idempotencia")
+
+// Pay cobra con una clave de idempotencia nueva: reintentar este pago es seguro, pero
+// dos llamadas a Pay son dos pagos distintos.
+func (ga GatewayPaymentAdapter) Pay(amount float64) (Receipt, error) {
+	return ga.PayWithKey(newIdempotencyKey(), amount)
+}
+
+// PayWithKey cobra amount identificando el pago con key (por ejemplo el número de
+// orden). Si ese pago ya se completó, retorna el mismo recibo sin cobrar de nuevo.
+func (ga GatewayPaymentAdapter) PayWithKey(key string, amount float64) (Receipt, error) {
+	if ga.Store != nil {
+		if stored, ok := ga.Store.Get("payment:" + key); ok {
+			return stored.(Receipt), nil
+		}
+		// El lock evita que dos pedidos simultáneos con la misma clave lleguen a la pasarela.
+		// Guarda un token propio de esta llamada: si el TTL vence y otro pedido toma el
+		// lock, DeleteIfEquals no le borra su lock a ese otro pedido.
+		token := rand.Text()
+		if !ga.Store.SetNX("payment-lock:"+key, token, ga.Timeout*time.Duration(ga.Retries+1)+time.Minute) {
+			return Receipt{}, fmt.Errorf("%w: %s", ErrPaymentInProgress, key)
+		}
+		defer ga.Store.DeleteIfEquals("payment-lock:"+key, token)
+	}
+
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		receipt, err := ga.charge(key, amount)
+		if err == nil {
+			if ga.Store != nil {
+				ga.Store.Set("payment:"+key, receipt, idempotencyTTL)
+			}
+			return receipt, nil
+		}
+		if !errors.Is(err, ErrGatewayUnavailable) || attempt == ga.Retries {
+			return Receipt{}, err
+		}
+		logf("🔁 Intento %d de %s falló (%v), reintentando en %v\n", attempt+1, key, err, delay)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// charge hace un intento de cobro con la pasarela.
+func (ga GatewayPaymentAdapter) charge(key string, amount float64) (Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ga.Timeout)
+	defer cancel()
+
+	charge, err := ga.Client.CreateCharge(ctx, ChargeRequest{
+		Amount:         toCents(amount),
+		Currency:       "USD",
+		Source:         ga.Source,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		return Receipt{}, translateGatewayError(err)
+	}
+
+	receipt := newReceipt("stripe", float64(charge.Amount)/100)
+	receipt.ID = charge.ID // El recibo conserva el id de la pasarela para poder rastrear el cobro
+	receipt.Timestamp = time.Unix(charge.Created, 0)
+	receipt.Reference = key
+	return receipt, nil
+}
+
+// newIdempotencyKey genera una clave de idempotencia aleatoria.
+func newIdempotencyKey() string {
+	return "idem_" + rand.Text()[:16]
+}
+
+// translateGatewayError convierte un error del SDK en uno del dominio, conservando el
+// original en la cadena para poder inspeccionarlo con errors.As.
+func translateGatewayError(err error) error {
+	var gatewayErr *GatewayError
+	switch {
+	case errors.As(err, &gatewayErr) && gatewayErr.Type == "card_error":
+		return fmt.Errorf("%w: %w", ErrPaymentDeclined, err)
+	case errors.As(err, &gatewayErr) && gatewayErr.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("%w: %w", ErrGatewayAuth, err)
+	case errors.As(err, &gatewayErr) && gatewayErr.StatusCode >= http.StatusInternalServerError:
+		return fmt.Errorf("%w: %w", ErrGatewayUnavailable, err)
+	case errors.As(err, &gatewayErr):
+		return err
+	default: // Timeout, conexión rechazada, DNS...: nunca hubo respuesta
+		return fmt.Errorf("%w: %w", ErrGatewayUnavailable, err)
+	}
+}
+
+// demonstrateGateway levanta la pasarela simulada con httptest y muestra, caso por
+// caso, el recibo o el error de dominio que produce el adaptador.
+func demonstrateGateway() {
+	fmt.Println("\n🌐 Adaptador para una pasarela HTTP externa (httptest):")
+	server := httptest.NewServer(&fakeGateway{apiKey: "sk_test_123"})
+	defer server.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close() // Un servidor apagado: la conexión se rechaza
+
+	client := func(baseURL, apiKey string) *GatewayClient {
+		return &GatewayClient{BaseURL: baseURL, APIKey: apiKey, HTTPClient: server.Client()}
+	}
+	adapter := func(baseURL, apiKey, source string, timeout time.Duration) GatewayPaymentAdapter {
+		return GatewayPaymentAdapter{Client: client(baseURL, apiKey), Source: source, Timeout: timeout}
+	}
+	cases := []struct {
+		name    string
+		adapter GatewayPaymentAdapter
+	}{
+		{"cobro exitoso", adapter(server.URL, "sk_test_123", "tok_visa", time.Second)},
+		{"tarjeta rechazada", adapter(server.URL, "sk_test_123", "tok_declined", time.Second)},
+		{"error de la pasarela", adapter(server.URL, "sk_test_123", "tok_error", time.Second)},
+		{"API key inválida", adapter(server.URL, "sk_wrong", "tok_visa", time.Second)},
+		{"timeout", adapter(server.URL, "sk_test_123", "tok_slow", 200*time.Millisecond)},
+		{"servidor caído", adapter(closed.URL, "sk_test_123", "tok_visa", time.Second)},
+	}
+	for _, c := range cases {
+		receipt, err := ProcessPayment(c.adapter, 59.90)
+		if err != nil {
+			fmt.Printf("🚫 %-20s -> %v\n", c.name, err)
+		} else {
+			fmt.Printf("💳 %-20s -> %v\n", c.name, receipt)
+		}
+	}
+}
+
+// demonstrateIdempotency reintenta fallos transitorios y respuestas perdidas sin cobrar
+// dos veces, y responde los pagos repetidos desde el store de deduplicación.
+func demonstrateIdempotency() {
+	fmt.Println("\n🔂 Reintentos con claves de idempotencia:")
+	gateway := &fakeGateway{apiKey: "sk_test_123"}
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+	store := rediscache.NewSimpleRedisCache()
+	store.SetLogging(false)
+
+	adapter := func(source string) GatewayPaymentAdapter {
+		return GatewayPaymentAdapter{
+			Client:  &GatewayClient{BaseURL: server.URL, APIKey: "sk_test_123", HTTPClient: server.Client()},
+			Source:  source,
+			Timeout: 300 * time.Millisecond,
+			Retries: 3,
+			Store:   store,
+		}
+	}
+	report := func(label string, receipt Receipt, err error) {
+		if err != nil {
+			fmt.Printf("%s ❌ %v\n", label, err)
+			return
+		}
+		fmt.Printf("%s %v | peticiones: %d, cobros creados: %d\n", label, receipt, gateway.requests.Load(), gateway.charges.Load())
+	}
+
+	fmt.Println("🌩️ Pasarela inestable (dos 503 seguidos):")
+	receipt, err := adapter("tok_flaky").PayWithKey("order-1001", 25)
+	report("  ", receipt, err)
+
+	fmt.Println("📭 La respuesta del primer intento se pierde (el cobro sí se hizo):")
+	receipt, err = adapter("tok_lost").PayWithKey("order-1002", 40)
+	report("  ", receipt, err)
+
+	fmt.Println("🖱️ El cliente repite order-1002 (doble clic):")
+	receipt, err = adapter("tok_lost").PayWithKey("order-1002", 40)
+	report("  ", receipt, err)
+
+	fmt.Println("👯 Dos pedidos simultáneos de order-1003:")
+	var wg sync.WaitGroup
+	for i := range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time.Sleep(time.Duration(i) * 20 * time.Millisecond) // El segundo llega mientras el primero reintenta
+			receipt, err := adapter("tok_flaky").PayWithKey("order-1003", 60)
+			report(fmt.Sprintf("   pedido %d:", i+1), receipt, err)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/rediscache"
+)
+
+// newTestGateway levanta la pasarela simulada y retorna un adaptador apuntando a ella.
+func newTestGateway(t *testing.T, source string, timeout time.Duration, retries int) (*fakeGateway, GatewayPaymentAdapter) {
+	t.Helper()
+	quiet.Store(true)
+	t.Cleanup(func() { quiet.Store(false) })
+	gateway := &fakeGateway{apiKey: "sk_test_123"}
+	server := httptest.NewServer(gateway)
+	t.Cleanup(server.Close)
+	return gateway, GatewayPaymentAdapter{
+		Client:  &GatewayClient{BaseURL: server.URL, APIKey: "sk_test_123", HTTPClient: server.Client()},
+		Source:  source,
+		Timeout: timeout,
+		Retries: retries,
+	}
+}
+
+func TestGatewayPaymentAdapter(t *testing.T) {
+	tests := []struct {
+		name         string
+		source       string
+		timeout      time.Duration
+		retries      int
+		wantErr      error
+		wantRequests int64
+		wantCharges  int64
+	}{
+		{"cobro exitoso", "tok_visa", time.Second, 0, nil, 1, 1},
+		{"5xx sin reintentos", "tok_flaky", time.Second, 0, ErrGatewayUnavailable, 1, 0},
+		{"5xx con reintentos", "tok_flaky", time.Second, 3, nil, 3, 1},
+		{"5xx agota los reintentos", "tok_error", time.Second, 1, ErrGatewayUnavailable, 2, 0},
+		{"tarjeta rechazada no se reintenta", "tok_declined", time.Second, 3, ErrPaymentDeclined, 1, 0},
+		{"timeout", "tok_slow", 100 * time.Millisecond, 0, ErrGatewayUnavailable, 1, 0},
+		{"respuesta perdida", "tok_lost", 200 * time.Millisecond, 1, nil, 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway, adapter := newTestGateway(t, tt.source, tt.timeout, tt.retries)
+
+			receipt, err := adapter.PayWithKey("order-1", 59.90)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, quiero %v", err, tt.wantErr)
+			}
+			if err == nil && (receipt.Amount != 59.90 || !strings.HasPrefix(receipt.ID, "ch_") || receipt.Reference != "order-1") {
+				t.Errorf("recibo = %+v", receipt)
+			}
+			if got := gateway.requests.Load(); got != tt.wantRequests {
+				t.Errorf("peticiones = %d, quiero %d", got, tt.wantRequests)
+			}
+			if got := gateway.charges.Load(); got != tt.wantCharges {
+				t.Errorf("cobros creados = %d, quiero %d", got, tt.wantCharges)
+			}
+		})
+	}
+}
+
+func TestGatewayAuthError(t *testing.T) {
+	_, adapter := newTestGateway(t, "tok_visa", time.Second, 2)
+	adapter.Client.APIKey = "sk_wrong"
+
+	_, err := adapter.Pay(10)
+	var gatewayErr *GatewayError
+	if !errors.Is(err, ErrGatewayAuth) || !errors.As(err, &gatewayErr) || gatewayErr.StatusCode != 401 {
+		t.Errorf("err = %v, quiero ErrGatewayAuth con el *GatewayError 401 original", err)
+	}
+}
+
+func TestGatewayIdempotencyReplay(t *testing.T) {
+	gateway, adapter := newTestGateway(t, "tok_visa", time.Second, 0)
+	adapter.Store = rediscache.NewSimpleRedisCache()
+	adapter.Store.SetLogging(false)
+
+	first, err := adapter.PayWithKey("order-7", 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := adapter.PayWithKey("order-7", 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second != first {
+		t.Errorf("el pago repetido retornó %v, quiero el recibo original %v", second, first)
+	}
+	if got := gateway.requests.Load(); got != 1 {
+		t.Errorf("peticiones = %d, quiero 1: el pago repetido se responde desde el store", got)
+	}
+
+	// Sin store, la pasarela reconoce la clave y retorna el mismo cobro sin crear otro
+	adapter.Store = nil
+	third, err := adapter.PayWithKey("order-7", 40)
+	if err != nil || third.ID != first.ID {
+		t.Errorf("PayWithKey sin store = (%v, %v), quiero el cobro %s", third, err, first.ID)
+	}
+	if got := gateway.charges.Load(); got != 1 {
+		t.Errorf("cobros creados = %d, quiero 1", got)
+	}
+
+	// Claves distintas son pagos distintos
+	if other, err := adapter.PayWithKey("order-8", 40); err != nil || other.ID == first.ID {
+		t.Errorf("PayWithKey(order-8) = (%v, %v), quiero un cobro nuevo", other, err)
+	}
+}
+
+// TestGatewayLockKeepsOtherOwner simula que el lock de un pago vence mientras sigue en
+// curso y otro pedido lo toma: al terminar, el primero no debe borrar el lock ajeno.
+func TestGatewayLockKeepsOtherOwner(t *testing.T) {
+	_, adapter := newTestGateway(t, "tok_slow", 200*time.Millisecond, 0)
+	adapter.Store = rediscache.NewSimpleRedisCache()
+	adapter.Store.SetLogging(false)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := adapter.PayWithKey("order-9", 25)
+		done <- err
+	}()
+	deadline := time.Now().Add(time.Second)
+	for !adapter.Store.Exists("payment-lock:order-9") {
+		if time.Now().After(deadline) {
+			t.Fatal("el pago no tomó el lock")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	adapter.Store.Delete("payment-lock:order-9") // Como si venciera el TTL
+	if !adapter.Store.SetNX("payment-lock:order-9", "otro-pedido", time.Minute) {
+		t.Fatal("SetNX del otro pedido = false, quiero true")
+	}
+
+	if err := <-done; !errors.Is(err, ErrGatewayUnavailable) {
+		t.Fatalf("err = %v, quiero ErrGatewayUnavailable por el timeout", err)
+	}
+	if owner, ok := adapter.Store.Get("payment-lock:order-9"); !ok || owner != "otro-pedido" {
+		t.Errorf("lock = (%v, %t), quiero que siga siendo del otro pedido", owner, ok)
+	}
+	if _, err := adapter.PayWithKey("order-9", 25); !errors.Is(err, ErrPaymentInProgress) {
+		t.Errorf("PayWithKey con el lock ajeno: err = %v, quiero ErrPaymentInProgress", err)
+	}
+}
@@ -22,92 +22,41 @@ Ventajas:
 - Facilita la integración de bibliotecas externas
 
 En este ejemplo:
-- IPayment: Interfaz objetivo que esperan los clientes
-- CashPayment: Implementación que ya cumple con IPayment
-- CreditCardPayment: Clase incompatible que necesita adaptación
-- CreditCardAdapter: Adaptador que hace compatible CreditCardPayment con IPayment
+  - IPayment: Interfaz objetivo que esperan los clientes; cada pago retorna un Receipt o un error
+  - CashPayment: Implementación que ya cumple con IPayment
+  - CreditCardPayment: Clase incompatible que necesita adaptación (cobra en centavos y retorna un código de autorización)
+  - CreditCardAdapter: Adaptador que hace compatible CreditCardPayment con IPayment
+  - BankPayment y BankPaymentAdapter: otro sistema incompatible que reporta los fallos con un bool y un mensaje
+  - GatewayClient y GatewayPaymentAdapter: el SDK HTTP de una pasarela externa, adaptado a IPayment,
+    con reintentos y claves de idempotencia para no cobrar dos veces
+  - CryptoPayment y CryptoPaymentAdapter: pagos en BTC adaptados a montos en dólares
+  - NewPaymentFactory: elige el adaptador a partir del nombre del método (Factory + Adapter)
+  - NewCreditCardPaymentAdapter, NewBankPaymentAdapter...: constructores que validan los datos de cuenta
+    y rechazan los inválidos con errores tipados (AccountError, ErrInvalidAccount)
+  - FraudCheck: controles antifraude encadenados que ProcessPayment ejecuta antes del adaptador
+    (Adapter + Chain of Responsibility)
+  - IRefundable: segunda interfaz objetivo para reembolsos, adaptada a los métodos de reembolso de cada sistema
+  - CurrencyAdapter: adapta montos en cualquier moneda (Money) a un sistema que solo acepta centavos de USD
+  - CreditCardClassAdapter: la misma adaptación con embedding ("adaptador de clase") para compararla con la composición
+  - ProcessPayments: cobra en paralelo un lote de pagos con distintos adaptadores y retorna un Report
+  - AsyncCryptoPaymentAdapter: adapta confirmaciones asíncronas (un canal) a la interfaz síncrona IPayment
+  - NewLoggingPayment y NewMeteredPayment: decoradores que envuelven cualquier IPayment, adaptado o no
+  - XMLPaymentAdapter: API moderna en JSON sobre un servicio de pagos antiguo que solo habla XML
+  - ChunkReader: otro escenario, adapta una fuente de datos con Fetch(offset, size) a io.Reader
 */
 package main
 
-import "fmt"
-
-// 1. Definición de la interfaz IPayment
-
-// IPayment define la interfaz objetivo que esperan los clientes
-// Todos los métodos de pago deben implementar esta interfaz
-type IPayment interface {
-	Pay() // Método estándar que todos los pagos deben implementar
-}
-
-// CashPayment representa un pago en efectivo que ya es compatible con IPayment
-// Esta clase NO necesita adaptador porque ya implementa la interfaz correcta
-type CashPayment struct{}
-
-// Pay implementa directamente la interfaz IPayment para pagos en efectivo
-func (c CashPayment) Pay() {
-	fmt.Println("💰 Pagando con efectivo")
-}
-
-// ProcessPayment es una función que puede trabajar con cualquier tipo de pago
-// que implemente la interfaz IPayment. Demuestra el polimorfismo.
-func ProcessPayment(p IPayment) {
-	p.Pay()
-}
-
-// 2. Definición de la clase incompatible y el adaptador
-
-// CreditCardPayment representa un sistema de pago con tarjeta de crédito INCOMPATIBLE
-// Esta clase tiene una interfaz diferente (requiere userAccountID como parámetro)
-// NO puede ser usado directamente con IPayment - necesita un adaptador
-type CreditCardPayment struct{}
-
-// Pay es el método original de CreditCardPayment que NO es compatible con IPayment
-// Requiere un parámetro userAccountID, mientras que IPayment.Pay() no requiere parámetros
-func (CreditCardPayment) Pay(userAccountID int) {
-	fmt.Printf("💳 Pagando desde la cuenta de usuario %d usando tarjeta de crédito\n", userAccountID)
-}
-
-// CreditCardPaymentAdapter es el ADAPTADOR que hace compatible CreditCardPayment con IPayment
-// Implementa el patrón Adapter siguiendo estos principios:
-// 1. Implementa la interfaz objetivo (IPayment)
-// 2. Contiene una instancia del objeto incompatible (CreditCardPayment)
-// 3. Almacena los datos necesarios para la adaptación (UserAccountID)
-type CreditCardPaymentAdapter struct {
-	CreditCardPayment *CreditCardPayment // La clase incompatible que queremos adaptar
-	UserAccountID     int                // Datos adicionales necesarios para la adaptación
-}
-
-// Pay implementa la interfaz IPayment en el adaptador
-// Esta es la "traducción" que hace que CreditCardPayment sea compatible con IPayment
-// El adaptador toma la llamada sin parámetros de IPayment.Pay() y la convierte
-// en una llamada con parámetros a CreditCardPayment.Pay(userAccountID)
-func (cca CreditCardPaymentAdapter) Pay() {
-	cca.CreditCardPayment.Pay(cca.UserAccountID)
-}
-
-// 3. Demostración adicional con otro método de pago incompatible
-
-type BankPayment struct{}
-
-func (b BankPayment) Pay(accountNumber string) {
-	fmt.Printf("🏦 Pagando desde la cuenta bancaria %s\n", accountNumber)
-}
-
-type BankPaymentAdapter struct {
-	BankPayment   *BankPayment
-	AccountNumber string
-}
-
-func (ba BankPaymentAdapter) Pay() {
-	ba.BankPayment.Pay(ba.AccountNumber)
-}
+import (
+	"fmt"
+	"os"
+)
 
 // main demuestra el uso del patrón Adapter
 func main() {
 	// 🔄 Ejemplo 1: Usar CashPayment directamente (ya compatible con IPayment)
 	fmt.Println("🟢 Procesando pago directo (sin adaptador):")
 	cash := &CashPayment{}
-	ProcessPayment(cash)
+	printResult(ProcessPayment(cash, 120.50))
 
 	fmt.Println("\n🔧 Procesando pago con adaptador:")
 	// 🔄 Ejemplo 2: Usar CreditCardPayment a través del adaptador
@@ -115,13 +64,39 @@ func main() {
 		CreditCardPayment: &CreditCardPayment{},
 		UserAccountID:     12345,
 	}
-	ProcessPayment(ccpa)
+	printResult(ProcessPayment(ccpa, 899.99))
+	printResult(ProcessPayment(ccpa, 12000)) // Supera el cupo de la tarjeta
 
 	fmt.Println("\n🔧 Procesando pago bancario con adaptador:")
 	// 🔄 Ejemplo 3: Usar BankPayment a través del adaptador
 	bpa := &BankPaymentAdapter{
 		BankPayment:   &BankPayment{},
-		AccountNumber: "987654321",
+		AccountNumber: "987654324",
+	}
+	printResult(ProcessPayment(bpa, 45))
+	printResult(ProcessPayment(&BankPaymentAdapter{BankPayment: &BankPayment{}, AccountNumber: "12-AB"}, 45))
+	printResult(ProcessPayment(bpa, -10)) // ProcessPayment rechaza el monto sin llamar al medio de pago
+
+	demonstrateGateway()
+	demonstrateIdempotency()
+	demonstratePaymentFactory(os.Args[1:])
+	demonstrateValidation()
+	demonstrateFraudChecks()
+	demonstrateRefunds()
+	demonstrateCurrencyAdapter()
+	demonstrateClassVsObjectAdapter()
+	demonstrateBatchPayments()
+	demonstrateAsyncCrypto()
+	demonstrateDecorators()
+	demonstrateXMLAdapter()
+	demonstrateReaderAdapter()
+}
+
+// printResult muestra el recibo de un pago o el error que lo impidió.
+func printResult(receipt Receipt, err error) {
+	if err != nil {
+		fmt.Println(err)
+		return
 	}
-	ProcessPayment(bpa)
+	fmt.Println(receipt)
 }
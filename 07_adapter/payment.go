@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// 1. Definición de la interfaz IPayment
+
+// Errores comunes a todos los métodos de pago. Los adaptadores traducen a estos los
+// errores propios de cada sistema, para que el cliente los trate igual.
+var (
+	ErrInvalidAmount   = errors.New("el monto debe ser positivo")
+	ErrPaymentDeclined = errors.New("pago rechazado")
+)
+
+// Receipt es el comprobante de un pago exitoso.
+// Los reembolsos también generan un Receipt, con Amount negativo.
+type Receipt struct {
+	ID        string
+	Method    string
+	Amount    float64
+	Timestamp time.Time
+	Reference string // Referencia del sistema de pago (código de autorización, hash...) o, en un reembolso, el ID del pago original
+}
+
+func (r Receipt) String() string {
+	s := fmt.Sprintf("🧾 %s | %-6s | $%9.2f | %s", r.ID, r.Method, r.Amount, r.Timestamp.Format(time.TimeOnly))
+	if r.Reference != "" {
+		s += " | ref " + r.Reference
+	}
+	return s
+}
+
+// quiet silencia los mensajes de los sistemas de pago en las demostraciones con muchos pagos.
+var quiet atomic.Bool
+
+func logf(format string, args ...any) {
+	if !quiet.Load() {
+		fmt.Printf(format, args...)
+	}
+}
+
+// receiptSequence numera los recibos de todos los métodos de pago.
+var receiptSequence atomic.Int64
+
+// newReceipt crea el recibo de un pago de amount hecho con method.
+func newReceipt(method string, amount float64) Receipt {
+	return Receipt{
+		ID:        fmt.Sprintf("R-%04d", receiptSequence.Add(1)),
+		Method:    method,
+		Amount:    amount,
+		Timestamp: time.Now(),
+	}
+}
+
+// IPayment define la interfaz objetivo que esperan los clientes
+// Todos los métodos de pago deben implementar esta interfaz
+type IPayment interface {
+	Pay(amount float64) (Receipt, error) // Cobra amount y retorna el recibo, o el motivo del fallo
+}
+
+// CashPayment representa un pago en efectivo que ya es compatible con IPayment
+// Esta clase NO necesita adaptador porque ya implementa la interfaz correcta
+type CashPayment struct{}
+
+// Pay implementa directamente la interfaz IPayment para pagos en efectivo
+func (c CashPayment) Pay(amount float64) (Receipt, error) {
+	logf("💰 Pagando $%.2f con efectivo\n", amount)
+	return newReceipt("cash", amount), nil
+}
+
+// ProcessPayment es una función que puede trabajar con cualquier tipo de pago
+// que implemente la interfaz IPayment. Demuestra el polimorfismo.
+// Valida el monto, pasa el pago por la cadena de controles antifraude checks (si se
+// indican) antes de cobrar y agrega contexto a los errores del medio de pago.
+func ProcessPayment(p IPayment, amount float64, checks ...FraudCheck) (Receipt, error) {
+	if amount <= 0 {
+		return Receipt{}, fmt.Errorf("❌ pago de $%.2f: %w", amount, ErrInvalidAmount)
+	}
+	if err := runFraudChecks(PaymentRequest{Payment: p, Amount: amount, Account: paymentAccount(p)}, checks); err != nil {
+		return Receipt{}, fmt.Errorf("❌ pago de $%.2f bloqueado: %w", amount, err)
+	}
+	receipt, err := p.Pay(amount)
+	if err != nil {
+		return Receipt{}, fmt.Errorf("❌ pago de $%.2f falló: %w", amount, err)
+	}
+	return receipt, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ChunkedDataSource es el cliente de un almacenamiento remoto de otro proveedor: no
+// cumple io.Reader, sino que entrega los datos por rangos con Fetch y como máximo
+// MaxChunk bytes por llamada.
+type ChunkedDataSource struct {
+	data     []byte
+	MaxChunk int
+	Fetches  int // Llamadas a Fetch, para el ejemplo
+}
+
+// NewChunkedDataSource crea una fuente remota simulada con content.
+func NewChunkedDataSource(content string, maxChunk int) *ChunkedDataSource {
+	return &ChunkedDataSource{data: []byte(content), MaxChunk: maxChunk}
+}
+
+// Fetch retorna hasta size bytes a partir de offset, e indica con last si ya no quedan más.
+func (s *ChunkedDataSource) Fetch(offset int64, size int) (chunk []byte, last bool, err error) {
+	s.Fetches++
+	if offset < 0 || offset > int64(len(s.data)) {
+		return nil, false, fmt.Errorf("offset %d fuera de rango (tamaño %d)", offset, len(s.data))
+	}
+	end := min(offset+int64(min(size, s.MaxChunk)), int64(len(s.data)))
+	return s.data[offset:end], end == int64(len(s.data)), nil
+}
+
+// ChunkReader adapta ChunkedDataSource a io.Reader: lleva la cuenta del offset, pide un
+// rango del tamaño del buffer de Read y traduce "last" a io.EOF. Con eso la fuente
+// remota funciona con bufio, io.Copy, json.Decoder y cualquier otra cosa de la
+// librería estándar que acepte un io.Reader.
+type ChunkReader struct {
+	Source *ChunkedDataSource
+	offset int64
+	done   bool
+}
+
+func (r *ChunkReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	chunk, last, err := r.Source.Fetch(r.offset, len(p))
+	if err != nil {
+		return 0, fmt.Errorf("chunk reader: %w", err)
+	}
+	n := copy(p, chunk)
+	r.offset += int64(n)
+	r.done = last
+	if last && n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// byteCounter es un io.Writer que solo cuenta lo que recibe.
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
+// demonstrateReaderAdapter lee un extracto de pagos (JSON por línea) guardado en la
+// fuente remota con tres herramientas de la librería estándar a través de ChunkReader.
+func demonstrateReaderAdapter() {
+	fmt.Println("\n📚 Fuente remota por rangos adaptada a io.Reader:")
+	var statement strings.Builder
+	for i := range 20 {
+		fmt.Fprintf(&statement, `{"account_id": "ACC-%04d", "amount": %d.50, "currency": "USD"}`+"\n", i, 10+i)
+	}
+	content := statement.String()
+
+	// bufio.Scanner: las líneas se arman aunque crucen varios trozos de 16 bytes
+	source := NewChunkedDataSource(content, 16)
+	scanner := bufio.NewScanner(&ChunkReader{Source: source})
+	lines := 0
+	for scanner.Scan() {
+		lines++
+	}
+	fmt.Printf("📄 bufio.Scanner: %d líneas en %d llamadas a Fetch (err: %v)\n", lines, source.Fetches, scanner.Err())
+
+	// json.Decoder: decodifica los pagos directamente del stream
+	source = NewChunkedDataSource(content, 64)
+	decoder := json.NewDecoder(&ChunkReader{Source: source})
+	var total float64
+	for {
+		var req PaymentAPIRequest
+		if err := decoder.Decode(&req); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			fmt.Println(err)
+			return
+		}
+		total += req.Amount
+	}
+	fmt.Printf("🧮 json.Decoder: $%.2f en total, %d llamadas a Fetch\n", total, source.Fetches)
+
+	// io.Copy: lo copia a un hash y a un contador a la vez, sin cargarlo entero en memoria
+	source = NewChunkedDataSource(content, 256)
+	hash := sha256.New()
+	var counter byteCounter
+	if _, err := io.Copy(io.MultiWriter(hash, &counter), &ChunkReader{Source: source}); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("🔐 io.Copy: %d bytes, sha256 %x..., %d llamadas a Fetch\n", counter, hash.Sum(nil)[:6], source.Fetches)
+}
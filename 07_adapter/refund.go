@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Errores de los reembolsos.
+var (
+	ErrRefundExceedsPayment = errors.New("el reembolso supera lo que queda por devolver del pago")
+	ErrRefundNotAllowed     = errors.New("el pago no se puede reembolsar con este método")
+)
+
+// IRefundable es la segunda interfaz objetivo: los métodos de pago que además
+// permiten devolver dinero. Cada sistema la expone con una forma distinta, así que
+// los adaptadores la implementan traduciendo igual que con Pay.
+type IRefundable interface {
+	Refund(original Receipt, amount float64) (Receipt, error)
+}
+
+// Reverse es el método de reembolso de CreditCardPayment: anula (total o parcialmente)
+// el cobro identificado por su código de autorización, en centavos.
+func (CreditCardPayment) Reverse(authorizationCode string, amountCents int64) error {
+	if !strings.HasPrefix(authorizationCode, "AUTH-") {
+		return fmt.Errorf("código de autorización desconocido %q", authorizationCode)
+	}
+	logf("💳 Reversando %d centavos de la autorización %s\n", amountCents, authorizationCode)
+	return nil
+}
+
+// ReturnTransfer es el método de reembolso de BankPayment: hace una transferencia de
+// vuelta a la cuenta, con el mismo estilo (ok, mensaje) que Pay.
+func (BankPayment) ReturnTransfer(accountNumber string, amount float64, reason string) (ok bool, message string) {
+	if !accountNumberPattern.MatchString(accountNumber) {
+		return false, fmt.Sprintf("la cuenta %q no existe", accountNumber)
+	}
+	logf("🏦 Devolviendo $%.2f a la cuenta bancaria %s (%s)\n", amount, accountNumber, reason)
+	return true, "devolución aprobada"
+}
+
+// Refund adapta IRefundable a CreditCardPayment.Reverse usando la referencia del recibo.
+func (cca CreditCardPaymentAdapter) Refund(original Receipt, amount float64) (Receipt, error) {
+	if original.Method != "credit" {
+		return Receipt{}, fmt.Errorf("%w: el recibo %s es de %s", ErrRefundNotAllowed, original.ID, original.Method)
+	}
+	if err := cca.CreditCardPayment.Reverse(original.Reference, toCents(amount)); err != nil {
+		return Receipt{}, fmt.Errorf("%w: %w", ErrPaymentDeclined, err)
+	}
+	return newRefundReceipt(original, amount), nil
+}
+
+// Refund adapta IRefundable a BankPayment.ReturnTransfer.
+func (ba BankPaymentAdapter) Refund(original Receipt, amount float64) (Receipt, error) {
+	if original.Method != "bank" {
+		return Receipt{}, fmt.Errorf("%w: el recibo %s es de %s", ErrRefundNotAllowed, original.ID, original.Method)
+	}
+	if ok, message := ba.BankPayment.ReturnTransfer(ba.AccountNumber, amount, "reembolso de "+original.ID); !ok {
+		return Receipt{}, fmt.Errorf("%w: %s", ErrPaymentDeclined, message)
+	}
+	return newRefundReceipt(original, amount), nil
+}
+
+// newRefundReceipt crea el recibo de un reembolso de amount sobre original.
+func newRefundReceipt(original Receipt, amount float64) Receipt {
+	refund := newReceipt(original.Method, -amount)
+	refund.Reference = original.ID
+	return refund
+}
+
+// refunded guarda cuánto se ha devuelto de cada pago, por ID de recibo. El mutex se
+// mantiene durante todo ProcessRefund para que dos reembolsos simultáneos del mismo
+// pago no puedan superar juntos el monto original.
+var refunded = struct {
+	sync.Mutex
+	byReceipt map[string]int64 // En centavos, para no acumular errores de redondeo
+}{byReceipt: map[string]int64{}}
+
+// ProcessRefund devuelve amount del pago original con cualquier IRefundable. Permite
+// reembolsos parciales, pero valida que la suma de todos no supere el monto pagado.
+func ProcessRefund(r IRefundable, original Receipt, amount float64) (Receipt, error) {
+	if amount <= 0 {
+		return Receipt{}, fmt.Errorf("❌ reembolso de $%.2f: %w", amount, ErrInvalidAmount)
+	}
+
+	refunded.Lock()
+	defer refunded.Unlock()
+	remaining := toCents(original.Amount) - refunded.byReceipt[original.ID]
+	if toCents(amount) > remaining {
+		return Receipt{}, fmt.Errorf("❌ reembolso de $%.2f sobre %s (quedan $%.2f): %w",
+			amount, original.ID, float64(remaining)/100, ErrRefundExceedsPayment)
+	}
+	receipt, err := r.Refund(original, amount)
+	if err != nil {
+		return Receipt{}, fmt.Errorf("❌ reembolso de $%.2f falló: %w", amount, err)
+	}
+	refunded.byReceipt[original.ID] += toCents(amount)
+	return receipt, nil
+}
+
+// demonstrateRefunds devuelve en partes un pago con tarjeta y uno bancario por el mismo
+// flujo ProcessRefund, y muestra los reembolsos que se rechazan.
+func demonstrateRefunds() {
+	fmt.Println("\n↩️ Reembolsos con una segunda interfaz adaptada:")
+	card := CreditCardPaymentAdapter{CreditCardPayment: &CreditCardPayment{}, UserAccountID: 12345}
+	bank := BankPaymentAdapter{BankPayment: &BankPayment{}, AccountNumber: "987654324"}
+
+	cardReceipt, err := ProcessPayment(card, 100)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	bankReceipt, err := ProcessPayment(bank, 80)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	printResult(ProcessRefund(card, cardReceipt, 30))
+	printResult(ProcessRefund(card, cardReceipt, 70))
+	printResult(ProcessRefund(card, cardReceipt, 0.01)) // Ya se devolvió todo
+	printResult(ProcessRefund(bank, bankReceipt, 50))
+	printResult(ProcessRefund(bank, bankReceipt, 40)) // Solo quedan $30
+	printResult(ProcessRefund(card, bankReceipt, 10)) // Un recibo bancario no se reversa con la tarjeta
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrInvalidAccount indica que los datos de cuenta de un adaptador no son válidos.
+var ErrInvalidAccount = errors.New("cuenta inválida")
+
+// AccountError describe qué dato de cuenta rechazó la validación y por qué.
+// errors.Is(err, ErrInvalidAccount) es verdadero para cualquier *AccountError.
+type AccountError struct {
+	Method  string // Método de pago: "credit", "bank", "crypto", "stripe"
+	Account string // Valor rechazado
+	Reason  string
+}
+
+func (e *AccountError) Error() string {
+	return fmt.Sprintf("%s: %s %q: %s", e.Method, ErrInvalidAccount, e.Account, e.Reason)
+}
+
+func (e *AccountError) Unwrap() error {
+	return ErrInvalidAccount
+}
+
+// bech32Charset son los caracteres válidos de una dirección bc1 después del prefijo.
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// NewCreditCardPaymentAdapter crea el adaptador de tarjeta validando el id de cuenta.
+func NewCreditCardPaymentAdapter(userAccountID int) (CreditCardPaymentAdapter, error) {
+	if userAccountID <= 0 {
+		return CreditCardPaymentAdapter{}, &AccountError{"credit", fmt.Sprint(userAccountID), "el id de cuenta debe ser positivo"}
+	}
+	return CreditCardPaymentAdapter{CreditCardPayment: &CreditCardPayment{}, UserAccountID: userAccountID}, nil
+}
+
+// NewBankPaymentAdapter crea el adaptador bancario. Además del formato que exige el
+// banco (9 dígitos) comprueba el dígito verificador, para rechazar los números mal
+// digitados antes de intentar la transferencia.
+func NewBankPaymentAdapter(accountNumber string) (BankPaymentAdapter, error) {
+	if !accountNumberPattern.MatchString(accountNumber) {
+		return BankPaymentAdapter{}, &AccountError{"bank", accountNumber, "debe tener 9 dígitos"}
+	}
+	if !luhnValid(accountNumber) {
+		return BankPaymentAdapter{}, &AccountError{"bank", accountNumber, "dígito verificador incorrecto"}
+	}
+	return BankPaymentAdapter{BankPayment: &BankPayment{}, AccountNumber: accountNumber}, nil
+}
+
+// NewCryptoPaymentAdapter crea el adaptador de criptomonedas validando que la billetera
+// sea una dirección bc1 con la longitud y los caracteres de bech32.
+func NewCryptoPaymentAdapter(wallet string) (CryptoPaymentAdapter, error) {
+	data, ok := strings.CutPrefix(wallet, "bc1")
+	switch {
+	case !ok:
+		return CryptoPaymentAdapter{}, &AccountError{"crypto", wallet, "debe empezar con bc1"}
+	case len(wallet) != 42 && len(wallet) != 62:
+		return CryptoPaymentAdapter{}, &AccountError{"crypto", wallet, "debe tener 42 o 62 caracteres"}
+	case strings.Trim(data, bech32Charset) != "":
+		return CryptoPaymentAdapter{}, &AccountError{"crypto", wallet, "tiene caracteres fuera de bech32"}
+	}
+	return CryptoPaymentAdapter{CryptoPayment: &CryptoPayment{}, Wallet: wallet}, nil
+}
+
+// NewGatewayPaymentAdapter crea el adaptador de la pasarela. Un cliente sin URL válida
+// nunca podrá cobrar, así que se reporta como ErrGatewayUnavailable desde el principio.
+func NewGatewayPaymentAdapter(client *GatewayClient, source string, timeout time.Duration) (GatewayPaymentAdapter, error) {
+	if client == nil {
+		return GatewayPaymentAdapter{}, fmt.Errorf("%w: falta el cliente", ErrGatewayUnavailable)
+	}
+	if u, err := url.Parse(client.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return GatewayPaymentAdapter{}, fmt.Errorf("%w: URL %q inválida", ErrGatewayUnavailable, client.BaseURL)
+	}
+	if client.APIKey == "" {
+		return GatewayPaymentAdapter{}, fmt.Errorf("%w: falta la API key", ErrGatewayAuth)
+	}
+	if !strings.HasPrefix(source, "tok_") {
+		return GatewayPaymentAdapter{}, &AccountError{"stripe", source, "el token debe empezar con tok_"}
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return GatewayPaymentAdapter{Client: client, Source: source, Timeout: timeout}, nil
+}
+
+// luhnValid comprueba el dígito verificador de digits con el algoritmo de Luhn.
+func luhnValid(digits string) bool {
+	sum := 0
+	for i := range len(digits) {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// demonstrateValidation pasa configuraciones válidas e inválidas a los constructores
+// de cada adaptador y muestra cuáles aceptan y con qué error tipado rechazan las demás.
+func demonstrateValidation() {
+	fmt.Println("\n🛂 Validación en los constructores de los adaptadores:")
+	client := &GatewayClient{BaseURL: "https://api.gateway.test", APIKey: "sk_test_123"}
+	ignore := func(_ any, err error) error { return err }
+
+	cases := []struct {
+		name string
+		err  error
+	}{
+		{"credit 12345", ignore(NewCreditCardPaymentAdapter(12345))},
+		{"credit 0", ignore(NewCreditCardPaymentAdapter(0))},
+		{"credit -7", ignore(NewCreditCardPaymentAdapter(-7))},
+		{"bank 987654324", ignore(NewBankPaymentAdapter("987654324"))},
+		{"bank 987654321 (dígito errado)", ignore(NewBankPaymentAdapter("987654321"))},
+		{"bank 12-AB", ignore(NewBankPaymentAdapter("12-AB"))},
+		{"crypto bc1qxy2…", ignore(NewCryptoPaymentAdapter("bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh"))},
+		{"crypto dirección legacy 1Boat…", ignore(NewCryptoPaymentAdapter("1BoatSLRHtKNngkdXEeobR76b53LETtpyT"))},
+		{"crypto bc1 corta", ignore(NewCryptoPaymentAdapter("bc1qxy2kg"))},
+		{"crypto bc1 con 'b'", ignore(NewCryptoPaymentAdapter("bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wbb"))},
+		{"stripe tok_visa", ignore(NewGatewayPaymentAdapter(client, "tok_visa", time.Second))},
+		{"stripe tarjeta sin tokenizar", ignore(NewGatewayPaymentAdapter(client, "4242424242424242", time.Second))},
+		{"stripe sin cliente", ignore(NewGatewayPaymentAdapter(nil, "tok_visa", time.Second))},
+		{"stripe URL vacía", ignore(NewGatewayPaymentAdapter(&GatewayClient{APIKey: "sk_test_123"}, "tok_visa", time.Second))},
+		{"stripe sin API key", ignore(NewGatewayPaymentAdapter(&GatewayClient{BaseURL: client.BaseURL}, "tok_visa", time.Second))},
+	}
+	for _, c := range cases {
+		if c.err != nil {
+			fmt.Printf("🚫 %-40s %v\n", c.name, c.err)
+		} else {
+			fmt.Printf("👍 %-40s aceptada\n", c.name)
+		}
+	}
+
+	// errors.As da acceso a los detalles del rechazo
+	_, err := NewBankPaymentAdapter("987654321")
+	var accountErr *AccountError
+	if errors.As(err, &accountErr) {
+		fmt.Printf("🔎 Método: %s, cuenta: %s, motivo: %s\n", accountErr.Method, accountErr.Account, accountErr.Reason)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdapterValidation(t *testing.T) {
+	client := &GatewayClient{BaseURL: "https://api.gateway.test", APIKey: "sk_test_123"}
+	ignore := func(_ any, err error) error { return err }
+
+	tests := []struct {
+		name       string
+		err        error
+		wantErr    error
+		wantMethod string // Método del *AccountError, si se espera uno
+		wantReason string
+	}{
+		{"credit válida", ignore(NewCreditCardPaymentAdapter(12345)), nil, "", ""},
+		{"credit 0", ignore(NewCreditCardPaymentAdapter(0)), ErrInvalidAccount, "credit", "el id de cuenta debe ser positivo"},
+		{"credit negativa", ignore(NewCreditCardPaymentAdapter(-7)), ErrInvalidAccount, "credit", "el id de cuenta debe ser positivo"},
+		{"bank válida", ignore(NewBankPaymentAdapter("987654324")), nil, "", ""},
+		{"bank dígito errado", ignore(NewBankPaymentAdapter("987654321")), ErrInvalidAccount, "bank", "dígito verificador incorrecto"},
+		{"bank formato", ignore(NewBankPaymentAdapter("12-AB")), ErrInvalidAccount, "bank", "debe tener 9 dígitos"},
+		{"crypto válida", ignore(NewCryptoPaymentAdapter("bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh")), nil, "", ""},
+		{"crypto legacy", ignore(NewCryptoPaymentAdapter("1BoatSLRHtKNngkdXEeobR76b53LETtpyT")), ErrInvalidAccount, "crypto", "debe empezar con bc1"},
+		{"crypto corta", ignore(NewCryptoPaymentAdapter("bc1qxy2kg")), ErrInvalidAccount, "crypto", "debe tener 42 o 62 caracteres"},
+		{"crypto fuera de bech32", ignore(NewCryptoPaymentAdapter("bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wbb")), ErrInvalidAccount, "crypto", "tiene caracteres fuera de bech32"},
+		{"stripe válida", ignore(NewGatewayPaymentAdapter(client, "tok_visa", time.Second)), nil, "", ""},
+		{"stripe sin tokenizar", ignore(NewGatewayPaymentAdapter(client, "4242424242424242", time.Second)), ErrInvalidAccount, "stripe", "el token debe empezar con tok_"},
+		{"stripe sin cliente", ignore(NewGatewayPaymentAdapter(nil, "tok_visa", time.Second)), ErrGatewayUnavailable, "", ""},
+		{"stripe URL vacía", ignore(NewGatewayPaymentAdapter(&GatewayClient{APIKey: "sk_test_123"}, "tok_visa", time.Second)), ErrGatewayUnavailable, "", ""},
+		{"stripe sin API key", ignore(NewGatewayPaymentAdapter(&GatewayClient{BaseURL: client.BaseURL}, "tok_visa", time.Second)), ErrGatewayAuth, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				if tt.err != nil {
+					t.Fatalf("err = %v, quiero nil", tt.err)
+				}
+				return
+			}
+			if !errors.Is(tt.err, tt.wantErr) {
+				t.Fatalf("err = %v, quiero %v", tt.err, tt.wantErr)
+			}
+
+			var accountErr *AccountError
+			isAccountErr := errors.As(tt.err, &accountErr)
+			if isAccountErr != (tt.wantMethod != "") {
+				t.Fatalf("errors.As(err, *AccountError) = %t con err = %v", isAccountErr, tt.err)
+			}
+			if isAccountErr && (accountErr.Method != tt.wantMethod || accountErr.Reason != tt.wantReason) {
+				t.Errorf("AccountError = {%s, %s}, quiero {%s, %s}", accountErr.Method, accountErr.Reason, tt.wantMethod, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestNewGatewayPaymentAdapterDefaultTimeout(t *testing.T) {
+	adapter, err := NewGatewayPaymentAdapter(&GatewayClient{BaseURL: "https://api.gateway.test", APIKey: "sk"}, "tok_visa", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if adapter.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, quiero 5s", adapter.Timeout)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// LegacyXMLPaymentService es un servicio de pagos antiguo que solo habla XML: recibe un
+// <PaymentRequest> y responde un <PaymentResponse>. Los fallos no son errores de Go,
+// sino un Status REJECTED con un código numérico (E001, E101...).
+type LegacyXMLPaymentService struct {
+	sequence atomic.Int64
+}
+
+// xmlPaymentRequest es el formato de petición del servicio antiguo.
+type xmlPaymentRequest struct {
+	XMLName xml.Name  `xml:"PaymentRequest"`
+	Account string    `xml:"Account"`
+	Amount  xmlAmount `xml:"Amount"`
+}
+
+// xmlAmount es un monto en centavos con la moneda como atributo.
+type xmlAmount struct {
+	Currency string `xml:"currency,attr"`
+	Cents    int64  `xml:"Cents"`
+}
+
+// xmlPaymentResponse es el formato de respuesta del servicio antiguo.
+type xmlPaymentResponse struct {
+	XMLName        xml.Name  `xml:"PaymentResponse"`
+	Status         string    `xml:"Status"` // APPROVED o REJECTED
+	TransactionRef string    `xml:"TransactionRef,omitempty"`
+	Error          *xmlError `xml:"Error,omitempty"`
+}
+
+// xmlError es el motivo de un rechazo.
+type xmlError struct {
+	Code string `xml:"Code"`
+	Text string `xml:"Text"`
+}
+
+// Process atiende una petición XML. Solo retorna error si no puede escribir la respuesta.
+func (s *LegacyXMLPaymentService) Process(requestXML []byte) ([]byte, error) {
+	var req xmlPaymentRequest
+	var rejection *xmlError
+	switch {
+	case xml.Unmarshal(requestXML, &req) != nil || req.Amount.Cents <= 0:
+		rejection = &xmlError{"E001", "MALFORMED REQUEST"}
+	case req.Amount.Currency != "USD":
+		rejection = &xmlError{"E002", "CURRENCY NOT SUPPORTED"}
+	case strings.HasPrefix(req.Account, "BLK"):
+		rejection = &xmlError{"E102", "ACCOUNT BLOCKED"}
+	case req.Amount.Cents > 500_000:
+		rejection = &xmlError{"E101", "INSUFFICIENT FUNDS"}
+	}
+	if rejection != nil {
+		return xml.MarshalIndent(xmlPaymentResponse{Status: "REJECTED", Error: rejection}, "", "  ")
+	}
+	return xml.MarshalIndent(xmlPaymentResponse{Status: "APPROVED", TransactionRef: fmt.Sprintf("TX%08d", s.sequence.Add(1))}, "", "  ")
+}
+
+// PaymentAPIRequest es la petición de la API moderna, en JSON.
+type PaymentAPIRequest struct {
+	AccountID string  `json:"account_id"`
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+}
+
+// PaymentAPIResponse es la respuesta de la API moderna, en JSON.
+type PaymentAPIResponse struct {
+	ID        string  `json:"id,omitempty"`
+	Status    string  `json:"status"` // succeeded o failed
+	Amount    float64 `json:"amount"`
+	Reference string  `json:"reference,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// ErrMalformedPayment indica que el servicio antiguo no entendió la petición.
+var ErrMalformedPayment = errors.New("petición de pago inválida")
+
+// legacyErrorCodes traduce los códigos del servicio antiguo a errores del dominio.
+var legacyErrorCodes = map[string]error{
+	"E001": ErrMalformedPayment,
+	"E002": ErrUnsupportedCurrency,
+	"E101": ErrPaymentDeclined,
+	"E102": ErrPaymentDeclined,
+}
+
+// XMLPaymentAdapter expone una API moderna (structs y JSON) sobre el servicio XML:
+// traduce los campos, convierte el monto a centavos, hace el marshaling en ambos
+// sentidos y convierte los códigos de rechazo en errores de Go. También cumple
+// IPayment cobrando a Account.
+type XMLPaymentAdapter struct {
+	Service *LegacyXMLPaymentService
+	Account string // Cuenta que usa Pay
+}
+
+// Charge cobra con el servicio antiguo a partir de una petición moderna.
+func (a XMLPaymentAdapter) Charge(req PaymentAPIRequest) (PaymentAPIResponse, error) {
+	requestXML, err := xml.Marshal(xmlPaymentRequest{
+		Account: req.AccountID,
+		Amount:  xmlAmount{Currency: strings.ToUpper(req.Currency), Cents: toCents(req.Amount)},
+	})
+	if err != nil {
+		return PaymentAPIResponse{}, err
+	}
+	responseXML, err := a.Service.Process(requestXML)
+	if err != nil {
+		return PaymentAPIResponse{}, fmt.Errorf("%w: %w", ErrGatewayUnavailable, err)
+	}
+	var legacy xmlPaymentResponse
+	if err := xml.Unmarshal(responseXML, &legacy); err != nil {
+		return PaymentAPIResponse{}, fmt.Errorf("%w: respuesta XML ilegible: %w", ErrGatewayUnavailable, err)
+	}
+
+	if legacy.Status != "APPROVED" {
+		rejection := legacy.Error
+		if rejection == nil {
+			rejection = &xmlError{Text: "SIN MOTIVO"}
+		}
+		cause, ok := legacyErrorCodes[rejection.Code]
+		if !ok {
+			cause = ErrPaymentDeclined
+		}
+		err := fmt.Errorf("%w: %s %s", cause, rejection.Code, strings.ToLower(rejection.Text))
+		return PaymentAPIResponse{Status: "failed", Amount: req.Amount, Error: err.Error()}, err
+	}
+	receipt := newReceipt("xml", req.Amount)
+	return PaymentAPIResponse{ID: receipt.ID, Status: "succeeded", Amount: req.Amount, Reference: legacy.TransactionRef}, nil
+}
+
+// HandleJSON es la API moderna completa: JSON de entrada, JSON de salida. Los rechazos
+// se informan en el campo error de la respuesta, no como error de Go.
+func (a XMLPaymentAdapter) HandleJSON(body []byte) ([]byte, error) {
+	var req PaymentAPIRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedPayment, err)
+	}
+	response, _ := a.Charge(req)
+	return json.Marshal(response)
+}
+
+func (a XMLPaymentAdapter) Pay(amount float64) (Receipt, error) {
+	response, err := a.Charge(PaymentAPIRequest{AccountID: a.Account, Amount: amount, Currency: "USD"})
+	if err != nil {
+		return Receipt{}, err
+	}
+	return Receipt{ID: response.ID, Method: "xml", Amount: amount, Timestamp: time.Now(), Reference: response.Reference}, nil
+}
+
+// demonstrateXMLAdapter muestra el XML que viaja por debajo, peticiones JSON con sus
+// respuestas y la traducción de los códigos de rechazo a errores.
+func demonstrateXMLAdapter() {
+	fmt.Println("\n📜 API JSON sobre un servicio de pagos XML:")
+	service := &LegacyXMLPaymentService{}
+	adapter := XMLPaymentAdapter{Service: service, Account: "ACC-778899"}
+
+	request, _ := xml.MarshalIndent(xmlPaymentRequest{Account: "ACC-778899", Amount: xmlAmount{Currency: "USD", Cents: 1999}}, "", "  ")
+	response, _ := service.Process(request)
+	fmt.Printf("🔽 Lo que entiende el servicio antiguo:\n%s\n%s\n", request, response)
+
+	for _, body := range []string{
+		`{"account_id": "ACC-778899", "amount": 19.99, "currency": "usd"}`,
+		`{"account_id": "BLK-000001", "amount": 10, "currency": "USD"}`,
+		`{"account_id": "ACC-778899", "amount": 7500, "currency": "USD"}`,
+		`{"account_id": "ACC-778899", "amount": 10, "currency": "EUR"}`,
+	} {
+		out, err := adapter.HandleJSON([]byte(body))
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Printf("➡️ %s\n⬅️ %s\n", body, out)
+	}
+
+	printResult(ProcessPayment(adapter, 42))
+	_, err := adapter.Charge(PaymentAPIRequest{AccountID: "BLK-1", Amount: 1, Currency: "USD"})
+	fmt.Println("🔎 errors.Is(err, ErrPaymentDeclined):", errors.Is(err, ErrPaymentDeclined))
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DeliveryMode indica cómo entrega un artículo sus notificaciones.
+type DeliveryMode int
+
+const (
+	Sync      DeliveryMode = iota // broadcast notifica a un observador tras otro y retorna al terminar
+	Ordered                       // Asíncrono: una cola por artículo notifica los eventos en orden y, en cada uno, a los observadores por prioridad
+	Unordered                     // Asíncrono: cada broadcast y cada observador se notifican en su propia goroutine (sin orden ni prioridades)
+)
+
+// ErrObserverTimeout indica que un observador no terminó update dentro del tiempo permitido.
+var ErrObserverTimeout = errors.New("el observador no respondió a tiempo")
+
+// ItemOption configura un Item al crearlo.
+type ItemOption func(*Item)
+
+// WithDelivery elige el modo de entrega de las notificaciones. Por defecto es Sync.
+func WithDelivery(mode DeliveryMode) ItemOption {
+	return func(i *Item) {
+		i.delivery = mode
+	}
+}
+
+// WithObserverTimeout limita cuánto se espera a cada observador. Un observador lento
+// no se puede interrumpir, pero deja de retrasar a los demás: su update sigue en
+// segundo plano y la notificación se reporta con ErrObserverTimeout.
+func WithObserverTimeout(timeout time.Duration) ItemOption {
+	return func(i *Item) {
+		i.observerTimeout = timeout
+	}
+}
+
+// notify entrega event a observers según el modo de entrega y retorna el resultado de
+// cada entrega en un NotificationReport.
+func (i *Item) notify(observers []registration, event Event) NotificationReport {
+	report := NotificationReport{Event: event}
+	if i.delivery != Unordered {
+		for _, observer := range observers {
+			if result, ok := i.deliver(observer, event); ok {
+				report.Results = append(report.Results, result)
+			}
+		}
+		return report
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, observer := range observers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if result, ok := i.deliver(observer, event); ok {
+				mu.Lock()
+				report.Results = append(report.Results, result)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// deliver entrega event a un observador aplicando la FailurePolicy del artículo, y
+// retorna ok = false si no le correspondía el aviso (por su tipo o porque ya recibió
+// todos los que pidió). Un observador registrado con
+// RegisterOnce o RegisterN se da de baja al recibir su último aviso, antes de llamar a
+// update.
+func (i *Item) deliver(observer registration, event Event) (result NotificationResult, ok bool) {
+	if !observer.accepts(event.Type) {
+		return result, false // Se registró para otros tipos de evento
+	}
+	ok, last := observer.claim()
+	if !ok {
+		return result, false // Otro broadcast concurrente ya usó el último aviso
+	}
+	if last {
+		i.Unregister(observer.getId())
+	}
+
+	result.ObserverID = observer.getId()
+	delay := i.failurePolicy.RetryDelay
+	for {
+		result.Attempts++
+		result.Err = i.call(observer, event)
+		// Un observador que no respondió a tiempo sigue ocupado: reintentar solo lo recargaría
+		if result.Err == nil || errors.Is(result.Err, ErrObserverTimeout) || result.Attempts > i.failurePolicy.Retries {
+			break
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+
+	if result.Err == nil {
+		i.delivered.Inc(result.ObserverID)
+	} else if i.failurePolicy.Drop {
+		result.Dropped = i.Unregister(result.ObserverID)
+	}
+	return result, true
+}
+
+// call llama a update de un observador, esperándolo como máximo observerTimeout.
+func (i *Item) call(observer Observer, event Event) error {
+	if i.observerTimeout <= 0 {
+		return observer.update(event)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- observer.update(event)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(i.observerTimeout):
+		return fmt.Errorf("%w: tardó más de %v", ErrObserverTimeout, i.observerTimeout)
+	}
+}
+
+// Wait bloquea hasta que terminan los broadcasts asíncronos en curso.
+func (i *Item) Wait() {
+	i.pending.Wait()
+}
+
+// BroadcastAndWait notifica event y espera a que todos los observadores terminen,
+// sin importar el modo de entrega. Retorna el reporte de las entregas y sus errores
+// unidos (por ejemplo ErrObserverTimeout de los observadores lentos), o ctx.Err() si
+// el contexto termina antes; en ese caso las notificaciones pendientes siguen en
+// segundo plano.
+func (i *Item) BroadcastAndWait(ctx context.Context, event Event) (NotificationReport, error) {
+	observers := i.snapshot()
+	result := make(chan NotificationReport, 1)
+	i.pending.Add(1)
+	go func() {
+		defer i.pending.Done()
+		result <- i.notify(observers, event)
+	}()
+
+	select {
+	case report := <-result:
+		return report, report.Err()
+	case <-ctx.Done():
+		return NotificationReport{Event: event}, ctx.Err()
+	}
+}
+
+// SlowClient es un observador que tarda delay en procesar cada aviso y registra el
+// orden en que los recibe.
+type SlowClient struct {
+	id       string
+	delay    time.Duration
+	received *[]string
+	mu       *sync.Mutex
+}
+
+func (s *SlowClient) getId() string {
+	return s.id
+}
+
+func (s *SlowClient) update(Event) error {
+	time.Sleep(s.delay)
+	s.mu.Lock()
+	*s.received = append(*s.received, s.id)
+	s.mu.Unlock()
+	return nil
+}
+
+// demonstrateAsyncBroadcast compara los modos de entrega con tres observadores lentos
+// y muestra los timeouts por observador y por broadcast.
+func demonstrateAsyncBroadcast() {
+	fmt.Println("\n⚡ Notificaciones asíncronas:")
+	delays := []time.Duration{300 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond}
+
+	for _, mode := range []struct {
+		name string
+		mode DeliveryMode
+	}{{"Sync", Sync}, {"Ordered", Ordered}, {"Unordered", Unordered}} {
+		var (
+			mu       sync.Mutex
+			received []string
+		)
+		item := NewItem("Teclado mecánico", WithDelivery(mode.mode))
+		for n, delay := range delays {
+			item.register(&SlowClient{id: fmt.Sprintf("lento-%d", n+1), delay: delay, received: &received, mu: &mu})
+		}
+
+		start := time.Now()
+		item.broadcast(Event{ItemName: item.name, Type: EventAvailable, Timestamp: start, NewAvailable: true})
+		returned := time.Since(start)
+		item.Wait()
+		fmt.Printf("🚚 %-9s broadcast retornó en %-5v, entregas completas en %-5v, orden: %v\n",
+			mode.name, returned.Round(100*time.Millisecond), time.Since(start).Round(100*time.Millisecond), received)
+	}
+
+	// Un observador que se cuelga no retrasa a los demás más allá de su timeout
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	item := NewItem("Mouse inalámbrico", WithDelivery(Unordered), WithObserverTimeout(250*time.Millisecond))
+	item.register(&SlowClient{id: "rápido", delay: 50 * time.Millisecond, received: &received, mu: &mu})
+	item.register(&SlowClient{id: "colgado", delay: time.Second, received: &received, mu: &mu})
+	event := Event{ItemName: item.name, Type: EventAvailable, Timestamp: time.Now(), NewAvailable: true}
+
+	start := time.Now()
+	_, err := item.BroadcastAndWait(context.Background(), event)
+	fmt.Printf("⏱️ BroadcastAndWait en %v: %v | errors.Is(err, ErrObserverTimeout): %t\n",
+		time.Since(start).Round(50*time.Millisecond), err, errors.Is(err, ErrObserverTimeout))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = item.BroadcastAndWait(ctx, event)
+	fmt.Println("⏱️ BroadcastAndWait con un contexto de 20ms:", err)
+	item.Wait()
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/eventbus"
+)
+
+// BusObserver conecta un Item con un eventbus.Bus: es un observador más del artículo
+// que republica cada Event en el tópico "inventory.<tipo>". Quien se suscribe al bus
+// recibe los avisos sin registrarse en cada artículo, ni saber qué artículos existen.
+type BusObserver struct {
+	id  string
+	bus *eventbus.Bus[Event]
+}
+
+func NewBusObserver(id string, bus *eventbus.Bus[Event]) *BusObserver {
+	return &BusObserver{
+		id:  id,
+		bus: bus,
+	}
+}
+
+func (b *BusObserver) getId() string {
+	return b.id
+}
+
+func (b *BusObserver) update(event Event) error {
+	b.bus.Publish(EventTopic(event), event) // Sin suscriptores no es un error: nadie pidió ese tópico
+	return nil
+}
+
+// EventTopic es el tópico del bus en el que se publica event.
+func EventTopic(event Event) string {
+	return "inventory." + string(event.Type)
+}
+
+// demonstrateEventBus publica los cambios de dos artículos en un bus de eventos y los
+// consume desde dos servicios suscritos a distintos tópicos. El mismo Bus genérico
+// sirve para otros tipos de payload, como los pedidos.
+func demonstrateEventBus() {
+	fmt.Println("\n🚌 Bus de eventos por tópicos:")
+	bus := eventbus.NewBus[Event]()
+
+	var wg sync.WaitGroup
+	received := make(map[string][]string) // Lo que recibió cada servicio, para mostrarlo en orden
+	var mu sync.Mutex
+	consume := func(service string, events <-chan Event) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range events { // Termina cuando se cancela la suscripción
+				mu.Lock()
+				received[service] = append(received[service], event.ItemName)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	available, cancelAvailable := bus.Subscribe("inventory.available")
+	consume("📧 correos", available)
+	inventoryAvailable, cancelInventoryAvailable := bus.Subscribe("inventory.available")
+	inventoryUnavailable, cancelInventoryUnavailable := bus.Subscribe("inventory.unavailable")
+	consume("📦 inventario (available)", inventoryAvailable)
+	consume("📦 inventario (unavailable)", inventoryUnavailable)
+	fmt.Printf("👂 Suscriptores: inventory.available=%d, inventory.unavailable=%d\n",
+		bus.Subscribers("inventory.available"), bus.Subscribers("inventory.unavailable"))
+
+	quiet.Store(true)
+	for _, name := range []string{"Tarjeta Gráfica RTX 4090", "Monitor Samsung 4K"} {
+		item := NewItem(name)
+		item.register(NewBusObserver("bus", bus))
+		item.MarkAsAvailable()
+		item.MarkAsUnavailable()
+	}
+	quiet.Store(false)
+
+	for _, cancel := range []func(){cancelAvailable, cancelInventoryAvailable, cancelInventoryUnavailable} {
+		cancel()
+	}
+	wg.Wait()
+	for _, service := range []string{"📧 correos", "📦 inventario (available)", "📦 inventario (unavailable)"} {
+		fmt.Printf("%s recibió %d eventos: %v\n", service, len(received[service]), received[service])
+	}
+	fmt.Println("👂 Publicar sin suscriptores entrega a:", bus.Publish("inventory.available", Event{}))
+
+	// El bus es genérico: otro tipo de payload, otro bus
+	orders := eventbus.NewBus[string]()
+	confirmations, cancel := orders.Subscribe("orders.created")
+	defer cancel()
+	orders.Publish("orders.created", "pedido #1001")
+	fmt.Println("🧾 Bus de pedidos:", <-confirmations)
+}
+
+// demonstrateWildcardTopics suscribe patrones con comodines que se solapan y muestra,
+// tópico por tópico, qué suscripciones reciben cada publicación.
+func demonstrateWildcardTopics() {
+	fmt.Println("\n🌳 Tópicos jerárquicos con comodines (* un nivel, # varios):")
+	bus := eventbus.NewBus[string]()
+	patterns := []string{
+		"inventory.gpu.rtx4090",
+		"inventory.gpu.*",
+		"inventory.gpu.#",
+		"inventory.*.rtx4090",
+		"inventory.#",
+		"*.gpu.*",
+		"#",
+	}
+	channels := make([]<-chan string, len(patterns))
+	for n, pattern := range patterns {
+		ch, cancel := bus.Subscribe(pattern)
+		defer cancel()
+		channels[n] = ch
+	}
+
+	topics := []string{
+		"inventory.gpu.rtx4090",
+		"inventory.gpu",
+		"inventory.gpu.rtx4090.oc",
+		"inventory.monitor.rtx4090",
+		"orders.gpu.rtx4090",
+	}
+	for _, topic := range topics {
+		delivered := bus.Publish(topic, topic)
+		var got []string
+		for n, ch := range channels {
+			for len(ch) > 0 {
+				<-ch
+				got = append(got, patterns[n])
+			}
+		}
+		fmt.Printf("📨 %-26s → %d: %v\n", topic, delivered, got)
+	}
+
+	for _, pattern := range []string{"inventory.#.gpu", "inventory..gpu", "inventory.gp*"} {
+		fmt.Println("🚫", eventbus.ValidatePattern(pattern))
+	}
+}
+
+// demonstrateBackpressure publica 50 eventos seguidos a un suscriptor lento con buffer
+// de 5, con cada política de Overflow: Block frena al publicador, las otras dos lo
+// dejan seguir a costa de descartar eventos.
+func demonstrateBackpressure() {
+	fmt.Println("\n🚰 Backpressure con un suscriptor lento (buffer de 5):")
+	const events = 50
+	for _, policy := range []eventbus.Overflow{eventbus.Block, eventbus.DropOldest, eventbus.DropNewest} {
+		bus := eventbus.NewBus[int]()
+		sub := bus.SubscribeWith("inventory.stock", eventbus.WithSubscriberBuffer(5), eventbus.WithOverflow(policy))
+
+		var received []int
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for n := range sub.C() {
+				received = append(received, n)
+				time.Sleep(2 * time.Millisecond) // Un consumidor lento
+			}
+		}()
+
+		start := time.Now()
+		for n := range events {
+			bus.Publish("inventory.stock", n)
+		}
+		published := time.Since(start)
+		sub.Cancel() // Cierra el canal: el consumidor procesa lo que quedó en el buffer y termina
+		<-done
+
+		fmt.Printf("📤 %-10s publicó %d eventos en %-6v | recibidos: %2d, descartados: %2d, últimos recibidos: %v\n",
+			policy, events, published.Round(time.Millisecond), len(received), sub.Dropped(), received[max(0, len(received)-3):])
+	}
+}
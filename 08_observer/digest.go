@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDigestClosed indica que se notificó a un Digest que ya se cerró.
+var ErrDigestClosed = errors.New("el resumen ya se cerró")
+
+// DigestObserver recibe los eventos agrupados en un resumen en vez de uno por uno,
+// como un correo diario que no llena la bandeja del cliente.
+type DigestObserver interface {
+	getId() string
+	updateDigest(events []Event) error
+}
+
+// Digest acumula los eventos de un DigestObserver y se los entrega juntos cada size
+// eventos o cada every desde el primero pendiente, lo que ocurra primero. Implementa
+// Observer, así que se registra en un Item como cualquier otro. Al apagar la aplicación
+// hay que llamar a Close para entregar lo que quedó pendiente.
+type Digest struct {
+	observer DigestObserver
+	size     int
+	every    time.Duration
+
+	flushMu sync.Mutex // Serializa las entregas: los resúmenes llegan en orden
+	mu      sync.Mutex // Protege pending, timer, gen y closed
+	pending []Event
+	timer   *time.Timer
+	gen     int // Cambia en cada entrega, para que un timer viejo no entregue el resumen siguiente antes de tiempo
+	closed  bool
+}
+
+// NewDigest agrupa los eventos de observer en resúmenes de hasta size eventos, entregados
+// a más tardar every después del primer evento pendiente.
+func NewDigest(observer DigestObserver, size int, every time.Duration) *Digest {
+	return &Digest{observer: observer, size: max(size, 1), every: every}
+}
+
+func (d *Digest) getId() string {
+	return d.observer.getId()
+}
+
+// update agrega el evento al resumen pendiente y lo entrega si alcanzó size.
+func (d *Digest) update(event Event) error {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return ErrDigestClosed
+	}
+	d.pending = append(d.pending, event)
+	if len(d.pending) == 1 && d.every > 0 {
+		gen := d.gen
+		d.timer = time.AfterFunc(d.every, func() {
+			if err := d.flush(gen); err != nil {
+				fmt.Printf("⚠️ Resumen de %s: %v\n", d.getId(), err) // Nadie espera al timer: solo queda avisar
+			}
+		})
+	}
+	full := len(d.pending) >= d.size
+	gen := d.gen
+	d.mu.Unlock()
+
+	if full {
+		return d.flush(gen)
+	}
+	return nil
+}
+
+// Flush entrega ya el resumen pendiente, si hay eventos.
+func (d *Digest) Flush() error {
+	d.mu.Lock()
+	gen := d.gen
+	d.mu.Unlock()
+	return d.flush(gen)
+}
+
+// Close entrega lo pendiente y cierra el resumen: los eventos que lleguen después se
+// rechazan con ErrDigestClosed. Llamarlo más de una vez no tiene efecto.
+func (d *Digest) Close() error {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return nil
+	}
+	d.closed = true
+	gen := d.gen
+	d.mu.Unlock()
+	return d.flush(gen)
+}
+
+// flush entrega el resumen pendiente si sigue siendo el de la generación gen; si ya se
+// entregó (por tamaño, por tiempo o con Flush), no hace nada.
+func (d *Digest) flush(gen int) error {
+	d.flushMu.Lock()
+	defer d.flushMu.Unlock()
+
+	d.mu.Lock()
+	if gen != d.gen || len(d.pending) == 0 {
+		d.mu.Unlock()
+		return nil
+	}
+	events := d.pending
+	d.pending = nil
+	d.gen++
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.mu.Unlock()
+
+	return d.observer.updateDigest(events)
+}
+
+// EmailDigestClient envía un solo correo con todos los cambios del resumen.
+type EmailDigestClient struct {
+	id    string
+	email string
+}
+
+func (e *EmailDigestClient) getId() string {
+	return e.id
+}
+
+func (e *EmailDigestClient) updateDigest(events []Event) error {
+	lines := make([]string, len(events))
+	for n, event := range events {
+		lines[n] = "   • " + event.String()
+	}
+	fmt.Printf("📧 Resumen para %s, cambios: %d\n%s\n", e.email, len(events), strings.Join(lines, "\n"))
+	return nil
+}
+
+// demonstrateDigest agrupa los cambios de un artículo en resúmenes: uno se envía por
+// tamaño, otro por tiempo y el último al cerrar.
+func demonstrateDigest() {
+	fmt.Println("\n📬 Notificaciones agrupadas en resúmenes:")
+	item := NewItem("Nintendo Switch 2")
+	digest := NewDigest(&EmailDigestClient{id: "1", email: "cliente1@gmail.com"}, 3, 100*time.Millisecond)
+	item.register(digest)
+
+	fmt.Println("⏩ Tres cambios seguidos: se envían al completar el resumen")
+	item.SetPrice(449)
+	item.SetStock(10) // Emite stock_changed y available
+
+	fmt.Println("⏳ Un cambio aislado: se envía cuando pasa el tiempo")
+	item.SetPrice(429)
+	time.Sleep(150 * time.Millisecond)
+
+	fmt.Println("🛑 Dos cambios y apagado: Close envía lo pendiente")
+	item.SetStock(4)
+	item.SetPrice(399)
+	item.Unregister(digest.getId()) // Primero se deja de recibir eventos y luego se cierra
+	if err := digest.Close(); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Printf("📊 Eventos entregados al resumen: %v | después de cerrar: %v\n", item.Delivered(), digest.update(Event{}))
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EventType indica qué cambió en el artículo.
+type EventType string
+
+const (
+	EventAvailable    EventType = "available"     // El artículo volvió a estar disponible
+	EventUnavailable  EventType = "unavailable"   // El artículo se agotó
+	EventPriceChanged EventType = "price_changed" // Cambió el precio
+	EventStockChanged EventType = "stock_changed" // Cambió la cantidad de unidades
+)
+
+// Event es lo que reciben los observadores: qué artículo cambió, qué tipo de cambio
+// fue, cuándo ocurrió, el estado del artículo (disponibilidad, precio y stock) antes y
+// después, y datos adicionales (tienda...) que el sujeto quiera compartir.
+type Event struct {
+	ItemName     string         `json:"item_name"`
+	Type         EventType      `json:"type"`
+	Timestamp    time.Time      `json:"timestamp"`
+	OldAvailable bool           `json:"old_available"`
+	NewAvailable bool           `json:"new_available"`
+	OldPrice     float64        `json:"old_price"`
+	NewPrice     float64        `json:"new_price"`
+	OldStock     int            `json:"old_stock"`
+	NewStock     int            `json:"new_stock"`
+	Metadata     map[string]any `json:"metadata,omitempty"`
+}
+
+func (e Event) String() string {
+	var change string
+	switch e.Type {
+	case EventPriceChanged:
+		change = fmt.Sprintf("$%.2f → $%.2f", e.OldPrice, e.NewPrice)
+	case EventStockChanged:
+		change = fmt.Sprintf("%d → %d unidades", e.OldStock, e.NewStock)
+	default:
+		change = fmt.Sprintf("%t → %t", e.OldAvailable, e.NewAvailable)
+	}
+	s := fmt.Sprintf("%s '%s' (%s) a las %s", e.Type, e.ItemName, change, e.Timestamp.Format(time.TimeOnly))
+	if len(e.Metadata) > 0 {
+		pairs := make([]string, 0, len(e.Metadata))
+		for _, key := range slices.Sorted(maps.Keys(e.Metadata)) {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", key, e.Metadata[key]))
+		}
+		s += " [" + strings.Join(pairs, " ") + "]"
+	}
+	return s
+}
+
+// LegacyObserver es la interfaz original de los observadores, que solo recibían el
+// nombre del artículo.
+type LegacyObserver interface {
+	getId() string
+	update(itemName string)
+}
+
+// legacyObserver adapta un LegacyObserver a Observer pasándole solo el nombre del artículo.
+type legacyObserver struct {
+	LegacyObserver
+}
+
+// FromLegacy permite registrar observadores escritos para la interfaz antigua sin
+// modificarlos.
+func FromLegacy(o LegacyObserver) Observer {
+	return legacyObserver{o}
+}
+
+func (l legacyObserver) update(event Event) error {
+	l.LegacyObserver.update(event.ItemName)
+	return nil // La interfaz antigua no reportaba errores
+}
+
+// SMSClient es un observador antiguo: sigue recibiendo solo el nombre del artículo.
+type SMSClient struct {
+	id    string
+	phone string
+}
+
+func NewSMSClient(id, phone string) *SMSClient {
+	return &SMSClient{
+		id:    id,
+		phone: phone,
+	}
+}
+
+func (s *SMSClient) getId() string {
+	return s.id
+}
+
+func (s *SMSClient) update(itemName string) {
+	fmt.Printf("💬 SMS para %s: El artículo '%s' cambió\n", s.phone, itemName)
+}
+
+// AuditClient registra cada evento completo; sirve para ver todos los datos que ahora
+// recibe un observador.
+type AuditClient struct {
+	id string
+}
+
+func (a *AuditClient) getId() string {
+	return a.id
+}
+
+func (a *AuditClient) update(event Event) error {
+	fmt.Println("🗂️ Auditoría:", event)
+	return nil
+}
+
+// demonstrateEvents agota y repone un artículo con metadatos, notificando a
+// observadores que usan el Event completo y a uno antiguo registrado con FromLegacy.
+func demonstrateEvents() {
+	fmt.Println("\n📦 Eventos con datos completos:")
+	audifonos := NewItem("Audífonos Sony WH-1000XM5")
+	audifonos.register(&AuditClient{id: "audit"})
+	audifonos.register(NewEmailClient("1", "cliente1@example.com"))
+	audifonos.register(FromLegacy(NewSMSClient("5", "+57 300 000 0000")))
+
+	audifonos.MarkAsAvailableWith(map[string]any{"precio": 349.99, "tienda": "Bogotá"})
+	audifonos.MarkAsUnavailable()
+}
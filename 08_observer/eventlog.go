@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// EventLog es un registro append-only de eventos: nunca se modifican ni se borran, solo
+// se agregan. Guardar cada broadcast convierte las notificaciones en una fuente de
+// verdad (como en event sourcing): un componente nuevo puede reconstruir lo que pasó
+// leyendo el log en lugar de depender de haber estado suscrito a tiempo.
+type EventLog interface {
+	Append(event Event) error
+	Since(from time.Time) ([]Event, error) // Eventos con Timestamp >= from, en orden
+}
+
+// MemoryEventLog guarda los eventos en memoria.
+type MemoryEventLog struct {
+	mu     sync.RWMutex
+	events []Event
+}
+
+func (l *MemoryEventLog) Append(event Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+	return nil
+}
+
+func (l *MemoryEventLog) Since(from time.Time) ([]Event, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var events []Event
+	for _, event := range l.events {
+		if !event.Timestamp.Before(from) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// FileEventLog guarda los eventos en un archivo, uno por línea en JSON (JSON Lines).
+// Como el archivo solo crece, sobrevive a reinicios: otro proceso que abra el mismo
+// path ve todo el historial.
+type FileEventLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileEventLog crea el log en path, creando el directorio si hace falta. Si el
+// archivo ya existe, los eventos nuevos se agregan al final.
+func NewFileEventLog(path string) (*FileEventLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("❌ no se pudo crear el directorio del log: %w", err)
+	}
+	return &FileEventLog{path: path}, nil
+}
+
+func (l *FileEventLog) Append(event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("❌ no se pudo serializar el evento: %w", err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("❌ no se pudo abrir el log: %w", err)
+	}
+	_, err = file.Write(append(line, '\n'))
+	return errors.Join(err, file.Close())
+}
+
+func (l *FileEventLog) Since(from time.Time) ([]Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	file, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil // Un log que todavía no tiene eventos
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ no se pudo abrir el log: %w", err)
+	}
+	defer file.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("❌ línea %d del log inválida: %w", line, err)
+		}
+		if !event.Timestamp.Before(from) {
+			events = append(events, event)
+		}
+	}
+	return events, scanner.Err()
+}
+
+// WithEventLog guarda cada cambio de disponibilidad del artículo en log.
+func WithEventLog(log EventLog) ItemOption {
+	return func(i *Item) {
+		i.eventLog = log
+	}
+}
+
+// Replay entrega a observer, en orden, los eventos de este artículo guardados en el
+// log desde from, y retorna cuántos entregó. Sirve para que un componente nuevo se
+// ponga al día antes de registrarse; los errores de update no detienen el replay y
+// se retornan unidos.
+func (i *Item) Replay(from time.Time, observer Observer) (int, error) {
+	if i.eventLog == nil {
+		return 0, fmt.Errorf("❌ el artículo '%s' no tiene un log de eventos", i.name)
+	}
+	events, err := i.eventLog.Since(from)
+	if err != nil {
+		return 0, err
+	}
+	replayed := 0
+	var errs []error
+	for _, event := range events {
+		if event.ItemName != i.name {
+			continue // El log puede ser compartido por varios artículos
+		}
+		errs = append(errs, observer.update(event))
+		replayed++
+	}
+	return replayed, errors.Join(errs...)
+}
+
+// demonstrateEventLog guarda los cambios de un artículo en un log en archivo. Luego un
+// componente nuevo se pone al día con Replay, otra instancia del artículo (como la de
+// un proceso reiniciado) lee el mismo archivo, y dos artículos comparten un log en memoria.
+func demonstrateEventLog() {
+	fmt.Println("\n📜 Log de eventos con replay:")
+	path := filepath.Join(os.TempDir(), "observer_events", "events.jsonl")
+	os.Remove(path) // Cada ejecución empieza con un log vacío
+	log, err := NewFileEventLog(path)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	quiet.Store(true)
+	item := NewItem("Cámara Sony A7 IV", WithEventLog(log))
+	item.MarkAsAvailableWith(map[string]any{"precio": 2499})
+	item.MarkAsUnavailable()
+	time.Sleep(10 * time.Millisecond)
+	checkpoint := time.Now() // El componente nuevo ya conocía lo anterior a este momento
+	item.MarkAsAvailableWith(map[string]any{"precio": 2299})
+	quiet.Store(false)
+
+	fmt.Println("🧩 Un componente nuevo se pone al día desde el último checkpoint:")
+	replayed, err := item.Replay(checkpoint, &AuditClient{id: "nuevo"})
+	fmt.Printf("   %d eventos reproducidos (error: %v)\n", replayed, err)
+
+	fmt.Println("🔄 Otra instancia del artículo (proceso reiniciado) lee el historial completo:")
+	restarted := NewItem("Cámara Sony A7 IV", WithEventLog(log))
+	replayed, err = restarted.Replay(time.Time{}, &AuditClient{id: "reiniciado"})
+	fmt.Printf("   %d eventos reproducidos (error: %v)\n", replayed, err)
+
+	// Un log en memoria compartido: cada artículo reproduce solo sus propios eventos
+	shared := &MemoryEventLog{}
+	lens := NewItem("Lente Sony 24-70mm", WithEventLog(shared))
+	body := NewItem("Cámara Sony A7C", WithEventLog(shared))
+	quiet.Store(true)
+	lens.MarkAsAvailable()
+	body.MarkAsAvailable()
+	body.MarkAsUnavailable()
+	quiet.Store(false)
+	all, _ := shared.Since(time.Time{})
+	replayed, _ = body.Replay(time.Time{}, &CountingClient{id: "contador"})
+	fmt.Printf("🧠 Log en memoria compartido: %d eventos en total, %d de '%s'\n", len(all), replayed, body.name)
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingClient guarda el stock de cada evento que recibe, en orden de llegada.
+// Puede tardar slowFor en procesar el evento con ese stock, para provocar desorden.
+type recordingClient struct {
+	id      string
+	slowFor int
+	mu      sync.Mutex
+	stocks  []int
+}
+
+func (r *recordingClient) getId() string {
+	return r.id
+}
+
+func (r *recordingClient) update(event Event) error {
+	if event.NewStock == r.slowFor {
+		time.Sleep(50 * time.Millisecond)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stocks = append(r.stocks, event.NewStock)
+	return nil
+}
+
+func (r *recordingClient) received() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.stocks)
+}
+
+func TestOrderedDeliveryKeepsOrderAcrossBroadcasts(t *testing.T) {
+	quiet.Store(true)
+	defer quiet.Store(false)
+
+	item := NewItem("Teclado", WithDelivery(Ordered))
+	client := &recordingClient{id: "registro", slowFor: 1}
+	item.register(client, ForEvents(EventStockChanged))
+
+	want := make([]int, 20)
+	for n := range want {
+		want[n] = n + 1
+		item.SetStock(n + 1)
+	}
+	item.Wait()
+
+	if got := client.received(); !slices.Equal(got, want) {
+		t.Errorf("orden recibido = %v, quiero %v", got, want)
+	}
+}
+
+// TestConcurrentSubject registra y da de baja observadores desde muchas goroutines
+// mientras otras cambian la disponibilidad del artículo. Con go test -race verifica
+// además que no hay condiciones de carrera.
+func TestConcurrentSubject(t *testing.T) {
+	quiet.Store(true)
+	defer quiet.Store(false)
+	const observers, broadcasts = 200, 100
+	item := NewItem("Tarjeta Gráfica RTX 5090")
+
+	var wg sync.WaitGroup
+	unsubscribes := make([]func(), observers)
+	for n := range observers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unsubscribes[n] = item.register(&CountingClient{id: fmt.Sprint(n)})
+		}()
+	}
+	for n := range broadcasts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if n%2 == 0 {
+				item.MarkAsAvailable()
+			} else {
+				item.MarkAsUnavailable()
+			}
+		}()
+	}
+	wg.Wait()
+	if got := len(item.Subscribers()); got != observers {
+		t.Fatalf("registros concurrentes: quedaron %d de %d", got, observers)
+	}
+
+	// La mitad se da de baja mientras siguen llegando avisos
+	for n := range observers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if n%2 == 0 {
+				unsubscribes[n]()
+			} else {
+				item.MarkAsAvailable()
+			}
+		}()
+	}
+	wg.Wait()
+	if got := len(item.Subscribers()); got != observers/2 {
+		t.Fatalf("tras dar de baja a la mitad quedan %d observadores, quiero %d", got, observers/2)
+	}
+
+	before := item.Delivered()
+	item.MarkAsAvailable()
+	after := item.Delivered()
+	for n := range observers {
+		id := fmt.Sprint(n)
+		notified := after[id] > before[id]
+		if notified != (n%2 == 1) {
+			t.Errorf("observador %s notificado en el último aviso: %t", id, notified)
+		}
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	quiet.Store(true)
+	defer quiet.Store(false)
+	item := NewItem("Consola PS5")
+	unsubscribe := item.register(&CountingClient{id: "1"})
+	item.register(&CountingClient{id: "2"})
+	item.register(&OneTimeClient{id: "3", item: item}) // Se da de baja desde update
+	item.register(&CountingClient{id: "4"})            // Va después del que se da de baja: no debe saltarse
+
+	item.MarkAsAvailable()
+	unsubscribe()
+	unsubscribe() // La segunda llamada no hace nada
+	if !item.Unregister("2") {
+		t.Error("Unregister(\"2\") = false, quiero true")
+	}
+	if item.Unregister("2") {
+		t.Error("Unregister(\"2\") por segunda vez = true, quiero false")
+	}
+	if item.Unregister("desconocido") {
+		t.Error("Unregister de un id no registrado = true, quiero false")
+	}
+	item.MarkAsAvailable()
+
+	want := map[string]int{"1": 1, "2": 1, "3": 1, "4": 2}
+	if got := item.Delivered(); !maps.Equal(got, want) {
+		t.Errorf("Delivered = %v, quiero %v", got, want)
+	}
+	if got := item.Subscribers(); !slices.Equal(got, []string{"4"}) {
+		t.Errorf("Subscribers = %v, quiero [4]", got)
+	}
+}
+
+func TestPriorityThenRegistrationOrder(t *testing.T) {
+	quiet.Store(true)
+	defer quiet.Store(false)
+	for _, mode := range []DeliveryMode{Sync, Ordered} {
+		var log []string
+		item := NewItem("Nintendo Switch 2", WithDelivery(mode))
+		register := func(id string, opts ...RegisterOption) {
+			item.register(orderRecorder{&CountingClient{id: id}, &log}, opts...)
+		}
+		register("bajo-1", WithPriority(PriorityLow))
+		register("normal-1")
+		register("bajo-2", WithPriority(PriorityLow))
+		register("alto", WithPriority(PriorityHigh))
+		register("normal-2", WithPriority(PriorityNormal))
+		register("muy-alto", WithPriority(PriorityHigh+1))
+
+		want := []string{"muy-alto", "alto", "normal-1", "normal-2", "bajo-1", "bajo-2"}
+		if got := item.Subscribers(); !slices.Equal(got, want) {
+			t.Errorf("modo %d: Subscribers = %v, quiero %v", mode, got, want)
+		}
+		item.MarkAsAvailable()
+		item.Wait()
+		if !slices.Equal(log, want) {
+			t.Errorf("modo %d: orden de entrega = %v, quiero %v", mode, log, want)
+		}
+	}
+}
+
+// typeRecorder guarda el tipo de cada evento que recibe.
+type typeRecorder struct {
+	id    string
+	types []EventType
+}
+
+func (r *typeRecorder) getId() string {
+	return r.id
+}
+
+func (r *typeRecorder) update(event Event) error {
+	r.types = append(r.types, event.Type)
+	return nil
+}
+
+func TestStickyReplaysCurrentState(t *testing.T) {
+	quiet.Store(true)
+	defer quiet.Store(false)
+	item := NewItem("AirPods Pro", WithStickyEvents())
+	item.SetPrice(249)
+	item.SetStock(3) // También lo vuelve disponible
+	item.SetStock(0) // También lo agota
+
+	late := &typeRecorder{id: "tarde"}
+	item.register(late)
+	want := []EventType{EventPriceChanged, EventStockChanged, EventUnavailable}
+	if !slices.Equal(late.types, want) {
+		t.Errorf("tras agotarse, el que llega tarde recibió %v, quiero %v", late.types, want)
+	}
+
+	item.MarkAsAvailable()
+	again := &typeRecorder{id: "de-nuevo"}
+	item.register(again)
+	want = []EventType{EventPriceChanged, EventStockChanged, EventAvailable}
+	if !slices.Equal(again.types, want) {
+		t.Errorf("tras reponerse, el que llega tarde recibió %v, quiero %v", again.types, want)
+	}
+}
+
+func TestRegisterN(t *testing.T) {
+	quiet.Store(true)
+	defer quiet.Store(false)
+	item := NewItem("Steam Deck OLED")
+	item.RegisterOnce(&CountingClient{id: "una-vez"})
+	item.RegisterN(&CountingClient{id: "dos-veces"}, 2)
+	item.register(&CountingClient{id: "siempre"})
+
+	for range 3 {
+		item.MarkAsAvailable()
+		item.MarkAsUnavailable()
+	}
+
+	want := map[string]int{"una-vez": 1, "dos-veces": 2, "siempre": 6}
+	if got := item.Delivered(); !maps.Equal(got, want) {
+		t.Errorf("Delivered = %v, quiero %v", got, want)
+	}
+	if got := item.Subscribers(); !slices.Equal(got, []string{"siempre"}) {
+		t.Errorf("Subscribers = %v, quiero [siempre]: los demás se dan de baja solos", got)
+	}
+}
+
+func TestRegisterOnceConcurrent(t *testing.T) {
+	quiet.Store(true)
+	defer quiet.Store(false)
+	item := NewItem("Steam Deck LCD", WithDelivery(Unordered))
+	item.RegisterOnce(&CountingClient{id: "una-vez"})
+
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item.MarkAsAvailable()
+		}()
+	}
+	wg.Wait()
+	item.Wait()
+
+	if got := item.Delivered()["una-vez"]; got != 1 {
+		t.Errorf("con 100 avisos simultáneos, RegisterOnce recibió %d, quiero 1", got)
+	}
+}
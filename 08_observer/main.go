@@ -1,54 +1,301 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
 
 // Subject-Observer Pattern - Ejemplo en Go
 
+// quiet silencia los mensajes de los artículos en las demostraciones con muchos avisos.
+var quiet atomic.Bool
+
+func logf(format string, args ...any) {
+	if !quiet.Load() {
+		fmt.Printf(format, args...)
+	}
+}
+
 // 1. Subject
 
 // 1.1 Subject: Definición de la interfaz de sujeto
-// Un sujeto puede registrar y notificar observadores
+// Un sujeto puede registrar, dar de baja, listar y notificar observadores
 type Subject interface {
-	register(observer Observer)
-	broadcast()
+	register(observer Observer, opts ...RegisterOption) (unsubscribe func())
+	Unregister(observerID string) bool
+	Subscribers() []string
+	broadcast(event Event)
 }
 
 // 1.2 Item: Implementación concreta del sujeto (Subject)
-// Item mantiene una lista de observadores y notifica cambios
+// Item mantiene una lista de observadores y notifica cambios. Es seguro usarlo desde
+// varias goroutines: mu protege observers y el estado del artículo, y la lista de observadores es
+// copy-on-write, así que un broadcast toma una copia con un RLock breve y notifica sin
+// tener el lock (un observador puede registrar o dar de baja a otros desde update).
 type Item struct {
-	observers []Observer
+	mu        sync.RWMutex
+	observers []registration // Ordenados por prioridad, de mayor a menor
 	name      string
 	available bool
+	price     float64
+	stock     int
+	delivered syncutil.SafeCounter[string] // Notificaciones entregadas por id de observador
+
+	delivery        DeliveryMode
+	observerTimeout time.Duration // Tiempo máximo que se espera a cada observador (0 = sin límite)
+	failurePolicy   FailurePolicy
+	onReport        func(NotificationReport) // Recibe el reporte de cada broadcast
+	sticky          bool                     // Los nuevos observadores reciben los eventos del estado actual al registrarse
+	lastEvent       *Event                   // Último evento, de cualquier tipo
+	lastByType      map[EventType]Event      // Últimos eventos de precio, stock y disponibilidad, para los eventos sticky
+	eventLog        EventLog                 // Registro de todos los eventos, para Replay
+	pending         sync.WaitGroup           // Broadcasts asíncronos en curso
+
+	queueMu  sync.Mutex        // Protege queue y draining
+	queue    []queuedBroadcast // Broadcasts pendientes en modo Ordered, en orden de llegada
+	draining bool              // Si hay una goroutine entregando la cola
+}
+
+// queuedBroadcast es un broadcast esperando su turno en la cola del modo Ordered.
+type queuedBroadcast struct {
+	observers []registration
+	event     Event
 }
 
-func NewItem(name string) *Item {
-	return &Item{
-		name: name,
+func NewItem(name string, opts ...ItemOption) *Item {
+	item := &Item{
+		name:       name,
+		lastByType: make(map[EventType]Event),
+	}
+	for _, opt := range opts {
+		opt(item)
 	}
+	return item
 }
 
-func (i *Item) register(observer Observer) {
-	i.observers = append(i.observers, observer)
+// register agrega observer y retorna una función que lo da de baja. Llamarla más de
+// una vez no tiene efecto.
+func (i *Item) register(observer Observer, opts ...RegisterOption) (unsubscribe func()) {
+	reg := registration{Observer: observer}
+	for _, opt := range opts {
+		opt(&reg)
+	}
+	i.mu.Lock()
+	// Va después de todos los de prioridad mayor o igual: entre iguales se respeta el orden de registro
+	index := len(i.observers)
+	for index > 0 && i.observers[index-1].priority < reg.priority {
+		index--
+	}
+	i.observers = slices.Insert(slices.Clip(i.observers), index, reg) // Clip obliga a copiar: las listas ya tomadas no cambian
+	var sticky []Event
+	if i.sticky {
+		sticky = slices.SortedFunc(maps.Values(i.lastByType), func(a, b Event) int { return a.Timestamp.Compare(b.Timestamp) })
+	}
+	i.mu.Unlock()
+
+	for _, event := range sticky {
+		i.replaySticky(reg, event)
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { i.Unregister(observer.getId()) })
+	}
+}
+
+// Unregister da de baja al observador con id observerID y retorna si estaba registrado.
+// No modifica el slice actual sino que crea uno nuevo (copy-on-write): un broadcast en
+// curso sigue recorriendo la lista que tenía, sin saltarse observadores, así que un
+// observador puede darse de baja a sí mismo (o a otro) desde update.
+func (i *Item) Unregister(observerID string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	index := slices.IndexFunc(i.observers, func(r registration) bool { return r.getId() == observerID })
+	if index < 0 {
+		return false
+	}
+	i.observers = slices.Delete(slices.Clone(i.observers), index, index+1)
+	return true
 }
 
 func (i *Item) MarkAsAvailable() {
-	fmt.Printf("🔔 El artículo '%s' ahora está disponible\n", i.name)
-	i.available = true
-	i.broadcast()
+	i.MarkAsAvailableWith(nil)
+}
+
+// MarkAsAvailableWith marca el artículo como disponible y agrega metadata al evento.
+func (i *Item) MarkAsAvailableWith(metadata map[string]any) {
+	logf("🔔 El artículo '%s' ahora está disponible\n", i.name)
+	i.setAvailable(EventAvailable, true, metadata)
+}
+
+func (i *Item) MarkAsUnavailable() {
+	logf("🔕 El artículo '%s' se agotó\n", i.name)
+	i.setAvailable(EventUnavailable, false, nil)
+}
+
+// setAvailable cambia la disponibilidad y notifica el cambio con un Event.
+func (i *Item) setAvailable(eventType EventType, available bool, metadata map[string]any) {
+	i.apply(func() []Event {
+		event := i.newEvent(eventType)
+		event.NewAvailable = available
+		event.Metadata = metadata
+		i.available = available
+		return []Event{event}
+	})
+}
+
+// newEvent crea un evento de tipo eventType con el estado actual del artículo como
+// estado anterior y nuevo; quien lo crea completa lo que cambió. Requiere tener mu.
+func (i *Item) newEvent(eventType EventType) Event {
+	return Event{
+		ItemName:     i.name,
+		Type:         eventType,
+		Timestamp:    time.Now(),
+		OldAvailable: i.available,
+		NewAvailable: i.available,
+		OldPrice:     i.price,
+		NewPrice:     i.price,
+		OldStock:     i.stock,
+		NewStock:     i.stock,
+	}
+}
+
+// apply ejecuta change con el lock tomado (change modifica el estado y retorna los
+// eventos que produjo), guarda los eventos y los notifica en orden.
+func (i *Item) apply(change func() []Event) {
+	i.mu.Lock()
+	events := change()
+	for _, event := range events {
+		i.lastEvent = &event
+		i.lastByType[event.Type] = event
+		// Solo la última disponibilidad describe el estado actual: tras agotarse, el
+		// aviso "disponible" ya no se repite a quien se registre después
+		switch event.Type {
+		case EventAvailable:
+			delete(i.lastByType, EventUnavailable)
+		case EventUnavailable:
+			delete(i.lastByType, EventAvailable)
+		}
+		if i.eventLog != nil {
+			// Se guarda con el lock tomado para que el log quede en el mismo orden que los cambios
+			if err := i.eventLog.Append(event); err != nil {
+				fmt.Printf("⚠️ '%s': no se pudo guardar el evento: %v\n", i.name, err)
+			}
+		}
+	}
+	observers := i.observers // Se toma junto con los eventos: quien se registre después los recibe como sticky
+	if i.delivery == Ordered {
+		// Se encolan con el lock tomado para que se entreguen en el mismo orden que los cambios
+		for _, event := range events {
+			i.enqueue(observers, event)
+		}
+	}
+	i.mu.Unlock()
+
+	if i.delivery != Ordered {
+		for _, event := range events {
+			i.broadcastTo(observers, event)
+		}
+	}
+}
+
+// broadcast notifica event a los observadores registrados según el modo de entrega
+// del artículo. En los modos asíncronos retorna enseguida; Wait espera a que terminen.
+func (i *Item) broadcast(event Event) {
+	i.broadcastTo(i.snapshot(), event)
+}
+
+func (i *Item) broadcastTo(observers []registration, event Event) {
+	switch i.delivery {
+	case Sync:
+		i.handleReport(i.notify(observers, event))
+	case Ordered:
+		i.enqueue(observers, event)
+	default:
+		i.pending.Add(1)
+		go func() {
+			defer i.pending.Done()
+			i.handleReport(i.notify(observers, event))
+		}()
+	}
 }
 
-func (i *Item) broadcast() {
-	for _, observer := range i.observers {
-		observer.update(i.name)
+// enqueue agrega un broadcast a la cola del modo Ordered y, si nadie la está
+// entregando, arranca una goroutine que la vacía. Así cada observador recibe los
+// eventos en el orden en que se emitieron, también entre broadcasts distintos.
+func (i *Item) enqueue(observers []registration, event Event) {
+	i.pending.Add(1)
+	i.queueMu.Lock()
+	defer i.queueMu.Unlock()
+	i.queue = append(i.queue, queuedBroadcast{observers: observers, event: event})
+	if !i.draining {
+		i.draining = true
+		go i.drain()
 	}
 }
 
+// drain entrega los broadcasts de la cola uno tras otro y termina cuando la cola queda vacía.
+func (i *Item) drain() {
+	for {
+		i.queueMu.Lock()
+		if len(i.queue) == 0 {
+			i.draining = false
+			i.queueMu.Unlock()
+			return
+		}
+		next := i.queue[0]
+		i.queue = i.queue[1:]
+		i.queueMu.Unlock()
+
+		i.handleReport(i.notify(next.observers, next.event))
+		i.pending.Done()
+	}
+}
+
+// snapshot retorna la lista actual de observadores. Como la lista es copy-on-write, no
+// cambia aunque después alguien se registre o se dé de baja.
+func (i *Item) snapshot() []registration {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.observers
+}
+
+// Subscribers retorna los ids de los observadores registrados, en el orden en que se
+// notifican.
+func (i *Item) Subscribers() []string {
+	observers := i.snapshot()
+	ids := make([]string, len(observers))
+	for n, observer := range observers {
+		ids[n] = observer.getId()
+	}
+	return ids
+}
+
+// Available indica si el artículo está disponible.
+func (i *Item) Available() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.available
+}
+
+// Delivered retorna cuántas notificaciones recibió cada observador, por id.
+func (i *Item) Delivered() map[string]int {
+	return i.delivered.Snapshot()
+}
+
 // 2. Observer
 
 // 2.1 Observer: Definición de la interfaz de observador
+// Recibe un Event con los datos del cambio; los observadores que solo necesitan el
+// nombre del artículo pueden seguir escritos como LegacyObserver (ver FromLegacy)
 type Observer interface {
 	getId() string
-	update(string)
+	update(event Event) error // Un error indica que el aviso no se pudo entregar (ver FailurePolicy)
 }
 
 // 2.2 EmailClient: Implementación concreta del observador (Observer)
@@ -69,8 +316,12 @@ func (e *EmailClient) getId() string {
 	return e.id
 }
 
-func (e *EmailClient) update(itemName string) {
-	fmt.Printf("📧 Notificación para %s: El artículo '%s' está disponible\n", e.email, itemName)
+func (e *EmailClient) update(event Event) error {
+	if event.Type != EventAvailable {
+		return nil // Solo se envía correo cuando el artículo vuelve a estar disponible
+	}
+	fmt.Printf("📧 Notificación para %s: El artículo '%s' está disponible\n", e.email, event.ItemName)
+	return nil
 }
 
 // 2.3 PushClient: Otro tipo de observador que recibe notificaciones push
@@ -91,8 +342,26 @@ func (p *PushClient) getId() string {
 	return p.id
 }
 
-func (p *PushClient) update(itemName string) {
-	fmt.Printf("📲 Notificación push para %s: El artículo '%s' está disponible\n", p.device, itemName)
+func (p *PushClient) update(event Event) error {
+	if event.Type != EventAvailable {
+		return nil
+	}
+	fmt.Printf("📲 Notificación push para %s: El artículo '%s' está disponible\n", p.device, event.ItemName)
+	return nil
+}
+
+// 2.4 CountingClient: Observador silencioso para las demostraciones con muchos avisos
+// CountingClient no hace nada con los avisos; el artículo cuenta los que recibe (ver Delivered)
+type CountingClient struct {
+	id string
+}
+
+func (c *CountingClient) getId() string {
+	return c.id
+}
+
+func (c *CountingClient) update(Event) error {
+	return nil
 }
 
 // 3. Demostración
@@ -118,4 +387,25 @@ func main() {
 	// Simular que los artículos se vuelven disponibles
 	tarjetaGrafica.MarkAsAvailable()
 	monitorSamsung.MarkAsAvailable()
+
+	fmt.Printf("📊 Notificaciones entregadas: %s => %v, %s => %v\n",
+		tarjetaGrafica.name, tarjetaGrafica.Delivered(), monitorSamsung.name, monitorSamsung.Delivered())
+
+	demonstrateUnregister()
+	demonstrateEvents()
+	demonstrateAsyncBroadcast()
+	demonstrateEventBus()
+	demonstrateWildcardTopics()
+	demonstrateBackpressure()
+	demonstratePriorities()
+	demonstrateRegisterOnce()
+	demonstrateNotificationReports()
+	demonstrateStickyEvents()
+	demonstrateEventLog()
+	demonstratePullModel()
+	demonstrateWebhooks()
+	demonstrateMiddleware()
+	demonstratePriceAndStock()
+	demonstrateDigest()
+	demonstrateSubscriptionManager()
 }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// ErrUnknownTopic indica que no hay ningún sujeto registrado con ese tópico.
+var ErrUnknownTopic = errors.New("tópico desconocido")
+
+// SubscriptionManager es el punto central donde se conectan los observadores con los
+// sujetos: cada sujeto se agrega con un nombre (su tópico) y el manager permite ver
+// quién está suscrito a qué y dar de baja a un cliente de todo a la vez.
+//
+// No guarda su propia lista de suscripciones sino que consulta a cada sujeto, así que
+// refleja también las bajas que hace el sujeto por su cuenta (RegisterOnce,
+// FailurePolicy.Drop o un Unregister directo).
+type SubscriptionManager struct {
+	mu       sync.RWMutex
+	subjects map[string]Subject
+}
+
+// NewSubscriptionManager crea un manager sin tópicos.
+func NewSubscriptionManager() *SubscriptionManager {
+	return &SubscriptionManager{subjects: make(map[string]Subject)}
+}
+
+// AddSubject agrega subject con el tópico topic, reemplazando al que tuviera ese nombre.
+func (m *SubscriptionManager) AddSubject(topic string, subject Subject) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subjects[topic] = subject
+}
+
+// subject retorna el sujeto de topic.
+func (m *SubscriptionManager) subject(topic string) (Subject, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	subject, ok := m.subjects[topic]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTopic, topic)
+	}
+	return subject, nil
+}
+
+// Subscribe registra observer en el sujeto de topic.
+func (m *SubscriptionManager) Subscribe(topic string, observer Observer, opts ...RegisterOption) error {
+	subject, err := m.subject(topic)
+	if err != nil {
+		return err
+	}
+	subject.register(observer, opts...)
+	return nil
+}
+
+// Unsubscribe da de baja al observador observerID del sujeto de topic y retorna si
+// estaba suscrito.
+func (m *SubscriptionManager) Unsubscribe(topic, observerID string) bool {
+	subject, err := m.subject(topic)
+	return err == nil && subject.Unregister(observerID)
+}
+
+// UnsubscribeAll da de baja al observador observerID de todos los tópicos y retorna, en
+// orden alfabético, de cuáles se dio de baja.
+func (m *SubscriptionManager) UnsubscribeAll(observerID string) []string {
+	var removed []string
+	for _, topic := range m.Topics() {
+		if m.Unsubscribe(topic, observerID) {
+			removed = append(removed, topic)
+		}
+	}
+	return removed
+}
+
+// ListSubscribers retorna los ids de los observadores suscritos a topic, en el orden en
+// que se notifican. Si el tópico no existe, retorna nil.
+func (m *SubscriptionManager) ListSubscribers(topic string) []string {
+	subject, err := m.subject(topic)
+	if err != nil {
+		return nil
+	}
+	return subject.Subscribers()
+}
+
+// Topics retorna los tópicos registrados en orden alfabético.
+func (m *SubscriptionManager) Topics() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Sorted(maps.Keys(m.subjects))
+}
+
+// demonstrateSubscriptionManager suscribe clientes a varios artículos desde un
+// manager central, lista las suscripciones y da de baja a un cliente de todo.
+func demonstrateSubscriptionManager() {
+	fmt.Println("\n🗃️ Manager central de suscripciones:")
+	manager := NewSubscriptionManager()
+	for _, name := range []string{"RTX 4090", "PS5 Pro", "Steam Deck"} {
+		manager.AddSubject(name, NewItem(name))
+	}
+
+	email := NewEmailClient("1", "cliente1@gmail.com")
+	push := NewPushClient("2", "Android de Cliente2")
+	sms := FromLegacy(&SMSClient{id: "3", phone: "+57 300 000 0000"})
+	for _, topic := range manager.Topics() {
+		_ = manager.Subscribe(topic, email)
+	}
+	_ = manager.Subscribe("PS5 Pro", push, WithPriority(PriorityHigh))
+	_ = manager.Subscribe("Steam Deck", sms)
+	_ = manager.Subscribe("Steam Deck", push)
+	if err := manager.Subscribe("Xbox Series X", email); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	printSubscriptions := func() {
+		for _, topic := range manager.Topics() {
+			fmt.Printf("   %-10s → %v\n", topic, manager.ListSubscribers(topic))
+		}
+	}
+	printSubscriptions()
+
+	fmt.Printf("👋 El cliente 1 se da de baja de todo: %v\n", manager.UnsubscribeAll(email.getId()))
+	fmt.Printf("👋 El cliente 1 otra vez: %v\n", manager.UnsubscribeAll(email.getId()))
+	fmt.Printf("👋 El cliente 3 deja 'Steam Deck': %t\n", manager.Unsubscribe("Steam Deck", sms.getId()))
+	printSubscriptions()
+}
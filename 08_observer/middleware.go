@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Los middleware envuelven un Observer con otro Observer (patrón Decorator): agregan
+// logging, reintentos o deduplicación sin modificar EmailClient, PushClient ni ningún
+// otro observador, y se pueden apilar en cualquier orden.
+
+// Middleware envuelve un observador y retorna otro con el mismo id.
+type Middleware func(Observer) Observer
+
+// Chain aplica middlewares a observer. El primero queda por fuera: en
+// Chain(o, WithLogging(), WithRetry(2)) el log muestra una sola llamada aunque haya
+// reintentos, y en Chain(o, WithRetry(2), WithLogging()) muestra cada intento.
+func Chain(observer Observer, middlewares ...Middleware) Observer {
+	for _, middleware := range slices.Backward(middlewares) {
+		observer = middleware(observer)
+	}
+	return observer
+}
+
+// loggingObserver muestra cada aviso, su resultado y cuánto tardó.
+type loggingObserver struct {
+	Observer
+}
+
+// WithLogging registra cada llamada a update del observador.
+func WithLogging() Middleware {
+	return func(o Observer) Observer {
+		return loggingObserver{o}
+	}
+}
+
+func (l loggingObserver) update(event Event) error {
+	fmt.Printf("📝 → %s.update(%s '%s')\n", l.getId(), event.Type, event.ItemName)
+	start := time.Now()
+	err := l.Observer.update(event)
+	elapsed := time.Since(start).Round(time.Microsecond)
+	if err != nil {
+		fmt.Printf("📝 ← %s falló en %v: %v\n", l.getId(), elapsed, err)
+	} else {
+		fmt.Printf("📝 ← %s ok en %v\n", l.getId(), elapsed)
+	}
+	return err
+}
+
+// retryObserver reintenta update cuando falla.
+type retryObserver struct {
+	Observer
+	retries int
+}
+
+// WithRetry reintenta hasta n veces los avisos que fallan, sin espera entre intentos.
+// A diferencia de FailurePolicy, que es del artículo, sirve para un solo observador.
+func WithRetry(n int) Middleware {
+	return func(o Observer) Observer {
+		return retryObserver{o, n}
+	}
+}
+
+func (r retryObserver) update(event Event) error {
+	var errs []error
+	for range r.retries + 1 {
+		err := r.Observer.update(event)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// dedupObserver descarta los avisos repetidos dentro de una ventana de tiempo.
+type dedupObserver struct {
+	Observer
+	window time.Duration
+	mu     *sync.Mutex
+	seen   map[string]time.Time // Última vez que se entregó cada aviso
+}
+
+// WithDeduplication descarta los avisos iguales (mismo artículo, tipo y estado nuevo:
+// disponibilidad, precio y stock) que lleguen antes de que pase window desde el último
+// entregado, para no mandar dos veces el mismo correo cuando el stock sube y baja
+// seguido. Un precio o un stock distinto no es un aviso repetido.
+func WithDeduplication(window time.Duration) Middleware {
+	return func(o Observer) Observer {
+		return dedupObserver{o, window, &sync.Mutex{}, make(map[string]time.Time)}
+	}
+}
+
+func (d dedupObserver) update(event Event) error {
+	key := fmt.Sprintf("%s|%s|%t|%v|%d", event.ItemName, event.Type, event.NewAvailable, event.NewPrice, event.NewStock)
+	d.mu.Lock()
+	last, ok := d.seen[key]
+	d.mu.Unlock()
+	if ok && event.Timestamp.Sub(last) < d.window {
+		logf("🧹 %s: aviso repetido de '%s' descartado\n", d.getId(), event.ItemName)
+		return nil
+	}
+
+	// Solo cuenta como entregado si update no falló: un reintento no es un aviso repetido
+	if err := d.Observer.update(event); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	d.seen[key] = event.Timestamp
+	d.mu.Unlock()
+	return nil
+}
+
+// demonstrateMiddleware apila middleware sobre observadores existentes: reintentos y
+// logging (en los dos órdenes) sobre uno que falla, y deduplicación sobre un correo.
+func demonstrateMiddleware() {
+	fmt.Println("\n🧅 Middleware de observadores (Decorator):")
+	item := NewItem("Kit de LEGO Halcón Milenario")
+	item.register(Chain(&FlakyClient{id: "log-afuera", failures: 1}, WithLogging(), WithRetry(2)))
+	item.register(Chain(&FlakyClient{id: "log-adentro", failures: 1}, WithRetry(2), WithLogging()))
+	item.register(Chain(NewEmailClient("correo", "cliente1@example.com"), WithDeduplication(time.Minute)))
+
+	item.MarkAsAvailable()
+	item.MarkAsUnavailable()
+	item.MarkAsAvailable() // Dentro de la ventana: el correo no se repite
+	fmt.Println("📊 Entregas:", item.Delivered())
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDeduplicationRetriesFailedDeliveries(t *testing.T) {
+	quiet.Store(true)
+	defer quiet.Store(false)
+	var reports []NotificationReport
+	item := NewItem("Kit de LEGO Halcón Milenario",
+		WithFailurePolicy(FailurePolicy{Retries: 2}),
+		WithOnReport(func(r NotificationReport) { reports = append(reports, r) }))
+	flaky := &FlakyClient{id: "correo", failures: 1}
+	item.register(Chain(flaky, WithDeduplication(time.Minute)))
+
+	item.MarkAsAvailable()
+	if len(reports) != 1 || len(reports[0].Results) != 1 {
+		t.Fatalf("reportes = %+v, quiero uno con un resultado", reports)
+	}
+	if result := reports[0].Results[0]; result.Attempts != 2 || result.Err != nil {
+		t.Errorf("resultado = %+v, quiero 2 intentos sin error", result)
+	}
+	if flaky.attempts != 2 {
+		t.Errorf("el observador recibió %d intentos, quiero 2: el reintento no es un aviso repetido", flaky.attempts)
+	}
+
+	// Una vez entregado, el mismo aviso dentro de la ventana sí se descarta
+	item.MarkAsUnavailable()
+	item.MarkAsAvailable()
+	if flaky.attempts != 3 {
+		t.Errorf("el observador recibió %d intentos, quiero 3 (el segundo disponible se descarta)", flaky.attempts)
+	}
+}
+
+func TestDeduplicationKeepsPriceAndStockChanges(t *testing.T) {
+	quiet.Store(true)
+	defer quiet.Store(false)
+	item := NewItem("iPad Air M2")
+	recorder := &typeRecorder{id: "auditoría"}
+	item.register(Chain(recorder, WithDeduplication(time.Minute)))
+
+	item.SetPrice(10)
+	item.SetPrice(8)
+	item.SetStock(5) // También lo vuelve disponible
+	item.SetStock(4)
+
+	want := []EventType{EventPriceChanged, EventPriceChanged, EventStockChanged, EventAvailable, EventStockChanged}
+	if !slices.Equal(recorder.types, want) {
+		t.Errorf("avisos entregados = %v, quiero %v", recorder.types, want)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// RegisterOnce registra observer para un solo aviso: se da de baja automáticamente al
+// recibirlo ("avísame una vez cuando vuelva a haber stock").
+func (i *Item) RegisterOnce(observer Observer, opts ...RegisterOption) (unsubscribe func()) {
+	return i.RegisterN(observer, 1, opts...)
+}
+
+// RegisterN registra observer para sus primeros n avisos. Aunque varios broadcasts
+// ocurran a la vez, el observador nunca recibe más de n.
+func (i *Item) RegisterN(observer Observer, n int, opts ...RegisterOption) (unsubscribe func()) {
+	remaining := new(atomic.Int64)
+	remaining.Store(int64(n))
+	return i.register(observer, append(opts, func(r *registration) { r.remaining = remaining })...)
+}
+
+// demonstrateRegisterOnce repone un artículo tres veces con clientes registrados para
+// uno, dos y todos los avisos, y muestra cuántos recibió cada uno.
+func demonstrateRegisterOnce() {
+	fmt.Println("\n1️⃣ Suscripciones de un solo aviso y de N avisos:")
+	item := NewItem("Steam Deck OLED")
+	item.RegisterOnce(NewEmailClient("una-vez", "cliente1@example.com"))
+	item.RegisterN(NewPushClient("dos-veces", "iPhone de Cliente3"), 2)
+	item.register(NewPushClient("siempre", "Android de Cliente4"))
+
+	for range 3 {
+		item.MarkAsAvailable()
+		item.MarkAsUnavailable()
+	}
+
+	delivered := item.Delivered()
+	for _, id := range []string{"una-vez", "dos-veces", "siempre"} {
+		fmt.Printf("📬 %s: %d avisos\n", id, delivered[id])
+	}
+	fmt.Println("👥 Siguen registrados:", item.Subscribers())
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
+
+// Prioridades de referencia para registrar observadores. Se puede usar cualquier entero:
+// los de prioridad mayor se notifican primero.
+const (
+	PriorityHigh   = 10
+	PriorityNormal = 0
+	PriorityLow    = -10
+)
+
+// registration es un observador registrado junto con sus opciones de registro.
+// Incrusta Observer, así que se puede notificar directamente.
+type registration struct {
+	Observer
+	priority  int
+	remaining *atomic.Int64 // Avisos que le quedan; nil = sin límite
+	events    []EventType   // Tipos de evento que recibe; vacío = todos
+}
+
+// accepts indica si el observador recibe eventos de tipo eventType.
+func (r registration) accepts(eventType EventType) bool {
+	return len(r.events) == 0 || slices.Contains(r.events, eventType)
+}
+
+// claim reserva un aviso para el observador. Retorna ok = false si ya no le quedan
+// avisos, y last = true si este es el último.
+func (r registration) claim() (ok, last bool) {
+	if r.remaining == nil {
+		return true, false
+	}
+	left := r.remaining.Add(-1)
+	return left >= 0, left == 0
+}
+
+// RegisterOption configura el registro de un observador.
+type RegisterOption func(*registration)
+
+// WithPriority define la prioridad del observador (por defecto PriorityNormal). En los
+// modos Sync y Ordered los de mayor prioridad se notifican primero, y los de igual
+// prioridad en el orden en que se registraron.
+func WithPriority(priority int) RegisterOption {
+	return func(r *registration) {
+		r.priority = priority
+	}
+}
+
+// InventorySystem es un observador interno que actualiza el inventario: tiene que
+// enterarse antes que los clientes, por si alguien compra enseguida.
+type InventorySystem struct {
+	id  string
+	log *[]string
+}
+
+func (s *InventorySystem) getId() string {
+	return s.id
+}
+
+func (s *InventorySystem) update(event Event) error {
+	*s.log = append(*s.log, s.id)
+	fmt.Printf("🏭 Inventario actualizado: '%s' disponible=%t\n", event.ItemName, event.NewAvailable)
+	return nil
+}
+
+// orderRecorder registra en log el id de cada observador al que llega un aviso.
+type orderRecorder struct {
+	Observer
+	log *[]string
+}
+
+func (r orderRecorder) update(event Event) error {
+	*r.log = append(*r.log, r.getId())
+	return r.Observer.update(event)
+}
+
+// demonstratePriorities registra observadores con distintas prioridades, en desorden,
+// y muestra que se notifican por prioridad y, entre iguales, por orden de registro.
+func demonstratePriorities() {
+	fmt.Println("\n🥇 Observadores con prioridad:")
+	var log []string
+	item := NewItem("Nintendo Switch 2")
+	item.register(orderRecorder{NewEmailClient("email-1", "cliente1@example.com"), &log}, WithPriority(PriorityLow))
+	item.register(orderRecorder{NewPushClient("push-1", "iPhone de Cliente3"), &log})
+	item.register(orderRecorder{NewEmailClient("email-2", "cliente2@example.com"), &log}, WithPriority(PriorityLow))
+	item.register(&InventorySystem{id: "inventario", log: &log}, WithPriority(PriorityHigh))
+	item.register(orderRecorder{NewPushClient("push-2", "Android de Cliente4"), &log}, WithPriority(PriorityNormal))
+
+	item.MarkAsAvailable()
+
+	fmt.Println("📋 Orden de entrega:", log)
+}
@@ -0,0 +1,120 @@
+package main
+
+import "fmt"
+
+// lowStockThreshold es la cantidad desde la que StockMonitor avisa que quedan pocas unidades.
+const lowStockThreshold = 2
+
+// SetPrice cambia el precio y emite EventPriceChanged. Si el precio no cambia, no
+// notifica nada.
+func (i *Item) SetPrice(price float64) {
+	i.apply(func() []Event {
+		if price == i.price {
+			return nil
+		}
+		event := i.newEvent(EventPriceChanged)
+		event.NewPrice = price
+		i.price = price
+		return []Event{event}
+	})
+}
+
+// SetStock cambia la cantidad de unidades y emite EventStockChanged. Si el artículo
+// pasa a tener (o a no tener) unidades, además cambia su disponibilidad y emite
+// EventAvailable o EventUnavailable.
+func (i *Item) SetStock(stock int) {
+	i.apply(func() []Event {
+		if stock == i.stock {
+			return nil
+		}
+		event := i.newEvent(EventStockChanged)
+		event.NewStock = stock
+		i.stock = stock
+		events := []Event{event}
+
+		if available := stock > 0; available != i.available {
+			eventType := EventUnavailable
+			if available {
+				eventType = EventAvailable
+			}
+			availability := i.newEvent(eventType)
+			availability.NewAvailable = available
+			i.available = available
+			events = append(events, availability)
+		}
+		return events
+	})
+}
+
+// Price retorna el precio del artículo.
+func (i *Item) Price() float64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.price
+}
+
+// Stock retorna cuántas unidades hay del artículo.
+func (i *Item) Stock() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.stock
+}
+
+// ForEvents registra al observador solo para los tipos de evento indicados.
+func ForEvents(types ...EventType) RegisterOption {
+	return func(r *registration) {
+		r.events = types
+	}
+}
+
+// PriceAlertClient avisa cuando el precio baja de lo que el cliente quiere pagar.
+type PriceAlertClient struct {
+	id     string
+	target float64
+}
+
+func (p *PriceAlertClient) getId() string {
+	return p.id
+}
+
+func (p *PriceAlertClient) update(event Event) error {
+	if event.NewPrice <= p.target && event.NewPrice < event.OldPrice {
+		fmt.Printf("💸 Alerta de precio para %s: '%s' bajó a $%.2f\n", p.id, event.ItemName, event.NewPrice)
+	}
+	return nil
+}
+
+// StockMonitor avisa a compras cuando quedan pocas unidades.
+type StockMonitor struct {
+	id string
+}
+
+func (s *StockMonitor) getId() string {
+	return s.id
+}
+
+func (s *StockMonitor) update(event Event) error {
+	if event.NewStock <= lowStockThreshold && event.NewStock < event.OldStock {
+		fmt.Printf("📉 %s: quedan %d unidades de '%s'\n", s.id, event.NewStock, event.ItemName)
+	}
+	return nil
+}
+
+// demonstratePriceAndStock cambia el precio y el stock de un artículo con observadores
+// registrados para distintos tipos de evento.
+func demonstratePriceAndStock() {
+	fmt.Println("\n🏷️ Eventos de precio y stock:")
+	item := NewItem("iPad Air M2")
+	item.register(&PriceAlertClient{id: "cliente-7", target: 550}, ForEvents(EventPriceChanged))
+	item.register(&StockMonitor{id: "compras"}, ForEvents(EventStockChanged))
+	item.register(NewPushClient("3", "iPhone de Cliente3"), ForEvents(EventAvailable))
+	item.register(&AuditClient{id: "audit"}) // Sin ForEvents: recibe todo
+
+	item.SetPrice(599)
+	item.SetStock(5) // También lo vuelve disponible
+	item.SetPrice(599)
+	item.SetPrice(529)
+	item.SetStock(2)
+	item.SetStock(0) // También lo agota
+	fmt.Printf("📊 Entregas: %v | precio $%.2f, stock %d, disponible %t\n", item.Delivered(), item.Price(), item.Stock(), item.Available())
+}
@@ -0,0 +1,114 @@
+package main
+
+import "fmt"
+
+// ItemState es la vista de solo lectura de un artículo que consultan los observadores
+// del modelo pull. No permite registrar observadores ni cambiar la disponibilidad.
+type ItemState interface {
+	Name() string
+	Available() bool
+	Price() float64
+	Stock() int
+	LastEvent() (Event, bool)
+}
+
+// Name retorna el nombre del artículo.
+func (i *Item) Name() string {
+	return i.name
+}
+
+// PullObserver es un observador del modelo pull: update no trae datos, solo avisa que
+// algo cambió, y el observador consulta al sujeto lo que le interesa.
+type PullObserver interface {
+	getId() string
+	update() error
+}
+
+// pullObserver adapta un PullObserver a Observer descartando el Event.
+type pullObserver struct {
+	PullObserver
+}
+
+// FromPull permite registrar un PullObserver en un Item.
+func FromPull(o PullObserver) Observer {
+	return pullObserver{o}
+}
+
+func (p pullObserver) update(Event) error {
+	return p.PullObserver.update()
+}
+
+// StockWatcher es un PullObserver: cuando le avisan, consulta la disponibilidad del
+// artículo. Guarda una referencia al sujeto, aunque solo a su vista de solo lectura.
+// Como un worker que lee de una cola, anota los avisos y los procesa después con Process.
+type StockWatcher struct {
+	id      string
+	state   ItemState
+	pending int
+	seen    []bool
+}
+
+func (w *StockWatcher) getId() string {
+	return w.id
+}
+
+func (w *StockWatcher) update() error {
+	w.pending++
+	return nil
+}
+
+// Process atiende los avisos pendientes consultando el estado del artículo.
+func (w *StockWatcher) Process() {
+	for ; w.pending > 0; w.pending-- {
+		w.seen = append(w.seen, w.state.Available()) // Lee el estado actual, no el del momento del cambio
+	}
+}
+
+// pushRecorder es el equivalente push de StockWatcher: encola cada Event y al
+// procesarlo usa los datos que trae.
+type pushRecorder struct {
+	id      string
+	pending []Event
+	seen    []bool
+}
+
+func (r *pushRecorder) getId() string {
+	return r.id
+}
+
+func (r *pushRecorder) update(event Event) error {
+	r.pending = append(r.pending, event)
+	return nil
+}
+
+func (r *pushRecorder) Process() {
+	for _, event := range r.pending {
+		r.seen = append(r.seen, event.NewAvailable) // El dato viaja con el aviso
+	}
+	r.pending = nil
+}
+
+// demonstratePullModel registra un observador push y uno pull que procesan sus avisos
+// más tarde, cambia la disponibilidad dos veces seguidas y compara lo que vio cada uno.
+func demonstratePullModel() {
+	fmt.Println("\n🔃 Modelo push vs. modelo pull:")
+	item := NewItem("Raspberry Pi 5")
+	push := &pushRecorder{id: "push"}
+	pull := &StockWatcher{id: "pull", state: item}
+	item.register(push)
+	item.register(FromPull(pull))
+
+	quiet.Store(true)
+	item.MarkAsAvailable()
+	item.MarkAsUnavailable() // Cambia antes de que los observadores procesen el primer aviso
+	quiet.Store(false)
+	push.Process()
+	pull.Process()
+
+	fmt.Printf("📬 Push vio: %v (el estado de cada cambio, viaja con el evento)\n", push.seen)
+	fmt.Printf("📪 Pull vio: %v (el estado al momento de consultar)\n", pull.seen)
+	fmt.Println("   Push ✅ cada observador recibe una foto consistente de cada cambio, aunque lo procese tarde")
+	fmt.Println("   Push ❌ el sujeto decide qué datos enviar; agregar uno cambia el Event de todos")
+	fmt.Println("   Pull ✅ update no depende de los datos: cada observador consulta solo lo que necesita")
+	fmt.Println("   Pull ❌ el observador depende de la interfaz del sujeto y puede leer un estado más nuevo que el aviso")
+}
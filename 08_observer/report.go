@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NotificationResult es el resultado de entregar un aviso a un observador.
+type NotificationResult struct {
+	ObserverID string
+	Attempts   int   // Veces que se llamó a update (1 + reintentos)
+	Err        error // Error del último intento; nil si se entregó
+	Dropped    bool  // Si el observador fue dado de baja por fallar
+}
+
+// NotificationReport reúne los resultados de un broadcast, un resultado por observador.
+type NotificationReport struct {
+	Event   Event
+	Results []NotificationResult
+}
+
+// Failed retorna los resultados de los observadores que no recibieron el aviso.
+func (r NotificationReport) Failed() []NotificationResult {
+	var failed []NotificationResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err retorna los errores de las entregas fallidas unidos con errors.Join, o nil si
+// todas tuvieron éxito.
+func (r NotificationReport) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s: %w", result.ObserverID, result.Err))
+	}
+	return errors.Join(errs...)
+}
+
+func (r NotificationReport) String() string {
+	failed := len(r.Failed())
+	return fmt.Sprintf("%s '%s': ✅ %d entregados, ❌ %d fallidos", r.Event.Type, r.Event.ItemName, len(r.Results)-failed, failed)
+}
+
+// FailurePolicy decide qué hacer con los observadores cuyo update retorna un error.
+// El valor cero solo reporta el fallo.
+type FailurePolicy struct {
+	Retries    int           // Reintentos tras el primer fallo
+	RetryDelay time.Duration // Espera antes del primer reintento; se duplica en cada uno
+	Drop       bool          // Dar de baja al observador si agota los reintentos
+}
+
+// WithFailurePolicy define cómo se tratan los observadores que fallan.
+func WithFailurePolicy(policy FailurePolicy) ItemOption {
+	return func(i *Item) {
+		i.failurePolicy = policy
+	}
+}
+
+// WithOnReport registra una función que recibe el NotificationReport de cada
+// broadcast, sea síncrono o asíncrono.
+func WithOnReport(fn func(NotificationReport)) ItemOption {
+	return func(i *Item) {
+		i.onReport = fn
+	}
+}
+
+// handleReport entrega el reporte de un broadcast que nadie está esperando: a onReport
+// si se configuró, y si no, muestra los fallos.
+func (i *Item) handleReport(report NotificationReport) {
+	if i.onReport != nil {
+		i.onReport(report)
+		return
+	}
+	for _, result := range report.Failed() {
+		fmt.Printf("⚠️ '%s': el observador %s falló tras %d intentos: %v\n", i.name, result.ObserverID, result.Attempts, result.Err)
+	}
+}
+
+// ErrDeliveryFailed es el error de los observadores de ejemplo que no logran entregar un aviso.
+var ErrDeliveryFailed = errors.New("no se pudo entregar el aviso")
+
+// FlakyClient falla sus primeros failures intentos y luego funciona; failures < 0
+// hace que falle siempre.
+type FlakyClient struct {
+	id       string
+	failures int
+	attempts int
+}
+
+func (f *FlakyClient) getId() string {
+	return f.id
+}
+
+func (f *FlakyClient) update(event Event) error {
+	f.attempts++
+	if f.failures < 0 || f.attempts <= f.failures {
+		return fmt.Errorf("%w: intento %d a %s", ErrDeliveryFailed, f.attempts, f.id)
+	}
+	return nil
+}
+
+// demonstrateNotificationReports notifica a observadores que fallan con tres políticas
+// distintas y muestra el NotificationReport de cada broadcast.
+func demonstrateNotificationReports() {
+	fmt.Println("\n📋 Reportes de notificación y políticas ante fallos:")
+	policies := []struct {
+		name   string
+		policy FailurePolicy
+	}{
+		{"solo reportar", FailurePolicy{}},
+		{"reintentar 2 veces", FailurePolicy{Retries: 2, RetryDelay: 10 * time.Millisecond}},
+		{"reintentar 1 vez y dar de baja", FailurePolicy{Retries: 1, RetryDelay: 10 * time.Millisecond, Drop: true}},
+	}
+	for _, p := range policies {
+		fmt.Printf("▶️ Política: %s\n", p.name)
+		var reports []NotificationReport
+		item := NewItem("Kindle Paperwhite", WithFailurePolicy(p.policy), WithOnReport(func(r NotificationReport) { reports = append(reports, r) }))
+		item.register(&CountingClient{id: "estable"})
+		item.register(&FlakyClient{id: "intermitente", failures: 2})
+		item.register(&FlakyClient{id: "caído", failures: -1})
+
+		quiet.Store(true)
+		item.MarkAsAvailable()
+		item.MarkAsUnavailable()
+		quiet.Store(false)
+
+		for _, report := range reports {
+			fmt.Println("   📋", report)
+			for _, result := range report.Results {
+				status := "✅"
+				if result.Err != nil {
+					status = "❌"
+				}
+				fmt.Printf("      %s %-12s intentos: %d", status, result.ObserverID, result.Attempts)
+				if result.Dropped {
+					fmt.Print(" (dado de baja)")
+				}
+				fmt.Println()
+			}
+		}
+		fmt.Println("   👥 Siguen registrados:", len(item.snapshot()))
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithStickyEvents hace que el artículo recuerde sus últimos eventos de precio, stock
+// y disponibilidad (solo el más reciente entre disponible y agotado) y se los entregue
+// a cada observador nuevo apenas se registra. Así un cliente que se suscribe después
+// de que el artículo volvió a estar disponible igual se entera, y uno que llega cuando
+// ya se agotó no recibe un "disponible" viejo.
+func WithStickyEvents() ItemOption {
+	return func(i *Item) {
+		i.sticky = true
+	}
+}
+
+// LastEvent retorna el último evento del artículo, si hubo alguno.
+func (i *Item) LastEvent() (Event, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.lastEvent == nil {
+		return Event{}, false
+	}
+	return *i.lastEvent, true
+}
+
+// replaySticky entrega un evento sticky a un observador recién registrado, con las
+// mismas reglas que un broadcast (ForEvents, RegisterOnce, timeouts, FailurePolicy).
+func (i *Item) replaySticky(reg registration, event Event) {
+	if result, ok := i.deliver(reg, event); ok {
+		i.handleReport(NotificationReport{Event: event, Results: []NotificationResult{result}})
+	}
+}
+
+// demonstrateStickyEvents registra un cliente después de que el artículo ya está
+// disponible, con y sin eventos sticky.
+func demonstrateStickyEvents() {
+	fmt.Println("\n📌 Eventos sticky para los que llegan tarde:")
+	for _, sticky := range []bool{false, true} {
+		var opts []ItemOption
+		if sticky {
+			opts = append(opts, WithStickyEvents())
+		}
+		item := NewItem("AirPods Pro", opts...)
+		item.register(NewEmailClient("1", "cliente1@example.com"))
+		item.MarkAsAvailableWith(map[string]any{"precio": 249})
+
+		time.Sleep(50 * time.Millisecond)
+		fmt.Printf("🕐 Con sticky=%t, el cliente 6 se registra después del aviso:\n", sticky)
+		item.register(&AuditClient{id: "6"})
+
+		delivered := item.Delivered()["6"]
+		want := 0
+		if sticky {
+			want = 1
+		}
+		status := "✅"
+		if delivered != want {
+			status = "❌"
+		}
+		fmt.Printf("%s El cliente 6 recibió %d avisos (esperados %d)\n", status, delivered, want)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import "fmt"
+
+// OneTimeClient es un observador que solo quiere el primer aviso: se da de baja a
+// sí mismo desde update, en medio del broadcast.
+type OneTimeClient struct {
+	id   string
+	item *Item
+}
+
+func (o *OneTimeClient) getId() string {
+	return o.id
+}
+
+func (o *OneTimeClient) update(event Event) error {
+	fmt.Printf("🎟️ Cliente %s recibió el aviso de '%s' y se da de baja\n", o.id, event.ItemName)
+	o.item.Unregister(o.id)
+	return nil
+}
+
+// demonstrateUnregister da de baja observadores de tres formas (con la función que
+// retorna register, con Unregister y desde el propio update) y muestra con los
+// contadores de entregas que los dados de baja ya no reciben avisos.
+func demonstrateUnregister() {
+	fmt.Println("\n🚪 Dar de baja observadores:")
+	consola := NewItem("Consola PS5")
+	unsubscribe := consola.register(NewEmailClient("1", "cliente1@example.com"))
+	consola.register(NewEmailClient("2", "cliente2@example.com"))
+	consola.register(&OneTimeClient{id: "3", item: consola})
+	consola.register(NewPushClient("4", "Android de Cliente4")) // Va después del que se da de baja: no debe saltarse
+
+	consola.MarkAsAvailable()
+
+	fmt.Println("➖ Cliente 1 cancela su suscripción y el 2 es dado de baja por id")
+	unsubscribe()
+	unsubscribe() // La segunda llamada no hace nada
+	fmt.Println("   Unregister(\"2\"):", consola.Unregister("2"), "| de nuevo:", consola.Unregister("2"))
+
+	consola.MarkAsAvailable()
+
+	fmt.Printf("📊 Avisos entregados por observador: %v | siguen registrados: %v\n", consola.Delivered(), consola.Subscribers())
+}
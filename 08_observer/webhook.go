@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// ErrWebhookFailed indica que el webhook no aceptó el evento.
+var ErrWebhookFailed = errors.New("el webhook no aceptó el evento")
+
+// webhookRetryDelay es la espera antes del primer reintento; se duplica en cada uno.
+const webhookRetryDelay = 50 * time.Millisecond
+
+// WebhookClient es un observador que vive en otro proceso: serializa cada Event a JSON
+// y lo envía con un POST a url. Cada intento tiene un timeout, y los fallos
+// transitorios (errores de red, timeouts y respuestas 5xx) se reintentan con backoff.
+// Una respuesta 4xx no se reintenta: el servidor rechazó el evento.
+type WebhookClient struct {
+	id      string
+	url     string
+	client  *http.Client
+	timeout time.Duration // Tiempo máximo por intento
+	retries int           // Reintentos tras el primer fallo transitorio
+}
+
+func NewWebhookClient(id, url string, timeout time.Duration, retries int) *WebhookClient {
+	return &WebhookClient{
+		id:      id,
+		url:     url,
+		client:  http.DefaultClient,
+		timeout: timeout,
+		retries: retries,
+	}
+}
+
+func (w *WebhookClient) getId() string {
+	return w.id
+}
+
+func (w *WebhookClient) update(event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWebhookFailed, err)
+	}
+
+	delay := webhookRetryDelay
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(event, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == w.retries {
+			return fmt.Errorf("%w: %w", ErrWebhookFailed, err)
+		}
+		logf("🔁 Webhook %s: intento %d falló (%v), reintentando en %v\n", w.id, attempt+1, err, delay)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// post hace un intento de envío y retorna si el error es transitorio.
+func (w *WebhookClient) post(event Event, body []byte) (retry bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Type", string(event.Type))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err // Error de red o timeout
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) // Leer el cuerpo permite reutilizar la conexión
+
+	switch {
+	case resp.StatusCode >= 500:
+		return true, fmt.Errorf("respuesta %s", resp.Status)
+	case resp.StatusCode >= 300:
+		return false, fmt.Errorf("respuesta %s", resp.Status)
+	}
+	return false, nil
+}
+
+// webhookServer simula los servidores que reciben los webhooks. Cada ruta se comporta
+// distinto: /ok acepta, /flaky falla dos veces antes de aceptar, /slow tarda más que
+// el timeout del cliente y /reject rechaza el evento.
+type webhookServer struct {
+	requests syncutil.SafeCounter[string] // Peticiones recibidas por ruta
+	flaky    atomic.Int32
+}
+
+func (s *webhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Inc(r.URL.Path)
+	var event Event
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/flaky":
+		if s.flaky.Add(1) <= 2 {
+			http.Error(w, "servicio no disponible", http.StatusServiceUnavailable)
+			return
+		}
+	case "/slow":
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+			return
+		}
+	case "/reject":
+		http.Error(w, "evento no soportado", http.StatusUnprocessableEntity)
+		return
+	}
+	fmt.Printf("🌐 %s recibió %s (%s)\n", r.URL.Path, event, r.Header.Get("X-Event-Type"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// demonstrateWebhooks notifica a cuatro webhooks HTTP, cada uno con un servidor que se
+// comporta distinto, y muestra el NotificationReport del broadcast.
+func demonstrateWebhooks() {
+	fmt.Println("\n🪝 Observadores webhook sobre HTTP:")
+	handler := &webhookServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var report NotificationReport
+	item := NewItem("Drone DJI Mini 4 Pro", WithOnReport(func(r NotificationReport) { report = r }))
+	for _, path := range []string{"/ok", "/flaky", "/slow", "/reject"} {
+		item.register(NewWebhookClient("webhook"+path, server.URL+path, 200*time.Millisecond, 2))
+	}
+
+	item.MarkAsAvailableWith(map[string]any{"precio": 959})
+
+	fmt.Println("📋", report)
+	for _, result := range report.Results {
+		status := "✅"
+		if result.Err != nil {
+			status = "❌"
+		}
+		fmt.Printf("   %s %-15s peticiones: %d", status, result.ObserverID, handler.requests.Get(result.ObserverID[len("webhook"):]))
+		if result.Err != nil {
+			fmt.Printf(" | %v", result.Err)
+		}
+		fmt.Println()
+	}
+}
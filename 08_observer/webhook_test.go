@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingWebhook es un servidor de webhooks de prueba: responde con statuses en
+// orden (el último se repite), tarda delay en cada respuesta y guarda lo recibido.
+type recordingWebhook struct {
+	statuses []int
+	delay    time.Duration
+
+	mu      sync.Mutex
+	bodies  [][]byte
+	headers []http.Header
+}
+
+func (h *recordingWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	h.mu.Lock()
+	attempt := len(h.bodies)
+	h.bodies = append(h.bodies, body)
+	h.headers = append(h.headers, r.Header.Clone())
+	h.mu.Unlock()
+
+	select {
+	case <-time.After(h.delay):
+	case <-r.Context().Done():
+		return
+	}
+	w.WriteHeader(h.statuses[min(attempt, len(h.statuses)-1)])
+}
+
+func (h *recordingWebhook) requests() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.bodies)
+}
+
+func TestWebhookClient(t *testing.T) {
+	quiet.Store(true)
+	defer quiet.Store(false)
+	tests := []struct {
+		name         string
+		statuses     []int
+		delay        time.Duration
+		retries      int
+		wantErr      error
+		wantRequests int
+	}{
+		{"aceptado", []int{http.StatusNoContent}, 0, 2, nil, 1},
+		{"5xx y luego aceptado", []int{503, 503, http.StatusOK}, 0, 2, nil, 3},
+		{"5xx agota los reintentos", []int{http.StatusInternalServerError}, 0, 1, ErrWebhookFailed, 2},
+		{"4xx no se reintenta", []int{http.StatusUnprocessableEntity}, 0, 3, ErrWebhookFailed, 1},
+		{"timeout", []int{http.StatusOK}, time.Second, 1, context.DeadlineExceeded, 2},
+	}
+	event := Event{
+		ItemName:     "Teclado",
+		Type:         EventPriceChanged,
+		Timestamp:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		OldPrice:     100,
+		NewPrice:     80,
+		NewAvailable: true,
+		Metadata:     map[string]any{"tienda": "centro"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &recordingWebhook{statuses: tt.statuses, delay: tt.delay}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+			client := NewWebhookClient("hook", server.URL, 100*time.Millisecond, tt.retries)
+
+			err := client.update(event)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, quiero %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrWebhookFailed) {
+				t.Errorf("err = %v, debería envolver ErrWebhookFailed", err)
+			}
+			if got := handler.requests(); got != tt.wantRequests {
+				t.Errorf("peticiones = %d, quiero %d", got, tt.wantRequests)
+			}
+
+			// Todos los intentos envían el mismo evento como JSON
+			handler.mu.Lock()
+			defer handler.mu.Unlock()
+			for n, body := range handler.bodies {
+				var got Event
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("intento %d: el cuerpo no es JSON válido: %v", n+1, err)
+				}
+				if got.ItemName != event.ItemName || got.Type != event.Type || !got.Timestamp.Equal(event.Timestamp) ||
+					got.NewPrice != event.NewPrice || got.Metadata["tienda"] != "centro" {
+					t.Errorf("intento %d: evento recibido = %+v, quiero %+v", n+1, got, event)
+				}
+				if ct, et := handler.headers[n].Get("Content-Type"), handler.headers[n].Get("X-Event-Type"); ct != "application/json" || et != string(EventPriceChanged) {
+					t.Errorf("intento %d: headers Content-Type=%q X-Event-Type=%q", n+1, ct, et)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookClientJSONFieldNames(t *testing.T) {
+	handler := &recordingWebhook{statuses: []int{http.StatusOK}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	if err := NewWebhookClient("hook", server.URL, time.Second, 0).update(Event{ItemName: "Mouse", Type: EventStockChanged, NewStock: 3}); err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(handler.bodies[0], &fields); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"item_name": "Mouse", "type": "stock_changed", "new_stock": 3.0}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("campo %q = %v, quiero %v", key, fields[key], value)
+		}
+	}
+	if _, ok := fields["metadata"]; ok {
+		t.Error("metadata vacía no debería enviarse")
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Patrón de Diseño Strategy - Ejemplo en Go
+
+El patrón Strategy es un patrón de diseño de comportamiento que define una familia
+de algoritmos, encapsula cada uno detrás de una misma interfaz y permite
+intercambiarlos en tiempo de ejecución sin cambiar el código que los usa.
+
+Problema que resuelve:
+- Una tienda calcula precios de varias formas (precio normal, Black Friday, miembros)
+- Con un switch por cada forma, agregar una nueva obliga a modificar la tienda
+- La forma de calcular debe poder cambiar mientras la tienda sigue atendiendo
+
+Solución:
+- Definir una interfaz (PricingStrategy) con la operación que varía
+- Implementar cada algoritmo como una estrategia concreta
+- La tienda (el contexto) guarda una estrategia y le delega el cálculo
+
+Ventajas:
+- Cada algoritmo vive en su propio tipo y se prueba por separado
+- Agregar una estrategia no modifica la tienda (principio abierto/cerrado)
+- La estrategia se elige o cambia en tiempo de ejecución
+
+En este ejemplo:
+  - Store: el contexto; lista el catálogo con una estrategia de precios y otra de orden
+  - PricingStrategy: RegularPricing, BlackFridayPricing y MemberPricing
+  - SortStrategy: ByName, ByPrice, ByRating, y Descending para invertir cualquiera de ellas
+  - SetPricing y SetSorting: cambian las estrategias mientras otras goroutines consultan el catálogo
+*/
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Product es un artículo del catálogo con su precio de lista.
+type Product struct {
+	Name     string
+	Category string
+	Price    float64
+	Rating   float64
+}
+
+// Listing es un producto con el precio que le asignó la estrategia de precios.
+type Listing struct {
+	Product
+	FinalPrice float64
+}
+
+// Store es el contexto del patrón: no sabe cómo se calculan los precios ni cómo se
+// ordena el catálogo, solo a qué estrategia preguntarle. Es seguro usarla desde varias
+// goroutines, incluso mientras se cambian las estrategias.
+type Store struct {
+	products []Product
+
+	mu      sync.RWMutex
+	pricing PricingStrategy
+	sorting SortStrategy
+}
+
+// NewStore crea una tienda con precios normales y el catálogo ordenado por nombre.
+func NewStore(products ...Product) *Store {
+	return &Store{
+		products: products,
+		pricing:  RegularPricing{},
+		sorting:  ByName{},
+	}
+}
+
+// SetPricing cambia la estrategia de precios.
+func (s *Store) SetPricing(pricing PricingStrategy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pricing = pricing
+}
+
+// SetSorting cambia la estrategia de orden del catálogo.
+func (s *Store) SetSorting(sorting SortStrategy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sorting = sorting
+}
+
+// strategies retorna las estrategias actuales, tomadas juntas para que un listado no
+// mezcle dos estrategias de precios.
+func (s *Store) strategies() (PricingStrategy, SortStrategy) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.pricing, s.sorting
+}
+
+// Catalog retorna los productos con su precio final y en el orden de las estrategias actuales.
+func (s *Store) Catalog() []Listing {
+	pricing, sorting := s.strategies()
+	listings := make([]Listing, len(s.products))
+	for n, product := range s.products {
+		listings[n] = Listing{Product: product, FinalPrice: roundCents(pricing.Price(product))}
+	}
+	sortListings(listings, sorting)
+	return listings
+}
+
+// printCatalog muestra el catálogo con las estrategias actuales.
+func printCatalog(store *Store) {
+	pricing, sorting := store.strategies()
+	fmt.Printf("🏷️ Precios: %s | ↕️ Orden: %s\n", pricing.Name(), sorting.Name())
+	for _, listing := range store.Catalog() {
+		price := fmt.Sprintf("$%.2f", listing.FinalPrice)
+		if listing.FinalPrice != listing.Price {
+			price = fmt.Sprintf("$%.2f (antes $%.2f)", listing.FinalPrice, listing.Price)
+		}
+		fmt.Printf("   %-22s %-10s ⭐ %.1f  %s\n", listing.Name, listing.Category, listing.Rating, price)
+	}
+}
+
+// sampleProducts es el catálogo de las demostraciones.
+func sampleProducts() []Product {
+	return []Product{
+		{Name: "Laptop Gamer", Category: "computo", Price: 1899.99, Rating: 4.7},
+		{Name: "Mouse inalámbrico", Category: "accesorio", Price: 39.90, Rating: 4.2},
+		{Name: "Monitor 4K", Category: "computo", Price: 429.00, Rating: 4.8},
+		{Name: "Audífonos", Category: "audio", Price: 129.50, Rating: 3.9},
+		{Name: "Teclado mecánico", Category: "accesorio", Price: 89.00, Rating: 4.5},
+	}
+}
+
+// main demuestra el uso del patrón Strategy
+func main() {
+	store := NewStore(sampleProducts()...)
+
+	fmt.Println("🛒 Catálogo con las estrategias por defecto:")
+	printCatalog(store)
+
+	fmt.Println("\n🖤 Llega el Black Friday: solo cambia la estrategia de precios")
+	blackFriday, err := NewBlackFridayPricing(30, "computo", "audio")
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	store.SetPricing(blackFriday)
+	store.SetSorting(ByPrice{})
+	printCatalog(store)
+
+	fmt.Println("\n💳 Un miembro del club ve sus precios, ordenados por calificación")
+	store.SetPricing(MemberPricing{Discount: 10, FreeShippingFrom: 100})
+	store.SetSorting(Descending(ByRating{}))
+	printCatalog(store)
+
+	if _, err := NewBlackFridayPricing(120); err != nil {
+		fmt.Println("\n❌", err)
+	}
+
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidDiscount indica un porcentaje de descuento fuera de rango.
+var ErrInvalidDiscount = errors.New("descuento inválido")
+
+// PricingStrategy calcula el precio final de un producto.
+type PricingStrategy interface {
+	Name() string
+	Price(product Product) float64
+}
+
+// RegularPricing cobra el precio de lista.
+type RegularPricing struct{}
+
+func (RegularPricing) Name() string {
+	return "normal"
+}
+
+func (RegularPricing) Price(product Product) float64 {
+	return product.Price
+}
+
+// BlackFridayPricing descuenta un porcentaje a los productos de las categorías en
+// oferta; si no tiene categorías, a todo el catálogo.
+type BlackFridayPricing struct {
+	percent    float64
+	categories []string
+}
+
+// NewBlackFridayPricing crea la estrategia de Black Friday con un descuento de percent
+// por ciento (mayor que 0 y menor que 100) para las categorías indicadas.
+func NewBlackFridayPricing(percent float64, categories ...string) (BlackFridayPricing, error) {
+	if percent <= 0 || percent >= 100 {
+		return BlackFridayPricing{}, fmt.Errorf("%w: Black Friday con %.0f%%, debe estar entre 0 y 100", ErrInvalidDiscount, percent)
+	}
+	return BlackFridayPricing{percent: percent, categories: categories}, nil
+}
+
+func (b BlackFridayPricing) Name() string {
+	if len(b.categories) == 0 {
+		return fmt.Sprintf("Black Friday -%.0f%%", b.percent)
+	}
+	return fmt.Sprintf("Black Friday -%.0f%% en %s", b.percent, strings.Join(b.categories, ", "))
+}
+
+func (b BlackFridayPricing) Price(product Product) float64 {
+	if len(b.categories) > 0 && !slices.Contains(b.categories, product.Category) {
+		return product.Price
+	}
+	return product.Price * (1 - b.percent/100)
+}
+
+// MemberPricing descuenta Discount por ciento a los miembros del club. Desde
+// FreeShippingFrom el envío es gratis; por debajo se suma memberShipping.
+type MemberPricing struct {
+	Discount         float64
+	FreeShippingFrom float64
+}
+
+// memberShipping es lo que paga un miembro por el envío de una compra pequeña.
+const memberShipping = 4.99
+
+func (m MemberPricing) Name() string {
+	return fmt.Sprintf("miembro -%.0f%% (envío gratis desde $%.0f)", m.Discount, m.FreeShippingFrom)
+}
+
+func (m MemberPricing) Price(product Product) float64 {
+	price := product.Price * (1 - m.Discount/100)
+	if price < m.FreeShippingFrom {
+		price += memberShipping
+	}
+	return price
+}
+
+// roundCents redondea un precio a centavos.
+func roundCents(price float64) float64 {
+	return math.Round(price*100) / 100
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// SortStrategy define el orden del catálogo: Compare retorna un número negativo si a va
+// antes que b, positivo si va después y 0 si da igual (como cmp.Compare).
+type SortStrategy interface {
+	Name() string
+	Compare(a, b Listing) int
+}
+
+// sortListings ordena listings con sorting. Es estable, así que los empates conservan
+// el orden del catálogo.
+func sortListings(listings []Listing, sorting SortStrategy) {
+	slices.SortStableFunc(listings, sorting.Compare)
+}
+
+// ByName ordena alfabéticamente, sin distinguir mayúsculas.
+type ByName struct{}
+
+func (ByName) Name() string {
+	return "nombre"
+}
+
+func (ByName) Compare(a, b Listing) int {
+	return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+}
+
+// ByPrice ordena del precio final más bajo al más alto.
+type ByPrice struct{}
+
+func (ByPrice) Name() string {
+	return "precio"
+}
+
+func (ByPrice) Compare(a, b Listing) int {
+	return cmp.Compare(a.FinalPrice, b.FinalPrice)
+}
+
+// ByRating ordena de la calificación más baja a la más alta.
+type ByRating struct{}
+
+func (ByRating) Name() string {
+	return "calificación"
+}
+
+func (ByRating) Compare(a, b Listing) int {
+	return cmp.Compare(a.Rating, b.Rating)
+}
+
+// descending invierte otra estrategia de orden.
+type descending struct {
+	SortStrategy
+}
+
+// Descending retorna la estrategia s en orden inverso. Las estrategias se combinan
+// porque todas comparten la misma interfaz.
+func Descending(s SortStrategy) SortStrategy {
+	return descending{s}
+}
+
+func (d descending) Name() string {
+	return d.SortStrategy.Name() + " (descendente)"
+}
+
+func (d descending) Compare(a, b Listing) int {
+	return d.SortStrategy.Compare(b, a)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// names retorna los nombres de los productos en el orden del listado.
+func names(listings []Listing) []string {
+	result := make([]string, len(listings))
+	for n, listing := range listings {
+		result[n] = listing.Name
+	}
+	return result
+}
+
+// prices retorna los precios finales en el orden del listado.
+func prices(listings []Listing) []float64 {
+	result := make([]float64, len(listings))
+	for n, listing := range listings {
+		result[n] = listing.FinalPrice
+	}
+	return result
+}
+
+func TestStrategies(t *testing.T) {
+	store := NewStore(
+		Product{Name: "B", Category: "audio", Price: 100, Rating: 3},
+		Product{Name: "A", Category: "computo", Price: 50, Rating: 5},
+		Product{Name: "C", Category: "computo", Price: 80, Rating: 4},
+	)
+	blackFriday, err := NewBlackFridayPricing(50, "computo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blackFridayAll, err := NewBlackFridayPricing(20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		pricing PricingStrategy
+		sorting SortStrategy
+		prices  []float64 // En el orden esperado
+		order   []string
+	}{
+		{"normal por nombre", RegularPricing{}, ByName{}, []float64{50, 100, 80}, []string{"A", "B", "C"}},
+		{"normal por precio", RegularPricing{}, ByPrice{}, []float64{50, 80, 100}, []string{"A", "C", "B"}},
+		{"Black Friday en computo por precio", blackFriday, ByPrice{}, []float64{25, 40, 100}, []string{"A", "C", "B"}},
+		{"Black Friday general por precio descendente", blackFridayAll, Descending(ByPrice{}), []float64{80, 64, 40}, []string{"B", "C", "A"}},
+		{"miembro por calificación", MemberPricing{Discount: 10, FreeShippingFrom: 60}, ByRating{}, []float64{90, 72, 49.99}, []string{"B", "C", "A"}},
+		{"miembro por calificación descendente", MemberPricing{Discount: 10, FreeShippingFrom: 60}, Descending(ByRating{}), []float64{49.99, 72, 90}, []string{"A", "C", "B"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Se cambian las estrategias de la misma tienda, sin crear otra
+			store.SetPricing(tt.pricing)
+			store.SetSorting(tt.sorting)
+			catalog := store.Catalog()
+			if got := names(catalog); !slices.Equal(got, tt.order) {
+				t.Errorf("orden = %v, quiero %v", got, tt.order)
+			}
+			if got := prices(catalog); !slices.Equal(got, tt.prices) {
+				t.Errorf("precios = %v, quiero %v", got, tt.prices)
+			}
+		})
+	}
+}
+
+func TestNewBlackFridayPricingInvalidDiscount(t *testing.T) {
+	for _, percent := range []float64{0, -10, 100, 120} {
+		if _, err := NewBlackFridayPricing(percent); !errors.Is(err, ErrInvalidDiscount) {
+			t.Errorf("NewBlackFridayPricing(%v): err = %v, quiero ErrInvalidDiscount", percent, err)
+		}
+	}
+}
+
+// TestConcurrentSwap cambia las estrategias mientras varias goroutines listan el
+// catálogo. Con -race verifica además que no hay condiciones de carrera; ningún
+// listado debe mezclar dos estrategias.
+func TestConcurrentSwap(t *testing.T) {
+	store := NewStore(sampleProducts()...)
+	blackFriday, err := NewBlackFridayPricing(30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pricings := []PricingStrategy{RegularPricing{}, blackFriday, MemberPricing{Discount: 10, FreeShippingFrom: 100}}
+	sortings := []SortStrategy{ByName{}, ByPrice{}, Descending(ByRating{})}
+
+	// Precios válidos de cada producto: los de alguna de las estrategias
+	valid := make(map[string][]float64)
+	for _, product := range sampleProducts() {
+		for _, pricing := range pricings {
+			valid[product.Name] = append(valid[product.Name], roundCents(pricing.Price(product)))
+		}
+	}
+
+	const readers, readsPerReader = 4, 200
+	var wg sync.WaitGroup
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range readsPerReader {
+				catalog := store.Catalog()
+				// Un listado usa una sola estrategia: todos sus precios salen de la misma
+				strategy := slices.Index(valid[catalog[0].Name], catalog[0].FinalPrice)
+				for _, listing := range catalog[1:] {
+					if strategy < 0 || valid[listing.Name][strategy] != listing.FinalPrice {
+						t.Errorf("listado con estrategias mezcladas: %v %v", names(catalog), prices(catalog))
+						return
+					}
+				}
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for swaps := 0; ; swaps++ {
+		select {
+		case <-done:
+			return
+		default:
+			store.SetPricing(pricings[swaps%len(pricings)])
+			store.SetSorting(sortings[swaps%len(sortings)])
+		}
+	}
+}
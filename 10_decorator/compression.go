@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"sync/atomic"
+)
+
+// encodingGzip es la marca de Compression en Message.Encodings.
+const encodingGzip = "gzip"
+
+// Compression es un decorador que comprime el cuerpo de cada mensaje con gzip. Lleva la
+// cuenta de los bytes que recibe y de los que envía para reportar cuánto ahorra.
+type Compression struct {
+	next     Notifier
+	bytesIn  atomic.Int64
+	bytesOut atomic.Int64
+}
+
+// NewCompression envuelve next para comprimir los mensajes antes de pasárselos.
+func NewCompression(next Notifier) *Compression {
+	return &Compression{next: next}
+}
+
+func (c *Compression) Send(msg Message) error {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(msg.Body); err != nil {
+		return fmt.Errorf("compression: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("compression: %w", err)
+	}
+	c.bytesIn.Add(int64(len(msg.Body)))
+	c.bytesOut.Add(int64(buf.Len()))
+
+	msg.Body = buf.Bytes()
+	msg.Encodings = append(msg.Encodings[:len(msg.Encodings):len(msg.Encodings)], encodingGzip) // Sin compartir el arreglo del llamador
+	return c.next.Send(msg)
+}
+
+// Stats resume los bytes procesados y la proporción resultante.
+func (c *Compression) Stats() string {
+	in, out := c.bytesIn.Load(), c.bytesOut.Load()
+	ratio := 0.0
+	if in > 0 {
+		ratio = float64(out) / float64(in) * 100
+	}
+	return fmt.Sprintf("gzip: %d → %d bytes (%.0f%%)", in, out, ratio)
+}
+
+// gunzip deshace Compression.
+func gunzip(body []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Decode recupera el cuerpo original de un mensaje deshaciendo sus transformaciones en
+// orden inverso al que se aplicaron, según Message.Encodings. key es la clave de
+// Encryption; si el mensaje no se cifró, no se usa.
+func Decode(msg Message, key []byte) ([]byte, error) {
+	body := msg.Body
+	for _, encoding := range slices.Backward(msg.Encodings) {
+		var err error
+		switch encoding {
+		case encodingGzip:
+			body, err = gunzip(body)
+		case encodingAESGCM:
+			aead, keyErr := newAEAD(key)
+			if keyErr != nil {
+				return nil, keyErr
+			}
+			body, err = decrypt(aead, body, msg.To)
+		default:
+			err = fmt.Errorf("codificación desconocida")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", encoding, err)
+		}
+	}
+	return body, nil
+}
+
+// demonstrateStackOrder apila los mismos decoradores en distinto orden. El resultado
+// cambia: comprimir después de cifrar no ahorra nada, porque los datos cifrados parecen
+// aleatorios.
+func demonstrateStackOrder(key []byte, body []byte) {
+	fmt.Println("\n🔀 El orden de la pila importa:")
+	stacks := []struct {
+		name  string
+		build func(Notifier) (Notifier, error)
+	}{
+		{"comprimir y luego cifrar", func(n Notifier) (Notifier, error) {
+			encryption, err := NewEncryption(n, key)
+			return NewCompression(encryption), err
+		}},
+		{"cifrar y luego comprimir", func(n Notifier) (Notifier, error) {
+			return NewEncryption(NewCompression(n), key)
+		}},
+		{"solo comprimir", func(n Notifier) (Notifier, error) {
+			return NewCompression(n), nil
+		}},
+	}
+	for _, stack := range stacks {
+		inbox := NewInbox()
+		notifier, err := stack.build(NewLogging(inbox, "red")) // Logging al fondo ve lo que sale por la red
+		if err != nil {
+			fmt.Println("❌", err)
+			continue
+		}
+		fmt.Printf("🧅 %s:\n", stack.name)
+		if err := notifier.Send(Message{To: "ana@gmail.com", Body: body}); err != nil {
+			fmt.Println("❌", err)
+			continue
+		}
+		decoded, err := Decode(inbox.Messages("ana@gmail.com")[0], key)
+		status := "✅"
+		if err != nil || !slices.Equal(decoded, body) {
+			status = "❌"
+		}
+		fmt.Printf("%s %d bytes originales → recuperados %d bytes\n", status, len(body), len(decoded))
+	}
+
+	// Un mensaje cifrado para otro destinatario no se descifra
+	inbox := NewInbox()
+	encryption, _ := NewEncryption(inbox, key)
+	_ = encryption.Send(Message{To: "ana@gmail.com", Body: body})
+	tampered := inbox.Messages("ana@gmail.com")[0]
+	tampered.To = "mallory@gmail.com"
+	_, err := Decode(tampered, key)
+	fmt.Printf("🕵️ Cambiando el destinatario: %v\n", err)
+
+	if _, err := NewEncryption(inbox, []byte("corta")); err != nil {
+		fmt.Printf("❌ Clave inválida: %v\n", err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Logging es un decorador que muestra cada envío y cuenta los enviados y los fallidos.
+// Según dónde se ponga en la pila ve el mensaje original o el que sale por la red.
+type Logging struct {
+	next   Notifier
+	name   string
+	sent   atomic.Int64
+	failed atomic.Int64
+}
+
+// NewLogging envuelve next para registrar sus envíos; name identifica la capa en los mensajes.
+func NewLogging(next Notifier, name string) *Logging {
+	return &Logging{next: next, name: name}
+}
+
+func (l *Logging) Send(msg Message) error {
+	err := l.next.Send(msg)
+	if err != nil {
+		l.failed.Add(1)
+		fmt.Printf("📝 [%s] envío a %q falló: %v\n", l.name, msg.To, err)
+		return err
+	}
+	l.sent.Add(1)
+	fmt.Printf("📝 [%s] enviado a %s: %d bytes %v\n", l.name, msg.To, len(msg.Body), msg.Encodings)
+	return nil
+}
+
+// Stats retorna los envíos exitosos y fallidos.
+func (l *Logging) Stats() string {
+	return fmt.Sprintf("log %s: %d enviados, %d fallidos", l.name, l.sent.Load(), l.failed.Load())
+}
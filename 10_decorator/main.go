@@ -0,0 +1,124 @@
+/*
+Patrón de Diseño Decorator - Ejemplo en Go
+
+El patrón Decorator es un patrón de diseño estructural que agrega comportamiento a un
+objeto envolviéndolo en otro que cumple la misma interfaz. El cliente no distingue el
+objeto original del decorado, y los decoradores se pueden apilar.
+
+Problema que resuelve:
+- Un Notifier envía mensajes, y a veces hace falta comprimirlos, cifrarlos o registrarlos
+- Con un tipo por combinación (NotifierComprimidoCifradoConLog...) las variantes se multiplican
+- Cada capacidad debe poder agregarse o quitarse sin modificar las demás
+
+Solución:
+- Cada capacidad es un decorador: implementa Notifier y envuelve a otro Notifier
+- Hace su parte sobre el mensaje y se lo pasa al siguiente
+- Las capas se combinan en cualquier orden al construir la pila
+
+Ventajas:
+- Capacidades independientes que se combinan en tiempo de ejecución
+- El Notifier base no cambia (principio abierto/cerrado)
+- Cada decorador puede tener su propio estado (contadores, claves, estadísticas)
+
+En este ejemplo:
+  - Notifier: la interfaz; Inbox es el Notifier base que entrega los mensajes
+  - Compression: comprime el cuerpo con gzip y lleva la cuenta de bytes antes y después
+  - Encryption: cifra el cuerpo con AES-GCM, con un nonce por mensaje tomado de un contador
+  - Logging: muestra cada envío y cuenta los enviados y los fallidos
+  - Decode: el receptor deshace las capas en orden inverso, leyendo Message.Encodings
+*/
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Message es lo que envía un Notifier. Encodings registra, en orden, las
+// transformaciones aplicadas al cuerpo, como el encabezado Content-Encoding de HTTP.
+type Message struct {
+	To        string
+	Body      []byte
+	Encodings []string
+}
+
+// Notifier envía mensajes. Es la interfaz que cumplen tanto el Notifier base como los
+// decoradores.
+type Notifier interface {
+	Send(msg Message) error
+}
+
+// Inbox es el Notifier base: entrega los mensajes guardándolos por destinatario. Es
+// seguro usarlo desde varias goroutines.
+type Inbox struct {
+	mu       sync.Mutex
+	messages map[string][]Message
+}
+
+// NewInbox crea una bandeja vacía.
+func NewInbox() *Inbox {
+	return &Inbox{messages: make(map[string][]Message)}
+}
+
+func (i *Inbox) Send(msg Message) error {
+	if msg.To == "" {
+		return fmt.Errorf("inbox: falta el destinatario")
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.messages[msg.To] = append(i.messages[msg.To], msg)
+	return nil
+}
+
+// Messages retorna los mensajes entregados a to.
+func (i *Inbox) Messages(to string) []Message {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return slices.Clone(i.messages[to])
+}
+
+// notification es el cuerpo de ejemplo: JSON repetitivo, como el de un aviso real.
+func notification() []byte {
+	var items []string
+	for n := range 20 {
+		items = append(items, fmt.Sprintf(`{"sku":"GPU-%03d","status":"available","store":"Bogotá"}`, n))
+	}
+	return []byte(`{"type":"restock","items":[` + strings.Join(items, ",") + `]}`)
+}
+
+// main demuestra el uso del patrón Decorator
+func main() {
+	key := []byte("clave-de-32-bytes-para-AES-256!!")
+	body := notification()
+
+	fmt.Println("📨 Notifier base, sin decoradores:")
+	inbox := NewInbox()
+	var notifier Notifier = inbox
+	if err := notifier.Send(Message{To: "ana@gmail.com", Body: body}); err != nil {
+		fmt.Println("❌", err)
+	}
+	fmt.Printf("📬 Entregado: %d bytes en claro\n", len(inbox.Messages("ana@gmail.com")[0].Body))
+
+	fmt.Println("\n🧅 Logging → Compression → Encryption → Inbox:")
+	encryption, err := NewEncryption(inbox, key)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	compression := NewCompression(encryption)
+	logging := NewLogging(compression, "externo")
+	for _, to := range []string{"ana@gmail.com", "luis@gmail.com", ""} {
+		if err := logging.Send(Message{To: to, Body: body}); err != nil {
+			fmt.Println("❌", err)
+		}
+	}
+	delivered := inbox.Messages("luis@gmail.com")[0]
+	decoded, err := Decode(delivered, key)
+	fmt.Printf("📬 Entregado: %d bytes %v | decodificado: %d bytes, igual al original: %t, err: %v\n",
+		len(delivered.Body), delivered.Encodings, len(decoded), slices.Equal(decoded, body), err)
+	fmt.Printf("📊 %s | %s | %s\n", logging.Stats(), compression.Stats(), encryption.Stats())
+
+	demonstrateStackOrder(key, body)
+}
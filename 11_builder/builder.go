@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRequest indica que la configuración del RequestBuilder no permite armar la
+// petición. Build lo envuelve junto con todos los problemas encontrados.
+var ErrInvalidRequest = errors.New("petición inválida")
+
+// validMethods son los métodos HTTP que acepta el builder.
+var validMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// RequestBuilder arma un *http.Request paso a paso. Los métodos se encadenan y no
+// fallan: los errores se acumulan y Build los reporta todos juntos, así el llamador
+// no tiene que revisar un error en cada paso.
+//
+//	req, err := NewRequestBuilder(http.MethodPost, "https://api.tienda.test").
+//		Path("/orders").Header("X-Request-Id", "abc").JSON(order).
+//		Timeout(5 * time.Second).Build()
+type RequestBuilder struct {
+	method  string
+	baseURL string
+	path    string
+	query   url.Values
+	headers http.Header
+	body    []byte
+	timeout time.Duration
+	errs    []error // Errores de los pasos (por ejemplo, un JSON que no se pudo serializar)
+}
+
+// NewRequestBuilder empieza a armar una petición method a baseURL. Son los dos datos
+// obligatorios, por eso van en el constructor y no en métodos opcionales.
+func NewRequestBuilder(method, baseURL string) *RequestBuilder {
+	return &RequestBuilder{
+		method:  strings.ToUpper(method),
+		baseURL: baseURL,
+		query:   url.Values{},
+		headers: http.Header{},
+	}
+}
+
+// Path define la ruta de la petición, que se agrega a la de baseURL.
+func (b *RequestBuilder) Path(path string) *RequestBuilder {
+	b.path = path
+	return b
+}
+
+// Query agrega un parámetro a la query string; se puede repetir la clave.
+func (b *RequestBuilder) Query(key, value string) *RequestBuilder {
+	b.query.Add(key, value)
+	return b
+}
+
+// Header define un encabezado, reemplazando el valor anterior.
+func (b *RequestBuilder) Header(key, value string) *RequestBuilder {
+	b.headers.Set(key, value)
+	return b
+}
+
+// BearerToken agrega el encabezado Authorization con token.
+func (b *RequestBuilder) BearerToken(token string) *RequestBuilder {
+	return b.Header("Authorization", "Bearer "+token)
+}
+
+// JSON serializa v como cuerpo y define Content-Type. Si v no se puede serializar, el
+// error se reporta en Build.
+func (b *RequestBuilder) JSON(v any) *RequestBuilder {
+	body, err := json.Marshal(v)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("el cuerpo no se puede serializar a JSON: %w", err))
+		return b
+	}
+	b.body = body
+	return b.Header("Content-Type", "application/json")
+}
+
+// Timeout limita cuánto puede tardar la petición, a través de su contexto.
+func (b *RequestBuilder) Timeout(timeout time.Duration) *RequestBuilder {
+	b.timeout = timeout
+	return b
+}
+
+// Build valida la configuración y crea la petición. Reporta todos los problemas juntos
+// en lugar de detenerse en el primero. Además del request retorna la función cancel de
+// su contexto, que hay que llamar cuando termine la petición (si no hay Timeout, no
+// hace nada).
+func (b *RequestBuilder) Build() (*http.Request, context.CancelFunc, error) {
+	errs := slices.Clone(b.errs)
+	if !slices.Contains(validMethods, b.method) {
+		errs = append(errs, fmt.Errorf("método %q no soportado", b.method))
+	}
+	u, err := url.Parse(b.baseURL)
+	switch {
+	case b.baseURL == "":
+		errs = append(errs, errors.New("falta la URL base"))
+	case err != nil || u.Scheme == "" || u.Host == "":
+		errs = append(errs, fmt.Errorf("URL base %q inválida: debe ser absoluta", b.baseURL))
+	case u.Scheme != "http" && u.Scheme != "https":
+		errs = append(errs, fmt.Errorf("esquema %q no soportado", u.Scheme))
+	}
+	if b.body != nil && (b.method == http.MethodGet || b.method == http.MethodHead) {
+		errs = append(errs, fmt.Errorf("una petición %s no lleva cuerpo", b.method))
+	}
+	if b.timeout < 0 {
+		errs = append(errs, fmt.Errorf("timeout negativo: %v", b.timeout))
+	}
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidRequest, errors.Join(errs...))
+	}
+
+	u = u.JoinPath(b.path)
+	u.RawQuery = b.query.Encode()
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if b.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, b.timeout)
+	}
+	var body io.Reader
+	if b.body != nil {
+		body = bytes.NewReader(b.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, b.method, u.String(), body)
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	req.Header = b.headers.Clone() // Build se puede llamar varias veces sin compartir encabezados
+	return req, cancel, nil
+}
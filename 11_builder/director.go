@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Director encapsula las recetas de las peticiones que la aplicación hace siempre
+// igual a la API de la tienda: conoce la URL base, el token y el orden de los pasos. El
+// cliente pide una receta y, si quiere, la ajusta antes de llamar a Build.
+type Director struct {
+	baseURL string
+	token   string
+	timeout time.Duration
+}
+
+// NewDirector crea un director para la API de baseURL autenticada con token.
+func NewDirector(baseURL, token string) Director {
+	return Director{baseURL: baseURL, token: token, timeout: 5 * time.Second}
+}
+
+// base es el paso común a todas las recetas.
+func (d Director) base(method, path string) *RequestBuilder {
+	return NewRequestBuilder(method, d.baseURL).
+		Path(path).
+		Header("Accept", "application/json").
+		Header("User-Agent", "curso-go-patrones/1.0").
+		Timeout(d.timeout)
+}
+
+// ListProducts lista una página de productos de category.
+func (d Director) ListProducts(category string, page int) *RequestBuilder {
+	return d.base(http.MethodGet, "/products").
+		Query("category", category).
+		Query("page", strconv.Itoa(page))
+}
+
+// Order es el cuerpo de CreateOrder.
+type Order struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+// CreateOrder crea un pedido autenticado. idempotencyKey evita que un reintento cree
+// el pedido dos veces.
+func (d Director) CreateOrder(order Order, idempotencyKey string) *RequestBuilder {
+	return d.base(http.MethodPost, "/orders").
+		BearerToken(d.token).
+		Header("Idempotency-Key", idempotencyKey).
+		JSON(order)
+}
+
+// HealthCheck consulta el estado de la API con un timeout corto.
+func (d Director) HealthCheck() *RequestBuilder {
+	return d.base(http.MethodHead, "/health").Timeout(time.Second)
+}
@@ -0,0 +1,138 @@
+/*
+Patrón de Diseño Builder - Ejemplo en Go
+
+El patrón Builder es un patrón de diseño creacional que separa la construcción de un
+objeto complejo de su representación: el objeto se arma paso a paso y solo se crea al
+final, cuando la configuración está completa y es válida.
+
+Problema que resuelve:
+  - Una petición HTTP tiene muchas partes opcionales (ruta, query, encabezados, cuerpo, timeout)
+  - Un constructor con todos los parámetros es difícil de leer y de mantener
+  - Armar el objeto a mano deja estados intermedios inválidos y validaciones repartidas
+
+Solución:
+  - RequestBuilder guarda la configuración y expone un método por paso, encadenables
+  - Build valida todo junto y retorna la petición o todos los errores a la vez
+  - Un Director encapsula las recetas que se repiten (listar productos, crear un pedido)
+
+Ventajas:
+  - Código cliente legible: cada paso dice qué configura
+  - El objeto nunca existe en un estado inválido
+  - Las recetas comunes viven en un solo lugar
+
+En este ejemplo:
+  - RequestBuilder: API fluida para armar un *http.Request, con validación en Build
+  - ErrInvalidRequest: envuelve los problemas de la configuración (errors.Join)
+  - Director: recetas estándar de la API de la tienda, ajustables antes de Build
+  - pkg/factory.ComputerBuilder (05_factory) es otro Builder del curso, combinado con Factory
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"time"
+)
+
+// printRequest muestra la petición tal como saldría por la red.
+func printRequest(req *http.Request) {
+	dump, err := httputil.DumpRequestOut(req, true)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	for line := range strings.Lines(strings.TrimSpace(string(dump))) {
+		fmt.Print("   ", line)
+	}
+	fmt.Println()
+}
+
+// main demuestra el uso del patrón Builder
+func main() {
+	fmt.Println("🧱 Petición armada paso a paso:")
+	req, cancel, err := NewRequestBuilder(http.MethodGet, "https://api.tienda.test/v1").
+		Path("/products/search").
+		Query("q", "rtx 4090").
+		Query("sort", "price").
+		Header("Accept", "application/json").
+		Timeout(3 * time.Second).
+		Build()
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	printRequest(req)
+	cancel()
+
+	fmt.Println("\n🚫 Una configuración con varios problemas se reporta completa:")
+	_, _, err = NewRequestBuilder("FETCH", "api.tienda.test").
+		JSON(map[string]any{"precio": func() {}}).
+		Timeout(-time.Second).
+		Build()
+	fmt.Println("❌", strings.ReplaceAll(err.Error(), "\n", "\n   • "))
+	fmt.Println("🔎 errors.Is(err, ErrInvalidRequest):", errors.Is(err, ErrInvalidRequest))
+
+	demonstrateDirector()
+	demonstrateValidation()
+}
+
+// demonstrateDirector arma peticiones con las recetas del Director, una de ellas
+// ajustada antes de Build.
+func demonstrateDirector() {
+	fmt.Println("\n🎬 Recetas del Director:")
+	director := NewDirector("https://api.tienda.test/v1", "tok_secreto")
+	recipes := []struct {
+		name    string
+		builder *RequestBuilder
+	}{
+		{"Listar productos", director.ListProducts("gpu", 2)},
+		{"Crear pedido", director.CreateOrder(Order{SKU: "GPU-4090", Quantity: 1}, "order-7f3a")},
+		{"Health check con encabezado extra", director.HealthCheck().Header("X-Probe", "k8s")},
+	}
+	for _, recipe := range recipes {
+		req, cancel, err := recipe.builder.Build()
+		if err != nil {
+			fmt.Println("❌", err)
+			continue
+		}
+		deadline, _ := req.Context().Deadline()
+		fmt.Printf("📦 %s (timeout %v):\n", recipe.name, time.Until(deadline).Round(time.Second))
+		printRequest(req)
+		cancel()
+	}
+}
+
+// demonstrateValidation recorre una tabla de configuraciones y comprueba que Build
+// acepte las válidas y rechace las demás.
+func demonstrateValidation() {
+	fmt.Println("\n🛂 Validación en Build:")
+	cases := []struct {
+		name    string
+		builder *RequestBuilder
+		valid   bool
+	}{
+		{"GET completo", NewRequestBuilder(http.MethodGet, "https://api.tienda.test").Path("/products"), true},
+		{"método en minúsculas", NewRequestBuilder("post", "http://localhost:8080").JSON(Order{SKU: "A", Quantity: 1}), true},
+		{"sin URL", NewRequestBuilder(http.MethodGet, ""), false},
+		{"URL relativa", NewRequestBuilder(http.MethodGet, "/products"), false},
+		{"esquema ftp", NewRequestBuilder(http.MethodGet, "ftp://files.tienda.test"), false},
+		{"método desconocido", NewRequestBuilder("FETCH", "https://api.tienda.test"), false},
+		{"GET con cuerpo", NewRequestBuilder(http.MethodGet, "https://api.tienda.test").JSON(Order{}), false},
+		{"cuerpo no serializable", NewRequestBuilder(http.MethodPost, "https://api.tienda.test").JSON(make(chan int)), false},
+		{"timeout negativo", NewRequestBuilder(http.MethodGet, "https://api.tienda.test").Timeout(-1), false},
+	}
+	for _, c := range cases {
+		_, cancel, err := c.builder.Build()
+		if err == nil {
+			cancel()
+		}
+		status := "✅"
+		if (err == nil) != c.valid || (err != nil && !errors.Is(err, ErrInvalidRequest)) {
+			status = "❌"
+		}
+		fmt.Printf("%s %-25s %v\n", status, c.name, strings.ReplaceAll(fmt.Sprint(err), "\n", "; "))
+	}
+}
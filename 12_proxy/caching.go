@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/memoize"
+)
+
+// CachingProxy es un proxy de cache: sirve GetProduct desde pkg/memoize y solo llama
+// al servicio cuando el producto no está cacheado. Como la memoización deduplica los
+// cálculos en curso, muchas consultas simultáneas del mismo producto hacen una sola
+// llamada remota.
+type CachingProxy struct {
+	service CatalogService
+	cache   *memoize.Memory[string, Product]
+}
+
+// NewCachingProxy pone un cache delante de service. Los productos inexistentes se
+// recuerdan solo durante notFoundTTL, por si se crean después.
+func NewCachingProxy(service CatalogService, notFoundTTL time.Duration) *CachingProxy {
+	return &CachingProxy{
+		service: service,
+		cache: memoize.NewMemory(service.GetProduct,
+			memoize.WithLogging(false),
+			memoize.WithNegativeTTL(notFoundTTL),
+		),
+	}
+}
+
+func (p *CachingProxy) GetProduct(id string) (Product, error) {
+	return p.cache.Get(id)
+}
+
+// UpdatePrice delega en el servicio y, si el cambio se hizo, invalida el producto para
+// que la siguiente consulta traiga el precio nuevo.
+func (p *CachingProxy) UpdatePrice(id string, price float64) error {
+	if err := p.service.UpdatePrice(id, price); err != nil {
+		return err
+	}
+	p.cache.Invalidate(id)
+	return nil
+}
+
+// Stats resume el uso del cache.
+func (p *CachingProxy) Stats() string {
+	stats := p.cache.Stats()
+	return fmt.Sprintf("cache: %d aciertos (%d de inexistentes), %d compartidas, %d llamadas, %v ahorrados",
+		stats.Hits, stats.Negative, stats.Shared, stats.Misses, stats.TimeSaved.Round(time.Millisecond))
+}
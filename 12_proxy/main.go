@@ -0,0 +1,151 @@
+/*
+Patrón de Diseño Proxy - Ejemplo en Go
+
+El patrón Proxy es un patrón de diseño estructural que pone un sustituto delante de un
+objeto para controlar el acceso a él. El proxy cumple la misma interfaz que el objeto
+real, así que el cliente no sabe si habla con uno o con el otro.
+
+Problema que resuelve:
+  - El catálogo vive en un servicio remoto: cada consulta tarda y cuesta
+  - No todos los usuarios pueden hacer todo (solo un administrador cambia precios)
+  - Ni el servicio remoto ni sus clientes deberían cambiar para resolverlo
+
+Solución:
+  - Un proxy de cache guarda las respuestas del servicio y las sirve sin consultarlo
+  - Un proxy de protección revisa los permisos del usuario antes de delegar
+  - Los dos cumplen CatalogService, así que se apilan delante del servicio real
+
+Ventajas:
+  - El cliente usa la misma interfaz con o sin proxies
+  - Cache y permisos quedan fuera de la lógica del servicio
+  - Cada proxy se agrega o se quita sin tocar a los demás
+
+En este ejemplo:
+  - CatalogService: la interfaz común; RemoteService es el servicio real (lento)
+  - CachingProxy: cachea GetProduct con pkg/memoize (deduplica consultas concurrentes
+    y cachea por poco tiempo los productos inexistentes) e invalida al cambiar un precio
+  - ProtectionProxy: exige el permiso de cada operación según el rol del usuario
+*/
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/memoize"
+)
+
+// Product es un producto del catálogo remoto.
+type Product struct {
+	ID    string
+	Name  string
+	Price float64
+}
+
+// CatalogService es la interfaz que cumplen el servicio real y sus proxies.
+type CatalogService interface {
+	GetProduct(id string) (Product, error)
+	UpdatePrice(id string, price float64) error
+}
+
+// RemoteService simula el catálogo remoto: cada llamada tarda latency y se cuenta.
+type RemoteService struct {
+	latency time.Duration
+	calls   atomic.Int64
+
+	mu       sync.RWMutex
+	products map[string]Product
+}
+
+// NewRemoteService crea el servicio con los productos indicados.
+func NewRemoteService(latency time.Duration, products ...Product) *RemoteService {
+	s := &RemoteService{latency: latency, products: make(map[string]Product)}
+	for _, product := range products {
+		s.products[product.ID] = product
+	}
+	return s
+}
+
+func (s *RemoteService) GetProduct(id string) (Product, error) {
+	s.calls.Add(1)
+	time.Sleep(s.latency)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	product, ok := s.products[id]
+	if !ok {
+		// memoize.ErrNotFound permite que el proxy de cache recuerde que no existe
+		return Product{}, fmt.Errorf("producto %q: %w", id, memoize.ErrNotFound)
+	}
+	return product, nil
+}
+
+func (s *RemoteService) UpdatePrice(id string, price float64) error {
+	s.calls.Add(1)
+	time.Sleep(s.latency)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	product, ok := s.products[id]
+	if !ok {
+		return fmt.Errorf("producto %q: %w", id, memoize.ErrNotFound)
+	}
+	product.Price = price
+	s.products[id] = product
+	return nil
+}
+
+// Calls retorna cuántas llamadas recibió el servicio.
+func (s *RemoteService) Calls() int64 {
+	return s.calls.Load()
+}
+
+// timed ejecuta fn y muestra cuánto tardó.
+func timed(label string, fn func() (Product, error)) {
+	start := time.Now()
+	product, err := fn()
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Printf("   %-28s ❌ %v (%v)\n", label, err, elapsed)
+		return
+	}
+	fmt.Printf("   %-28s %s $%.2f (%v)\n", label, product.Name, product.Price, elapsed)
+}
+
+// main demuestra el uso del patrón Proxy
+func main() {
+	remote := NewRemoteService(100*time.Millisecond,
+		Product{ID: "gpu-4090", Name: "RTX 4090", Price: 1999},
+		Product{ID: "ps5-pro", Name: "PS5 Pro", Price: 699},
+	)
+	cache := NewCachingProxy(remote, time.Second)
+	var catalog CatalogService = cache // El cliente solo conoce la interfaz
+
+	fmt.Println("🪞 Proxy de cache delante del servicio remoto:")
+	timed("gpu-4090 (remoto)", func() (Product, error) { return catalog.GetProduct("gpu-4090") })
+	timed("gpu-4090 (cache)", func() (Product, error) { return catalog.GetProduct("gpu-4090") })
+	timed("xbox (no existe)", func() (Product, error) { return catalog.GetProduct("xbox") })
+	timed("xbox (no existe, cache)", func() (Product, error) { return catalog.GetProduct("xbox") })
+
+	fmt.Println("✏️ Cambiar el precio invalida el producto en el cache:")
+	if err := catalog.UpdatePrice("gpu-4090", 1799); err != nil {
+		fmt.Println("❌", err)
+	}
+	timed("gpu-4090 (remoto otra vez)", func() (Product, error) { return catalog.GetProduct("gpu-4090") })
+
+	fmt.Println("👥 50 consultas concurrentes del mismo producto:")
+	var wg sync.WaitGroup
+	before := remote.Calls()
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = catalog.GetProduct("ps5-pro")
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("   llamadas al servicio remoto: %d\n", remote.Calls()-before)
+	fmt.Printf("📊 %s | llamadas remotas en total: %d\n", cache.Stats(), remote.Calls())
+
+	demonstrateProtection(remote, cache)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrForbidden indica que el usuario no tiene permiso para la operación.
+var ErrForbidden = errors.New("acceso denegado")
+
+// Role es el rol de un usuario.
+type Role string
+
+const (
+	RoleGuest    Role = "invitado"
+	RoleCustomer Role = "cliente"
+	RoleAdmin    Role = "admin"
+)
+
+// Permission es una operación de CatalogService que se puede autorizar.
+type Permission string
+
+const (
+	PermRead        Permission = "leer"
+	PermUpdatePrice Permission = "cambiar precio"
+)
+
+// rolePermissions son los permisos de cada rol.
+var rolePermissions = map[Role][]Permission{
+	RoleGuest:    {PermRead},
+	RoleCustomer: {PermRead},
+	RoleAdmin:    {PermRead, PermUpdatePrice},
+}
+
+// User es quien usa el catálogo a través del ProtectionProxy.
+type User struct {
+	Name string
+	Role Role
+}
+
+// PermissionError describe qué operación se le negó a quién.
+// errors.Is(err, ErrForbidden) es verdadero para cualquier *PermissionError.
+type PermissionError struct {
+	User       User
+	Permission Permission
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("%s: %s (%s) no puede %s", ErrForbidden, e.User.Name, e.User.Role, e.Permission)
+}
+
+func (e *PermissionError) Unwrap() error {
+	return ErrForbidden
+}
+
+// ProtectionProxy es un proxy de protección: antes de delegar en el servicio comprueba
+// que el usuario tenga el permiso de la operación. Cada usuario tiene su propio proxy.
+type ProtectionProxy struct {
+	service CatalogService
+	user    User
+}
+
+// NewProtectionProxy da acceso a service en nombre de user.
+func NewProtectionProxy(service CatalogService, user User) *ProtectionProxy {
+	return &ProtectionProxy{service: service, user: user}
+}
+
+// authorize retorna un *PermissionError si el usuario no tiene permission.
+func (p *ProtectionProxy) authorize(permission Permission) error {
+	if !slices.Contains(rolePermissions[p.user.Role], permission) {
+		return &PermissionError{User: p.user, Permission: permission}
+	}
+	return nil
+}
+
+func (p *ProtectionProxy) GetProduct(id string) (Product, error) {
+	if err := p.authorize(PermRead); err != nil {
+		return Product{}, err
+	}
+	return p.service.GetProduct(id)
+}
+
+func (p *ProtectionProxy) UpdatePrice(id string, price float64) error {
+	if err := p.authorize(PermUpdatePrice); err != nil {
+		return err
+	}
+	return p.service.UpdatePrice(id, price)
+}
+
+// demonstrateProtection apila el proxy de protección sobre el de cache y comprueba qué
+// puede hacer cada rol. Las operaciones negadas no llegan al servicio remoto.
+func demonstrateProtection(remote *RemoteService, cache *CachingProxy) {
+	fmt.Println("\n🛡️ Proxy de protección sobre el proxy de cache:")
+	users := []User{
+		{Name: "Ana", Role: RoleAdmin},
+		{Name: "Luis", Role: RoleCustomer},
+		{Name: "Mallory", Role: "desconocido"},
+	}
+	for _, user := range users {
+		var catalog CatalogService = NewProtectionProxy(cache, user)
+		before := remote.Calls()
+
+		_, readErr := catalog.GetProduct("gpu-4090")
+		updateErr := catalog.UpdatePrice("ps5-pro", 649)
+		for _, check := range []struct {
+			permission Permission
+			err        error
+		}{{PermRead, readErr}, {PermUpdatePrice, updateErr}} {
+			allowed := slices.Contains(rolePermissions[user.Role], check.permission)
+			status := "✅"
+			if allowed != (check.err == nil) || (!allowed && !errors.Is(check.err, ErrForbidden)) {
+				status = "❌"
+			}
+			result := "permitido"
+			if check.err != nil {
+				result = check.err.Error()
+			}
+			fmt.Printf("%s %-8s %-15s %s\n", status, user.Name, check.permission, result)
+		}
+		fmt.Printf("   llamadas remotas de %s: %d\n", user.Name, remote.Calls()-before)
+	}
+
+	var permErr *PermissionError
+	if errors.As(NewProtectionProxy(cache, users[1]).UpdatePrice("gpu-4090", 1), &permErr) {
+		fmt.Printf("🔎 Rol: %s, permiso negado: %s\n", permErr.User.Role, permErr.Permission)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/eventbus"
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/rediscache"
+)
+
+// Tópicos de los avisos de stock: "stock.<sku>.<tipo>".
+const (
+	stockLow      = "low"      // Quedan lowStockLevel unidades o menos
+	stockOut      = "out"      // Se agotó
+	stockRestored = "restored" // Volvió a tener unidades
+)
+
+// lowStockLevel es el stock desde el que se avisa que quedan pocas unidades.
+const lowStockLevel = 2
+
+// stockCacheTTL es cuánto vive en el cache una consulta al inventario.
+const stockCacheTTL = 30 * time.Second
+
+// Order es el resultado de una compra.
+type Order struct {
+	SKU       string
+	Name      string
+	Quantity  int
+	Total     float64
+	Receipt   string
+	StockLeft int
+}
+
+// ProductInfo es lo que la fachada informa de un producto.
+type ProductInfo struct {
+	SKU   string
+	Name  string
+	Price float64
+	Stock int
+}
+
+// StoreFacade es la fachada de la tienda: expone unas pocas operaciones simples
+// (agregar un producto, consultarlo, comprarlo y seguir su stock) y por dentro coordina
+// la factory de productos, el inventario, su cache, los pagos y los avisos de stock.
+type StoreFacade struct {
+	warehouse *Warehouse
+	cache     *rediscache.SimpleRedisCache
+	bus       *eventbus.Bus[StockEvent]
+}
+
+// NewStoreFacade crea la tienda con sus subsistemas.
+func NewStoreFacade() *StoreFacade {
+	cache := rediscache.NewSimpleRedisCache()
+	cache.SetLogging(false)
+	return &StoreFacade{
+		warehouse: NewWarehouse(),
+		cache:     cache,
+		bus:       eventbus.NewBus[StockEvent](),
+	}
+}
+
+// AddProduct crea un producto del tipo productType con la factory, lo guarda en el
+// inventario y retorna su SKU.
+func (s *StoreFacade) AddProduct(productType, name string, stock int, price float64) (string, error) {
+	create, err := factory.GetProductFactory(productType)
+	if err != nil {
+		return "", err
+	}
+	product, err := create(name, factory.WithStock(stock), factory.WithPrice(price))
+	if err != nil {
+		return "", err
+	}
+	s.warehouse.Add(product)
+	fmt.Printf("   🏭 factory: %s creado con SKU %s\n", name, product.SKU())
+	return product.SKU(), nil
+}
+
+// Product consulta un producto. Las consultas se sirven desde el cache mientras el
+// stock no cambie.
+func (s *StoreFacade) Product(sku string) (ProductInfo, error) {
+	if cached, ok := s.cache.Get(cacheKey(sku)); ok {
+		fmt.Printf("   ⚡ cache: %s\n", sku)
+		return cached.(ProductInfo), nil
+	}
+	name, price, stock, err := s.warehouse.Lookup(sku)
+	if err != nil {
+		return ProductInfo{}, err
+	}
+	fmt.Printf("   📦 inventario: %s\n", sku)
+	info := ProductInfo{SKU: sku, Name: name, Price: price, Stock: stock}
+	s.cache.Set(cacheKey(sku), info, stockCacheTTL)
+	return info, nil
+}
+
+// Checkout compra qty unidades de sku pagando con payment. Reserva las unidades antes
+// de cobrar; si el pago falla, las devuelve. Después de la compra invalida el cache y
+// publica los avisos de stock que correspondan.
+func (s *StoreFacade) Checkout(sku string, qty int, payment PaymentMethod) (Order, error) {
+	if qty <= 0 {
+		return Order{}, ErrInvalidQuantity
+	}
+	info, err := s.Product(sku)
+	if err != nil {
+		return Order{}, err
+	}
+	left, err := s.warehouse.Reserve(sku, qty)
+	if err != nil {
+		return Order{}, err
+	}
+	fmt.Printf("   📦 inventario: %d reservadas, quedan %d\n", qty, left)
+
+	total := info.Price * float64(qty)
+	receipt, err := payment.Pay(total)
+	if err != nil {
+		restored := s.warehouse.Release(sku, qty)
+		fmt.Printf("   ↩️ inventario: %d devueltas, quedan %d\n", qty, restored)
+		return Order{}, fmt.Errorf("compra de %s: %w", info.Name, err)
+	}
+	fmt.Printf("   💳 pagos: $%.2f cobrados (%s)\n", total, receipt)
+
+	s.stockChanged(info, left+qty, left)
+	return Order{SKU: sku, Name: info.Name, Quantity: qty, Total: total, Receipt: receipt, StockLeft: left}, nil
+}
+
+// Restock agrega qty unidades de sku al inventario.
+func (s *StoreFacade) Restock(sku string, qty int) error {
+	if qty <= 0 {
+		return ErrInvalidQuantity
+	}
+	info, err := s.Product(sku)
+	if err != nil {
+		return err
+	}
+	stock := s.warehouse.Release(sku, qty)
+	s.stockChanged(info, stock-qty, stock)
+	return nil
+}
+
+// WatchStock se suscribe a los avisos de stock. pattern es un tópico del bus, por
+// ejemplo "stock.*.out" para todos los productos agotados o "stock.<sku>.#" para todo
+// lo de un producto.
+func (s *StoreFacade) WatchStock(pattern string) (<-chan StockEvent, func()) {
+	return s.bus.Subscribe(pattern)
+}
+
+// stockChanged invalida el cache de info y publica el aviso que corresponde al cambio
+// de stock de before a after.
+func (s *StoreFacade) stockChanged(info ProductInfo, before, after int) {
+	s.cache.Delete(cacheKey(info.SKU))
+
+	var kind string
+	switch {
+	case after == 0:
+		kind = stockOut
+	case before == 0:
+		kind = stockRestored
+	case after <= lowStockLevel && before > lowStockLevel:
+		kind = stockLow
+	default:
+		return
+	}
+	topic := "stock." + info.SKU + "." + kind
+	n := s.bus.Publish(topic, StockEvent{SKU: info.SKU, Name: info.Name, Stock: after})
+	fmt.Printf("   🔔 avisos: %s a %d suscriptores\n", topic, n)
+}
+
+func cacheKey(sku string) string {
+	return "product:" + sku
+}
+
+// checkoutError resume el error de una compra para las demostraciones.
+func checkoutError(err error) string {
+	switch {
+	case errors.Is(err, ErrOutOfStock):
+		return "sin stock"
+	case errors.Is(err, ErrPaymentDeclined):
+		return "pago rechazado"
+	case errors.Is(err, ErrUnknownProduct):
+		return "producto desconocido"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Patrón de Diseño Facade - Ejemplo en Go
+
+El patrón Facade es un patrón de diseño estructural que ofrece una interfaz simple a
+un conjunto de subsistemas complejos. La fachada no agrega funcionalidad nueva:
+coordina a los subsistemas en el orden correcto para que el cliente no tenga que hacerlo.
+
+Problema que resuelve:
+  - Vender un producto toca varios subsistemas: factory, inventario, cache, pagos y avisos
+  - Cada cliente que venda tendría que conocerlos todos y coordinarlos (reservar antes de
+    cobrar, devolver si el pago falla, invalidar el cache, avisar del stock...)
+  - Un cambio en cualquier subsistema obligaría a cambiar a todos los clientes
+
+Solución:
+  - StoreFacade expone unas pocas operaciones: AddProduct, Product, Checkout, Restock y WatchStock
+  - Por dentro orquesta los subsistemas; el cliente solo ve la fachada
+
+Ventajas:
+  - Los clientes son simples y no dependen de los subsistemas
+  - Las reglas de coordinación viven en un solo lugar
+  - Los subsistemas siguen disponibles para quien necesite usarlos directamente
+
+En este ejemplo, los subsistemas reutilizan los de las otras lecciones:
+  - pkg/factory (05_factory): crea los productos
+  - Warehouse + pkg/rediscache (04_cache_redis): inventario lento con cache delante
+  - PaymentMethod + CardPayment: un datáfono externo adaptado, como en 07_adapter
+  - pkg/eventbus (08_observer): avisos de stock por tópicos
+*/
+package main
+
+import "fmt"
+
+// main demuestra el uso del patrón Facade
+func main() {
+	store := NewStoreFacade()
+
+	fmt.Println("🏬 Agregando productos:")
+	gpuRig, err := store.AddProduct("desktop", "Gaming Pro", 3, 1499)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	laptop, err := store.AddProduct("laptop", "Ultrabook 14", 1, 1299)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	if _, err := store.AddProduct("tablet", "Tab S9", 5, 799); err != nil {
+		fmt.Println(err)
+	}
+
+	// Un cliente que sigue los avisos de stock de todos los productos
+	events, cancel := store.WatchStock("stock.#")
+	defer cancel()
+
+	fmt.Println("\n🔍 Consultas (la segunda sale del cache):")
+	for range 2 {
+		if info, err := store.Product(gpuRig); err == nil {
+			fmt.Printf("   %s: $%.2f, %d unidades\n", info.Name, info.Price, info.Stock)
+		}
+	}
+
+	card := CardPayment{Card: "4242424242424242"}
+	purchases := []struct {
+		sku     string
+		qty     int
+		payment PaymentMethod
+	}{
+		{gpuRig, 1, card},
+		{gpuRig, 2, card}, // Supera el cupo del datáfono: se devuelven las unidades
+		{gpuRig, 1, CardPayment{Card: "1234"}},
+		{laptop, 1, card},
+		{laptop, 1, card},
+		{"NO-EXISTE", 1, card},
+	}
+	fmt.Println("\n🛒 Compras:")
+	for _, p := range purchases {
+		fmt.Printf("➡️ %d x %s\n", p.qty, p.sku)
+		order, err := store.Checkout(p.sku, p.qty, p.payment)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", checkoutError(err), err)
+			continue
+		}
+		fmt.Printf("✅ Pedido: %d x %s por $%.2f, quedan %d\n", order.Quantity, order.Name, order.Total, order.StockLeft)
+		printStockEvents(events)
+	}
+
+	fmt.Println("\n📦 Reposición:")
+	if err := store.Restock(laptop, 4); err != nil {
+		fmt.Println("❌", err)
+	}
+	printStockEvents(events)
+	if info, err := store.Product(laptop); err == nil {
+		fmt.Printf("   %s: %d unidades\n", info.Name, info.Stock)
+	}
+}
+
+// printStockEvents muestra los avisos de stock que ya llegaron, sin esperar más.
+func printStockEvents(events <-chan StockEvent) {
+	for {
+		select {
+		case event := <-events:
+			fmt.Printf("   📣 Aviso: '%s' (%s) tiene %d unidades\n", event.Name, event.SKU, event.Stock)
+		default:
+			return
+		}
+	}
+}
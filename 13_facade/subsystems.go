@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/factory"
+)
+
+// Los subsistemas de este archivo son los que StoreFacade coordina. Cada uno tiene su
+// propia interfaz y sus propias reglas; el cliente de la fachada no los conoce.
+
+// Errores de los subsistemas que la fachada deja pasar al cliente.
+var (
+	ErrUnknownProduct  = errors.New("producto desconocido")
+	ErrOutOfStock      = errors.New("no hay unidades suficientes")
+	ErrPaymentDeclined = errors.New("pago rechazado")
+	ErrInvalidQuantity = errors.New("la cantidad debe ser positiva")
+)
+
+const (
+	warehouseLatency = 30 * time.Millisecond // Lo que tarda cada consulta al inventario
+	cardLimitCents   = 2_000_00              // Cupo por cobro del datáfono, en centavos
+)
+
+// Warehouse es el inventario: la fuente de verdad del stock. Cada consulta tarda, como
+// una base de datos remota, por eso la fachada la pone detrás de un cache.
+type Warehouse struct {
+	mu       sync.Mutex
+	products map[string]factory.IProduct
+}
+
+// NewWarehouse crea un inventario vacío.
+func NewWarehouse() *Warehouse {
+	return &Warehouse{products: make(map[string]factory.IProduct)}
+}
+
+// Add guarda un producto nuevo.
+func (w *Warehouse) Add(product factory.IProduct) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.products[product.SKU()] = product
+}
+
+// Lookup retorna el nombre, precio y stock de sku.
+func (w *Warehouse) Lookup(sku string) (name string, price float64, stock int, err error) {
+	time.Sleep(warehouseLatency)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	product, ok := w.products[sku]
+	if !ok {
+		return "", 0, 0, fmt.Errorf("%w: %s", ErrUnknownProduct, sku)
+	}
+	return product.Name(), product.Price(), product.Stock(), nil
+}
+
+// Reserve descuenta qty unidades de sku y retorna el stock que queda. La comprobación y
+// el descuento ocurren juntos, así dos compras simultáneas no venden la misma unidad.
+func (w *Warehouse) Reserve(sku string, qty int) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	product, ok := w.products[sku]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownProduct, sku)
+	}
+	if product.Stock() < qty {
+		return product.Stock(), fmt.Errorf("%w: %s tiene %d, se pidieron %d", ErrOutOfStock, product.Name(), product.Stock(), qty)
+	}
+	product.SetStock(product.Stock() - qty)
+	return product.Stock(), nil
+}
+
+// Release devuelve qty unidades de sku al inventario y retorna el stock resultante.
+func (w *Warehouse) Release(sku string, qty int) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	product := w.products[sku]
+	product.SetStock(product.Stock() + qty)
+	return product.Stock()
+}
+
+// CardTerminal es el datáfono de un proveedor externo: cobra en centavos y reporta el
+// resultado con un bool, una interfaz que el resto de la tienda no quiere conocer.
+type CardTerminal struct{}
+
+func (CardTerminal) Charge(card string, cents int64) (authCode string, ok bool) {
+	if cents > cardLimitCents || len(card) != 16 {
+		return "", false
+	}
+	return fmt.Sprintf("AUTH-%s-%d", card[12:], cents), true
+}
+
+// PaymentMethod es la interfaz de pagos que usa la fachada, la misma idea que IPayment
+// en 07_adapter.
+type PaymentMethod interface {
+	Pay(amount float64) (receipt string, err error)
+}
+
+// CardPayment adapta CardTerminal a PaymentMethod (patrón Adapter).
+type CardPayment struct {
+	Terminal CardTerminal
+	Card     string
+}
+
+func (c CardPayment) Pay(amount float64) (string, error) {
+	cents := int64(math.Round(amount * 100))
+	authCode, ok := c.Terminal.Charge(c.Card, cents)
+	if !ok {
+		return "", fmt.Errorf("%w: tarjeta ****%s por $%.2f", ErrPaymentDeclined, c.Card[max(len(c.Card)-4, 0):], amount)
+	}
+	return authCode, nil
+}
+
+// StockEvent es lo que publica la fachada en el bus cuando cambia el stock.
+type StockEvent struct {
+	SKU   string
+	Name  string
+	Stock int
+}
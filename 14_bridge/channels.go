@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoAddress indica que el destinatario no tiene dirección para un canal (no tiene
+// correo, teléfono o dispositivo registrado).
+var ErrNoAddress = errors.New("el destinatario no tiene dirección para este canal")
+
+// Recipient es a quién se notifica, con su dirección en cada canal.
+type Recipient struct {
+	Name   string
+	Email  string
+	Phone  string
+	Device string // Token del dispositivo para las notificaciones push
+}
+
+// Message es lo que la abstracción le pide entregar al canal: el canal decide cómo
+// representarlo dentro de sus propios límites.
+type Message struct {
+	Subject string
+	Body    string
+	Urgent  bool
+}
+
+// Channel es la implementación del puente: cómo se entrega un mensaje. No sabe qué
+// tipo de notificación está enviando.
+type Channel interface {
+	Name() string
+	Deliver(to Recipient, msg Message) error
+}
+
+// EmailChannel entrega por correo: sin límite de largo, con asunto.
+type EmailChannel struct{}
+
+func (EmailChannel) Name() string {
+	return "email"
+}
+
+func (EmailChannel) Deliver(to Recipient, msg Message) error {
+	if to.Email == "" {
+		return fmt.Errorf("email a %s: %w", to.Name, ErrNoAddress)
+	}
+	priority := ""
+	if msg.Urgent {
+		priority = " (prioridad alta)"
+	}
+	fmt.Printf("   📧 Para: %s%s | Asunto: %s\n", to.Email, priority, msg.Subject)
+	for line := range strings.Lines(msg.Body) {
+		fmt.Printf("      %s\n", strings.TrimSuffix(line, "\n"))
+	}
+	return nil
+}
+
+// smsLimit es el largo máximo de un SMS.
+const smsLimit = 160
+
+// SMSChannel entrega por SMS: un solo texto de hasta smsLimit caracteres, sin asunto.
+type SMSChannel struct{}
+
+func (SMSChannel) Name() string {
+	return "sms"
+}
+
+func (SMSChannel) Deliver(to Recipient, msg Message) error {
+	if to.Phone == "" {
+		return fmt.Errorf("sms a %s: %w", to.Name, ErrNoAddress)
+	}
+	text := msg.Subject + ": " + strings.Join(strings.Fields(msg.Body), " ")
+	fmt.Printf("   📱 SMS a %s: %s\n", to.Phone, truncate(text, smsLimit))
+	return nil
+}
+
+// Límites de una notificación push.
+const (
+	pushTitleLimit = 40
+	pushBodyLimit  = 80
+)
+
+// PushChannel entrega una notificación push: título y cuerpo cortos. Las urgentes
+// suenan aunque el dispositivo esté en silencio.
+type PushChannel struct{}
+
+func (PushChannel) Name() string {
+	return "push"
+}
+
+func (PushChannel) Deliver(to Recipient, msg Message) error {
+	if to.Device == "" {
+		return fmt.Errorf("push a %s: %w", to.Name, ErrNoAddress)
+	}
+	sound := "🔕"
+	if msg.Urgent {
+		sound = "🔔"
+	}
+	body := strings.Join(strings.Fields(msg.Body), " ")
+	fmt.Printf("   📲 Push %s a %s: [%s] %s\n", sound, to.Device, truncate(msg.Subject, pushTitleLimit), truncate(body, pushBodyLimit))
+	return nil
+}
+
+// truncate recorta s a limit caracteres (runas), terminando en "…" si lo recortó.
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,100 @@
+/*
+Patrón de Diseño Bridge - Ejemplo en Go
+
+El patrón Bridge es un patrón de diseño estructural que separa una abstracción de su
+implementación para que las dos puedan variar de forma independiente. La abstracción
+guarda una referencia a la implementación (el "puente") y le delega el trabajo.
+
+Problema que resuelve:
+  - Hay varios tipos de notificación (alerta, reporte, recordatorio)
+  - Y varios canales de entrega (email, SMS, push), cada uno con sus límites
+  - Con un tipo por combinación (AlertaPorSMS, ReportePorEmail...) son 3 × 3 = 9 tipos,
+    y cada notificación o canal nuevo multiplica la cuenta
+
+Solución:
+  - Abstracción: Notification (Alert, Report, Reminder) decide QUÉ se dice
+  - Implementación: Channel (EmailChannel, SMSChannel, PushChannel) decide CÓMO se entrega
+  - Cada notificación recibe un Channel: cualquier combinación funciona sin tipos nuevos
+
+Ventajas:
+  - Las dos jerarquías crecen por separado: 3 + 3 tipos en lugar de 3 × 3
+  - Un canal nuevo sirve de inmediato para todas las notificaciones (y al revés)
+  - El canal se elige en tiempo de ejecución, por ejemplo según las preferencias del cliente
+
+Complementa a 08_observer: allí EmailClient, PushClient y SMSClient mezclan el qué (un
+artículo disponible) con el cómo (el canal); aquí están separados.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// main demuestra el uso del patrón Bridge
+func main() {
+	ana := Recipient{Name: "Ana", Email: "ana@gmail.com", Phone: "+57 300 111 2222", Device: "pixel-8"}
+	channels := []Channel{EmailChannel{}, SMSChannel{}, PushChannel{}}
+	now := time.Date(2025, 9, 1, 9, 0, 0, 0, time.Local)
+
+	// Cada tipo de notificación se construye a partir de un canal cualquiera
+	kinds := []struct {
+		name  string
+		build func(Channel) Notification
+	}{
+		{"Alerta", func(c Channel) Notification {
+			return Alert{Channel: c, Severity: SeverityCritical, Text: "El servidor de pagos no responde desde las 08:55. Los cobros con tarjeta están fallando."}
+		}},
+		{"Reporte", func(c Channel) Notification {
+			return Report{Channel: c, Title: "Ventas de agosto", Metrics: []Metric{
+				{"Pedidos", "1.284"}, {"Ingresos", "$412.930"}, {"Ticket promedio", "$321,59"}, {"Devoluciones", "2,1%"},
+			}}
+		}},
+		{"Recordatorio", func(c Channel) Notification {
+			return Reminder{Channel: c, Event: "la revisión de inventario", At: now.Add(45 * time.Minute), now: func() time.Time { return now }}
+		}},
+	}
+
+	fmt.Println("🌉 Cada notificación por cada canal (3 + 3 tipos, 9 combinaciones):")
+	for _, kind := range kinds {
+		for _, channel := range channels {
+			fmt.Printf("➡️ %s por %s\n", kind.name, channel.Name())
+			if err := kind.build(channel).Send(ana); err != nil {
+				fmt.Println("❌", err)
+			}
+		}
+	}
+
+	demonstratePreferences(kinds[0].build)
+}
+
+// demonstratePreferences elige el canal de cada cliente en tiempo de ejecución, según
+// sus preferencias, y recurre al siguiente si no tiene dirección para uno.
+func demonstratePreferences(alert func(Channel) Notification) {
+	fmt.Println("\n🎛️ El canal se elige en tiempo de ejecución, según cada cliente:")
+	byName := map[string]Channel{"email": EmailChannel{}, "sms": SMSChannel{}, "push": PushChannel{}}
+	customers := []struct {
+		recipient   Recipient
+		preferences []string
+	}{
+		{Recipient{Name: "Luis", Phone: "+57 310 333 4444", Email: "luis@gmail.com"}, []string{"push", "sms"}},
+		{Recipient{Name: "Marta", Device: "iphone-15"}, []string{"push"}},
+		{Recipient{Name: "Pedro"}, []string{"email", "sms"}},
+	}
+	for _, customer := range customers {
+		var errs []error
+		for _, preference := range customer.preferences {
+			err := alert(byName[preference]).Send(customer.recipient)
+			if err == nil {
+				errs = nil
+				break
+			}
+			errs = append(errs, err)
+		}
+		if err := errors.Join(errs...); err != nil {
+			fmt.Printf("❌ %s no recibió la alerta: %s\n", customer.recipient.Name, strings.ReplaceAll(err.Error(), "\n", "; "))
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Notification es la abstracción del puente: qué se notifica. Cada notificación tiene
+// un Channel y le delega la entrega, así que cualquier notificación sirve con
+// cualquier canal.
+type Notification interface {
+	Send(to Recipient) error
+}
+
+// Severity es la gravedad de una alerta.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "advertencia"
+	case SeverityCritical:
+		return "crítica"
+	default:
+		return "info"
+	}
+}
+
+// Alert avisa de algo que pasó; las críticas son urgentes.
+type Alert struct {
+	Channel  Channel
+	Severity Severity
+	Text     string
+}
+
+func (a Alert) Send(to Recipient) error {
+	return a.Channel.Deliver(to, Message{
+		Subject: fmt.Sprintf("Alerta %s", a.Severity),
+		Body:    a.Text,
+		Urgent:  a.Severity == SeverityCritical,
+	})
+}
+
+// Report resume métricas de un período, una por línea.
+type Report struct {
+	Channel Channel
+	Title   string
+	Metrics []Metric
+}
+
+// Metric es una línea de un Report.
+type Metric struct {
+	Name  string
+	Value string
+}
+
+func (r Report) Send(to Recipient) error {
+	lines := make([]string, len(r.Metrics))
+	for n, metric := range r.Metrics {
+		lines[n] = fmt.Sprintf("%s: %s;", metric.Name, metric.Value)
+	}
+	return r.Channel.Deliver(to, Message{Subject: r.Title, Body: strings.Join(lines, "\n")})
+}
+
+// Reminder recuerda algo que va a pasar; es urgente si falta menos de una hora.
+type Reminder struct {
+	Channel Channel
+	Event   string
+	At      time.Time
+	now     func() time.Time // Reloj; nil = time.Now
+}
+
+func (r Reminder) Send(to Recipient) error {
+	now := time.Now
+	if r.now != nil {
+		now = r.now
+	}
+	left := r.At.Sub(now()).Round(time.Minute)
+	return r.Channel.Deliver(to, Message{
+		Subject: "Recordatorio: " + r.Event,
+		Body:    fmt.Sprintf("Hola %s, %s es a las %s (en %v).", to.Name, r.Event, r.At.Format("15:04"), left),
+		Urgent:  left < time.Hour,
+	})
+}
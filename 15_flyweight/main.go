@@ -0,0 +1,118 @@
+/*
+Patrón de Diseño Flyweight - Ejemplo en Go
+
+El patrón Flyweight es un patrón de diseño estructural que reduce el uso de memoria
+compartiendo entre muchos objetos la parte de su estado que es igual para todos.
+
+Problema que resuelve:
+  - Un bosque tiene miles de árboles, y cada uno necesita la textura de su especie
+  - Si cada árbol guarda su propia copia, la memoria crece con la cantidad de árboles
+  - Pero solo hay unas pocas especies: casi toda esa memoria está repetida
+
+Solución:
+  - Separar el estado intrínseco (especie, color, textura), igual para muchos árboles,
+    del extrínseco (posición), propio de cada uno
+  - El intrínseco vive en un objeto inmutable y compartido: el flyweight (TreeType)
+  - Una factory (TreeFactory) entrega siempre el mismo flyweight para la misma especie
+
+Ventajas:
+  - La memoria depende de la cantidad de especies, no de la de árboles
+  - Crear un árbol es barato: la textura se carga una sola vez por especie
+
+En este ejemplo:
+  - TreeType y TreeFactory: el flyweight y su factory con cache
+  - Tree: un árbol con su posición y un puntero al TreeType compartido
+  - heavyTree: el mismo árbol sin Flyweight, para comparar la memoria con runtime.MemStats
+  - pkg/factory (05_factory) aplica la misma idea a las unidades de un modelo de computadora
+*/
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+)
+
+// forestSize es la cantidad de árboles de la demostración.
+const forestSize = 10_000
+
+// species son las especies del bosque: pocas, comparadas con los árboles.
+var species = []struct{ name, color string }{
+	{"Roble", "🟫"}, {"Pino", "🟩"}, {"Abedul", "⬜"}, {"Arce", "🟥"}, {"Sauce", "🟨"},
+}
+
+// heapAlloc retorna la memoria ocupada por objetos vivos, después de recolectar la basura.
+func heapAlloc() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+// measure retorna cuánta memoria sigue ocupando lo que construye build.
+func measure(build func() any) uint64 {
+	before := heapAlloc()
+	result := build()
+	after := heapAlloc()
+	runtime.KeepAlive(result)
+	if after < before {
+		return 0
+	}
+	return after - before
+}
+
+// main demuestra el uso del patrón Flyweight
+func main() {
+	rng := rand.New(rand.NewPCG(1, 2)) // Semilla fija: el mismo bosque en cada ejecución
+	positions := make([][2]int, forestSize)
+	kinds := make([]int, forestSize)
+	for n := range forestSize {
+		positions[n] = [2]int{rng.IntN(1000), rng.IntN(1000)}
+		kinds[n] = rng.IntN(len(species))
+	}
+
+	factory := NewTreeFactory()
+	var forest []Tree
+	shared := measure(func() any {
+		forest = make([]Tree, forestSize)
+		for n := range forestSize {
+			s := species[kinds[n]]
+			forest[n] = Tree{X: positions[n][0], Y: positions[n][1], Type: factory.TreeType(s.name, s.color)}
+		}
+		return forest
+	})
+
+	var heavyForest []heavyTree
+	unshared := measure(func() any {
+		heavyForest = make([]heavyTree, forestSize)
+		for n := range forestSize {
+			s := species[kinds[n]]
+			heavyForest[n] = heavyTree{X: positions[n][0], Y: positions[n][1], Type: *newTreeType(s.name, s.color)}
+		}
+		return heavyForest
+	})
+
+	fmt.Printf("🌲 Bosque de %d árboles de %d especies:\n", forestSize, len(species))
+	for _, tree := range forest[:3] {
+		fmt.Println("   " + tree.Draw())
+	}
+	fmt.Printf("   Los mismos árboles sin Flyweight: %s\n", heavyForest[0].Draw())
+
+	fmt.Println("\n📊 Memoria (runtime.MemStats.HeapAlloc):")
+	fmt.Printf("   Con Flyweight: %7.2f MB (%d texturas cargadas)\n", mb(shared), factory.Loaded())
+	fmt.Printf("   Sin Flyweight: %7.2f MB (%d texturas cargadas)\n", mb(unshared), forestSize)
+	if shared > 0 {
+		fmt.Printf("   Ahorro: %.0fx menos memoria\n", float64(unshared)/float64(shared))
+	}
+
+	distinct := make(map[*TreeType]bool)
+	for _, tree := range forest {
+		distinct[tree.Type] = true
+	}
+	fmt.Printf("\n🔗 Los %d árboles apuntan a %d *TreeType distintos (%d especies)\n", forestSize, len(distinct), len(species))
+}
+
+// mb convierte bytes a megabytes.
+func mb(bytes uint64) float64 {
+	return float64(bytes) / (1 << 20)
+}
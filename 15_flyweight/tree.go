@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// textureSize es el tamaño de la textura de cada tipo de árbol: el dato pesado que
+// conviene compartir.
+const textureSize = 4 << 10 // 4KB
+
+// TreeType es el estado intrínseco de un árbol (el flyweight): lo que es igual para
+// todos los árboles de la misma especie. Es inmutable y se comparte, así que no se
+// debe modificar.
+type TreeType struct {
+	Name    string
+	Color   string
+	Texture []byte
+}
+
+// newTreeType carga la textura de una especie. Es lo costoso: la factory lo hace una
+// sola vez por especie.
+func newTreeType(name, color string) *TreeType {
+	texture := make([]byte, textureSize)
+	for n := range texture {
+		texture[n] = byte(len(name) + n) // Contenido simulado
+	}
+	return &TreeType{Name: name, Color: color, Texture: texture}
+}
+
+// Draw dibuja un árbol de esta especie en (x, y): el estado extrínseco lo pone quien llama.
+func (t *TreeType) Draw(x, y int) string {
+	return fmt.Sprintf("%s %s en (%d, %d)", t.Color, t.Name, x, y)
+}
+
+// TreeFactory entrega los TreeType compartidos: la primera vez que se pide una especie
+// la crea y la guarda, y las siguientes retorna la misma. Es segura para usarla desde
+// varias goroutines.
+type TreeFactory struct {
+	mu    sync.Mutex
+	types map[string]*TreeType
+}
+
+// NewTreeFactory crea una factory sin especies cargadas.
+func NewTreeFactory() *TreeFactory {
+	return &TreeFactory{types: make(map[string]*TreeType)}
+}
+
+// TreeType retorna la especie name de color color, creándola si es la primera vez.
+func (f *TreeFactory) TreeType(name, color string) *TreeType {
+	key := name + "|" + color
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if t, ok := f.types[key]; ok {
+		return t
+	}
+	t := newTreeType(name, color)
+	f.types[key] = t
+	return t
+}
+
+// Loaded retorna cuántas especies se crearon.
+func (f *TreeFactory) Loaded() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.types)
+}
+
+// Tree es un árbol del bosque: solo guarda su estado extrínseco (la posición) y un
+// puntero a la especie compartida.
+type Tree struct {
+	X, Y int
+	Type *TreeType
+}
+
+func (t Tree) Draw() string {
+	return t.Type.Draw(t.X, t.Y)
+}
+
+// heavyTree es el árbol sin Flyweight: cada uno carga su propia copia de la especie.
+type heavyTree struct {
+	X, Y int
+	Type TreeType
+}
+
+func (t heavyTree) Draw() string {
+	return t.Type.Draw(t.X, t.Y)
+}
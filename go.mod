@@ -0,0 +1,3 @@
+module github.com/afperdomo2/curso_go_patrones_diseno
+
+go 1.24
@@ -0,0 +1,207 @@
+// Package eventbus implementa un bus de eventos genérico por tópicos: los
+// publicadores envían un payload a un tópico y cada suscriptor de ese tópico lo
+// recibe por su propio canal, sin que unos conozcan a los otros.
+//
+// Es el patrón Observer de 08_observer generalizado: el sujeto pasa a ser un tópico
+// (un string) y los observadores, canales que pueden suscribirse a varios tópicos a la
+// vez con comodines.
+package eventbus
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// defaultBufferSize es el buffer por defecto del canal de cada suscriptor.
+const defaultBufferSize = 16
+
+// Option configura un Bus al crearlo.
+type Option func(*options)
+
+type options struct {
+	bufferSize int
+}
+
+// WithBufferSize define el buffer por defecto del canal de cada suscriptor. Mientras
+// el buffer no esté lleno, Publish no espera a que el suscriptor lea.
+func WithBufferSize(n int) Option {
+	return func(o *options) {
+		o.bufferSize = n
+	}
+}
+
+// Bus distribuye payloads de tipo T por tópico. Es seguro usarlo desde varias goroutines.
+//
+// Los tópicos son jerárquicos, con niveles separados por puntos
+// ("inventory.gpu.rtx4090"), y las suscripciones aceptan comodines al estilo MQTT:
+// "*" equivale a exactamente un nivel ("inventory.*.rtx4090") y "#", que solo puede
+// ir al final, a cualquier cantidad de niveles, incluso ninguno ("inventory.#"
+// recibe "inventory" y todo lo que está debajo).
+type Bus[T any] struct {
+	options options
+	mu      sync.RWMutex
+	root    *node[T]
+}
+
+// node es un nivel del trie de suscripciones: cada hijo es el siguiente nivel del
+// patrón (un nombre, "*" o "#"). Publicar recorre solo las ramas que coinciden con el
+// tópico, sin comparar contra todos los patrones suscritos.
+type node[T any] struct {
+	children map[string]*node[T]
+	subs     []*Subscription[T] // Suscripciones cuyo patrón termina en este nivel
+}
+
+// Comodines de las suscripciones.
+const (
+	singleLevel = "*"
+	multiLevel  = "#"
+)
+
+// ValidatePattern comprueba que pattern sea un patrón de suscripción válido: sin
+// niveles vacíos y con "#" solo como último nivel.
+func ValidatePattern(pattern string) error {
+	levels := strings.Split(pattern, ".")
+	for n, level := range levels {
+		switch {
+		case level == "":
+			return fmt.Errorf("eventbus: el patrón %q tiene un nivel vacío", pattern)
+		case level == multiLevel && n != len(levels)-1:
+			return fmt.Errorf("eventbus: en el patrón %q, %q solo puede ser el último nivel", pattern, multiLevel)
+		case level != multiLevel && level != singleLevel && strings.ContainsAny(level, singleLevel+multiLevel):
+			return fmt.Errorf("eventbus: en el patrón %q, los comodines deben ocupar un nivel completo", pattern)
+		}
+	}
+	return nil
+}
+
+// NewBus crea un bus vacío.
+func NewBus[T any](opts ...Option) *Bus[T] {
+	b := &Bus[T]{
+		options: options{bufferSize: defaultBufferSize},
+		root:    &node[T]{},
+	}
+	for _, opt := range opts {
+		opt(&b.options)
+	}
+	return b
+}
+
+// Subscribe se suscribe a los tópicos que coinciden con pattern (un tópico o un patrón
+// con comodines). Retorna el canal por el que llegan los payloads y una función cancel
+// que da de baja la suscripción y cierra el canal (así un for range sobre él termina).
+// Llamar a cancel más de una vez no tiene efecto. Hace panic si pattern no es válido
+// (ver ValidatePattern), igual que regexp.MustCompile con una expresión inválida.
+func (b *Bus[T]) Subscribe(pattern string, opts ...SubscribeOption) (ch <-chan T, cancel func()) {
+	sub := b.SubscribeWith(pattern, opts...)
+	return sub.C(), sub.Cancel
+}
+
+// SubscribeWith es como Subscribe pero retorna la *Subscription, que además del canal
+// da acceso a sus contadores (por ejemplo, cuántos payloads descartó).
+func (b *Bus[T]) SubscribeWith(pattern string, opts ...SubscribeOption) *Subscription[T] {
+	if err := ValidatePattern(pattern); err != nil {
+		panic(err)
+	}
+	config := subscribeOptions{bufferSize: b.options.bufferSize}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	sub := &Subscription[T]{
+		ch:       make(chan T, config.bufferSize),
+		done:     make(chan struct{}),
+		overflow: config.overflow,
+	}
+	levels := strings.Split(pattern, ".")
+	b.mu.Lock()
+	n := b.root
+	for _, level := range levels {
+		if n.children == nil {
+			n.children = make(map[string]*node[T])
+		}
+		child, ok := n.children[level]
+		if !ok {
+			child = &node[T]{}
+			n.children[level] = child
+		}
+		n = child
+	}
+	n.subs = append(slices.Clip(n.subs), sub) // Copy-on-write: un Publish en curso sigue con la lista que tenía
+	b.mu.Unlock()
+
+	sub.cancel = func() { b.remove(levels, sub) }
+	return sub
+}
+
+// remove quita sub del nodo de su patrón y poda los nodos que quedan vacíos.
+func (b *Bus[T]) remove(levels []string, sub *Subscription[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	path := []*node[T]{b.root}
+	for _, level := range levels {
+		path = append(path, path[len(path)-1].children[level])
+	}
+	n := path[len(path)-1]
+	n.subs = slices.DeleteFunc(slices.Clone(n.subs), func(s *Subscription[T]) bool { return s == sub })
+
+	for depth := len(levels); depth > 0; depth-- {
+		n := path[depth]
+		if len(n.subs) > 0 || len(n.children) > 0 {
+			break
+		}
+		delete(path[depth-1].children, levels[depth-1])
+	}
+}
+
+// Publish envía payload a todas las suscripciones que coinciden con topic y retorna a
+// cuántas se entregó. topic no puede tener comodines (se publica en un tópico concreto);
+// si los tiene, no se entrega a nadie. Si el buffer de un suscriptor está lleno, se
+// aplica su política de Overflow: esperar (Block) o descartar un payload.
+func (b *Bus[T]) Publish(topic string, payload T) int {
+	delivered := 0
+	for _, sub := range b.match(topic) {
+		if sub.send(payload) {
+			delivered++
+		}
+	}
+	return delivered
+}
+
+// match retorna las suscripciones cuyo patrón coincide con topic.
+func (b *Bus[T]) match(topic string) []*Subscription[T] {
+	levels := strings.Split(topic, ".")
+	if slices.Contains(levels, singleLevel) || slices.Contains(levels, multiLevel) {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var subs []*Subscription[T]
+	b.root.collect(levels, &subs)
+	return subs
+}
+
+// collect agrega a subs las suscripciones de este nodo y sus descendientes que
+// coinciden con los niveles restantes del tópico. Cada nodo se visita a lo sumo una
+// vez, así que ninguna suscripción recibe el mismo payload dos veces.
+func (n *node[T]) collect(levels []string, subs *[]*Subscription[T]) {
+	if hash, ok := n.children[multiLevel]; ok {
+		*subs = append(*subs, hash.subs...) // "#" coincide con el resto del tópico, aunque no quede nada
+	}
+	if len(levels) == 0 {
+		*subs = append(*subs, n.subs...)
+		return
+	}
+	if child, ok := n.children[levels[0]]; ok {
+		child.collect(levels[1:], subs)
+	}
+	if star, ok := n.children[singleLevel]; ok {
+		star.collect(levels[1:], subs)
+	}
+}
+
+// Subscribers retorna cuántas suscripciones recibirían un payload publicado en topic.
+func (b *Bus[T]) Subscribers(topic string) int {
+	return len(b.match(topic))
+}
@@ -0,0 +1,124 @@
+package eventbus
+
+import (
+	"slices"
+	"testing"
+)
+
+// drain retorna los payloads que hay en el buffer de ch, sin esperar.
+func drain[T any](ch <-chan T) []T {
+	var got []T
+	for {
+		select {
+		case payload := <-ch:
+			got = append(got, payload)
+		default:
+			return got
+		}
+	}
+}
+
+func TestPatternMatching(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topics  []string // Tópicos que debe recibir, de los publicados
+	}{
+		{"inventory.gpu.rtx4090", []string{"inventory.gpu.rtx4090"}},
+		{"inventory.*.rtx4090", []string{"inventory.gpu.rtx4090", "inventory.oferta.rtx4090"}},
+		{"inventory.*", []string{"inventory.gpu"}},
+		{"*.gpu.*", []string{"inventory.gpu.rtx4090", "inventory.gpu.rx7900"}},
+		{"inventory.#", []string{"inventory", "inventory.gpu", "inventory.gpu.rtx4090", "inventory.gpu.rx7900", "inventory.oferta.rtx4090"}},
+		{"inventory.gpu.#", []string{"inventory.gpu", "inventory.gpu.rtx4090", "inventory.gpu.rx7900"}},
+		{"#", []string{"inventory", "inventory.gpu", "inventory.gpu.rtx4090", "inventory.gpu.rx7900", "inventory.oferta.rtx4090", "orders"}},
+		{"*", []string{"inventory", "orders"}},
+	}
+	published := []string{"inventory", "inventory.gpu", "inventory.gpu.rtx4090", "inventory.gpu.rx7900", "inventory.oferta.rtx4090", "orders"}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			bus := NewBus[string]()
+			ch, cancel := bus.Subscribe(tt.pattern)
+			defer cancel()
+			for _, topic := range published {
+				bus.Publish(topic, topic)
+			}
+			if got := drain(ch); !slices.Equal(got, tt.topics) {
+				t.Errorf("recibió %v, quiero %v", got, tt.topics)
+			}
+		})
+	}
+}
+
+func TestPublishWithWildcardsDeliversNothing(t *testing.T) {
+	bus := NewBus[string]()
+	_, cancel := bus.Subscribe("#")
+	defer cancel()
+	for _, topic := range []string{"inventory.*", "inventory.#"} {
+		if got := bus.Publish(topic, "x"); got != 0 {
+			t.Errorf("Publish(%q) entregó a %d, quiero 0", topic, got)
+		}
+	}
+}
+
+func TestOverlappingPatternsDeliverOnce(t *testing.T) {
+	bus := NewBus[int]()
+	patterns := []string{"inventory.gpu.rtx4090", "inventory.*.rtx4090", "inventory.#", "inventory.gpu.#", "#"}
+	channels := make([]<-chan int, len(patterns))
+	for n, pattern := range patterns {
+		ch, cancel := bus.Subscribe(pattern)
+		defer cancel()
+		channels[n] = ch
+	}
+
+	if got := bus.Publish("inventory.gpu.rtx4090", 1); got != len(patterns) {
+		t.Errorf("Publish entregó a %d suscripciones, quiero %d", got, len(patterns))
+	}
+	for n, ch := range channels {
+		if got := drain(ch); !slices.Equal(got, []int{1}) {
+			t.Errorf("%q recibió %v, quiero [1]", patterns[n], got)
+		}
+	}
+}
+
+func TestCancelPrunesEmptyNodes(t *testing.T) {
+	bus := NewBus[int]()
+	ch, cancelDeep := bus.Subscribe("inventory.gpu.rtx4090")
+	_, cancelShallow := bus.Subscribe("inventory.#")
+
+	cancelDeep()
+	cancelDeep() // La segunda llamada no tiene efecto
+	if _, open := <-ch; open {
+		t.Error("el canal sigue abierto después de cancel")
+	}
+	if _, ok := bus.root.children["inventory"].children["gpu"]; ok {
+		t.Error("el nodo inventory.gpu no se podó tras cancelar su única suscripción")
+	}
+	if got := bus.Subscribers("inventory.gpu.rtx4090"); got != 1 {
+		t.Errorf("Subscribers = %d, quiero 1 (inventory.#)", got)
+	}
+
+	cancelShallow()
+	if len(bus.root.children) != 0 {
+		t.Errorf("quedan nodos tras cancelar todo: %v", bus.root.children)
+	}
+	if got := bus.Publish("inventory.gpu.rtx4090", 1); got != 0 {
+		t.Errorf("Publish tras cancelar todo entregó a %d, quiero 0", got)
+	}
+}
+
+func TestValidatePattern(t *testing.T) {
+	for pattern, valid := range map[string]bool{
+		"inventory.gpu":   true,
+		"inventory.*.gpu": true,
+		"inventory.#":     true,
+		"#":               true,
+		"inventory..gpu":  false,
+		"":                false,
+		"inventory.#.gpu": false,
+		"inventory.gp*":   false,
+	} {
+		if err := ValidatePattern(pattern); (err == nil) != valid {
+			t.Errorf("ValidatePattern(%q) = %v, quiero válido = %t", pattern, err, valid)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package eventbus
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Overflow es lo que hace Publish cuando el buffer de un suscriptor está lleno.
+type Overflow int
+
+const (
+	// Block espera a que el suscriptor lea. No se pierde nada, pero un suscriptor
+	// lento frena a quien publica (y con él, a los demás suscriptores).
+	Block Overflow = iota
+	// DropOldest descarta el payload más viejo del buffer para hacer lugar al nuevo:
+	// el suscriptor siempre tiene lo más reciente.
+	DropOldest
+	// DropNewest descarta el payload nuevo: el suscriptor conserva lo que ya tenía.
+	DropNewest
+)
+
+func (o Overflow) String() string {
+	switch o {
+	case DropOldest:
+		return "DropOldest"
+	case DropNewest:
+		return "DropNewest"
+	default:
+		return "Block"
+	}
+}
+
+// SubscribeOption configura una suscripción.
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	bufferSize int
+	overflow   Overflow
+}
+
+// WithSubscriberBuffer define el buffer del canal de esta suscripción, en lugar del
+// del bus (WithBufferSize).
+func WithSubscriberBuffer(n int) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.bufferSize = n
+	}
+}
+
+// WithOverflow define qué hacer cuando el buffer de esta suscripción está lleno. Por
+// defecto es Block.
+func WithOverflow(policy Overflow) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.overflow = policy
+	}
+}
+
+// Subscription es una suscripción a un patrón de tópicos.
+type Subscription[T any] struct {
+	ch       chan T
+	done     chan struct{} // Se cierra al cancelar, para liberar a un Publish bloqueado
+	mu       sync.RWMutex  // Publish envía con RLock; cancelar cierra ch con Lock
+	closed   bool
+	overflow Overflow
+	dropped  atomic.Int64
+	cancel   func() // Quita la suscripción del bus
+	once     sync.Once
+}
+
+// C retorna el canal por el que llegan los payloads. Se cierra al cancelar.
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// Dropped retorna cuántos payloads se descartaron porque el buffer estaba lleno.
+func (s *Subscription[T]) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Cancel da de baja la suscripción y cierra su canal. Llamarla más de una vez no
+// tiene efecto.
+func (s *Subscription[T]) Cancel() {
+	s.once.Do(func() {
+		s.cancel()
+		close(s.done) // Despierta a un Publish que esté esperando a este suscriptor
+		s.mu.Lock()   // Espera a que termine cualquier envío en curso
+		s.closed = true
+		close(s.ch)
+		s.mu.Unlock()
+	})
+}
+
+// send entrega payload según la política de Overflow y retorna si quedó en el canal.
+func (s *Subscription[T]) send(payload T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return false
+	}
+
+	overflow := s.overflow
+	if overflow == DropOldest && cap(s.ch) == 0 {
+		overflow = DropNewest // Sin buffer no hay un payload viejo que descartar
+	}
+	switch overflow {
+	case DropNewest:
+		select {
+		case s.ch <- payload:
+			return true
+		default:
+			s.dropped.Add(1)
+			return false
+		}
+	case DropOldest:
+		for {
+			select {
+			case s.ch <- payload:
+				return true
+			default:
+			}
+			select {
+			case <-s.ch: // Saca el más viejo; si el suscriptor lo leyó justo antes, ya hay lugar
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- payload:
+			return true
+		case <-s.done:
+			return false
+		}
+	}
+}
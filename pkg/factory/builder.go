@@ -0,0 +1,150 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// ComputerBuilder arma paso a paso productos con muchas opciones (patrón Builder).
+// Los métodos se encadenan y la validación ocurre toda junta en Build, que usa la
+// factory para crear el producto del tipo indicado:
+//
+//	product, err := factory.NewComputerBuilder("desktop", "Gaming Pro").
+//		CPU("Ryzen 9 7950X").RAM(64).GPU("RTX 4090").Storage(2048).
+//		Price(3999).Build()
+type ComputerBuilder struct {
+	productType string
+	name        string
+	stock       int
+	price       float64
+	specs       Specs
+}
+
+// NewComputerBuilder empieza a armar un producto del tipo productType.
+func NewComputerBuilder(productType, name string) *ComputerBuilder {
+	return &ComputerBuilder{productType: productType, name: name}
+}
+
+func (b *ComputerBuilder) CPU(cpu string) *ComputerBuilder {
+	b.specs.CPU = cpu
+	return b
+}
+
+func (b *ComputerBuilder) RAM(gb int) *ComputerBuilder {
+	b.specs.RAMGB = gb
+	return b
+}
+
+func (b *ComputerBuilder) GPU(gpu string) *ComputerBuilder {
+	b.specs.GPU = gpu
+	return b
+}
+
+func (b *ComputerBuilder) Storage(gb int) *ComputerBuilder {
+	b.specs.StorageGB = gb
+	return b
+}
+
+func (b *ComputerBuilder) Stock(stock int) *ComputerBuilder {
+	b.stock = stock
+	return b
+}
+
+func (b *ComputerBuilder) Price(price float64) *ComputerBuilder {
+	b.price = price
+	return b
+}
+
+// Build valida la configuración y crea el producto. Reporta todos los problemas
+// juntos en lugar de detenerse en el primero.
+func (b *ComputerBuilder) Build() (IProduct, error) {
+	ctor, err := GetComputerFactory(b.productType)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	if b.specs.CPU == "" {
+		errs = append(errs, errors.New("❌ falta el procesador"))
+	}
+	if b.specs.RAMGB < 4 || bits.OnesCount(uint(b.specs.RAMGB)) != 1 {
+		errs = append(errs, fmt.Errorf("❌ RAM inválida: %dGB (debe ser potencia de 2, mínimo 4GB)", b.specs.RAMGB))
+	}
+	if b.specs.StorageGB < 128 {
+		errs = append(errs, fmt.Errorf("❌ almacenamiento insuficiente: %dGB (mínimo 128GB)", b.specs.StorageGB))
+	}
+	if b.productType == "server" && b.specs.RAMGB < 32 {
+		errs = append(errs, fmt.Errorf("❌ un servidor necesita al menos 32GB de RAM, tiene %dGB", b.specs.RAMGB))
+	}
+	if len(errs) > 0 {
+		// No se llama al constructor, que consumiría un número de SKU para un producto
+		// que no se va a crear; igual se reportan los errores de nombre, stock y precio
+		if err := validateProduct(b.name, b.stock, b.price); err != nil {
+			errs = append(errs, err)
+		}
+		return nil, fmt.Errorf("❌ no se pudo armar %q: %w", b.name, errors.Join(errs...))
+	}
+	product, err := ctor(b.name, b.stock, b.price)
+	if err != nil {
+		return nil, fmt.Errorf("❌ no se pudo armar %q: %w", b.name, err)
+	}
+
+	configurable, ok := product.(interface{ setSpecs(Specs) }) // Lo cumplen los productos que embeben Computer
+	if !ok {
+		return nil, fmt.Errorf("❌ el tipo %s no admite especificaciones", b.productType)
+	}
+	configurable.setSpecs(b.specs)
+	return product, nil
+}
+
+// ModelRecipe arma el ComputerBuilder de un modelo del catálogo, sin el stock.
+type ModelRecipe func() *ComputerBuilder
+
+// models son las recetas de los modelos complejos que ofrece la tienda.
+var models syncutil.SafeMap[string, ModelRecipe]
+
+func init() {
+	RegisterModel("gaming-desktop", func() *ComputerBuilder {
+		return NewComputerBuilder("desktop", "Gaming Pro").
+			CPU("Ryzen 9 7950X").RAM(64).GPU("RTX 4090").Storage(2048).Price(3999)
+	})
+	RegisterModel("ultrabook", func() *ComputerBuilder {
+		return NewComputerBuilder("laptop", "Ultrabook 14").
+			CPU("Core Ultra 7").RAM(16).Storage(512).Price(1399)
+	})
+}
+
+// RegisterModel agrega un modelo al catálogo de CreateModel. Igual que con
+// RegisterProduct, un paquete que define su propio tipo de producto puede registrar
+// también los modelos que lo usan, sin que la factory los conozca.
+// Registrar dos veces el mismo modelo, o una receta nil, es un error de programación.
+func RegisterModel(model string, recipe ModelRecipe) {
+	if recipe == nil {
+		panic("factory: receta nil para el modelo " + model)
+	}
+	if _, exists := models.GetOrSet(model, recipe); exists {
+		panic("factory: el modelo " + model + " ya está registrado")
+	}
+}
+
+// CreateModel crea uno de los modelos complejos del catálogo con stock unidades.
+// Es la factory delegando en el Builder: el cliente pide un modelo por nombre y no
+// necesita conocer ni su tipo ni su configuración.
+func CreateModel(model string, stock int) (IProduct, error) {
+	recipe, ok := models.Get(model)
+	if !ok {
+		return nil, fmt.Errorf("❌ Unknown model: %s", model)
+	}
+	return recipe().Stock(stock).Build()
+}
+
+// Models retorna los nombres de los modelos disponibles, en orden alfabético.
+func Models() []string {
+	names := models.Keys()
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,134 @@
+package factory
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// productJSON es la representación JSON de un producto.
+type productJSON struct {
+	SKU        string            `json:"sku"`
+	Type       string            `json:"type"`
+	Name       string            `json:"name"`
+	Stock      int               `json:"stock"`
+	Price      float64           `json:"price"`
+	Warranty   int               `json:"warranty_months"`
+	Specs      *Specs            `json:"specs,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// MarshalJSON serializa el producto. Los productos concretos la heredan al embeber Computer.
+func (c *Computer) MarshalJSON() ([]byte, error) {
+	p := productJSON{
+		SKU:        c.sku,
+		Type:       c.kind,
+		Name:       c.name,
+		Stock:      c.stock,
+		Price:      c.price,
+		Warranty:   c.warranty,
+		Attributes: c.attributes,
+	}
+	if c.specs != (Specs{}) {
+		p.Specs = &c.specs
+	}
+	return json.Marshal(p)
+}
+
+// Catalog agrupa los productos creados por la factory y permite listarlos
+// filtrados y ordenados. Filter y SortBy retornan un Catalog nuevo, así que se
+// pueden encadenar sin modificar el original. Es seguro para uso concurrente.
+type Catalog struct {
+	mu       sync.RWMutex
+	products []IProduct
+}
+
+// NewCatalog crea un catálogo con los productos dados.
+func NewCatalog(products ...IProduct) *Catalog {
+	return &Catalog{products: slices.Clone(products)}
+}
+
+// Add agrega productos al catálogo.
+func (c *Catalog) Add(products ...IProduct) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.products = append(c.products, products...)
+}
+
+// Products retorna una copia de la lista de productos.
+func (c *Catalog) Products() []IProduct {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.products)
+}
+
+// Len retorna la cantidad de productos.
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.products)
+}
+
+// Filter retorna un catálogo con los productos para los que keep retorna true.
+func (c *Catalog) Filter(keep func(IProduct) bool) *Catalog {
+	var filtered []IProduct
+	for _, product := range c.Products() {
+		if keep(product) {
+			filtered = append(filtered, product)
+		}
+	}
+	return &Catalog{products: filtered}
+}
+
+// SortBy retorna un catálogo ordenado con compare (ByName, ByStock, ByPrice o Desc de
+// alguno de ellos). El orden es estable: los empates mantienen el orden original.
+func (c *Catalog) SortBy(compare func(a, b IProduct) int) *Catalog {
+	sorted := c.Products()
+	slices.SortStableFunc(sorted, compare)
+	return &Catalog{products: sorted}
+}
+
+// ByName, ByStock y ByPrice comparan productos para SortBy.
+func ByName(a, b IProduct) int {
+	return strings.Compare(strings.ToLower(a.Name()), strings.ToLower(b.Name()))
+}
+func ByStock(a, b IProduct) int { return cmp.Compare(a.Stock(), b.Stock()) }
+func ByPrice(a, b IProduct) int { return cmp.Compare(a.Price(), b.Price()) }
+
+// Desc invierte un criterio de orden.
+func Desc(compare func(a, b IProduct) int) func(a, b IProduct) int {
+	return func(a, b IProduct) int { return compare(b, a) }
+}
+
+// InStock filtra los productos con unidades disponibles.
+func InStock(p IProduct) bool {
+	return p.Stock() > 0
+}
+
+// OfType retorna un filtro de los productos del tipo indicado.
+func OfType(productType string) func(IProduct) bool {
+	return func(p IProduct) bool { return p.Type() == productType }
+}
+
+// MarshalJSON serializa el catálogo como una lista de productos.
+func (c *Catalog) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Products())
+}
+
+// Print escribe el catálogo como una tabla alineada.
+func (c *Catalog) Print(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "SKU\tTIPO\tNOMBRE\tSTOCK\tPRECIO\t")
+	total := 0.0
+	for _, p := range c.Products() {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%d\t%10.2f\t\n", p.SKU(), p.Type(), p.Name(), p.Stock(), p.Price())
+		total += float64(p.Stock()) * p.Price()
+	}
+	fmt.Fprintf(table, "\t\t%d productos\t\t%10.2f\t(valor del inventario)\n", c.Len(), total)
+	return table.Flush()
+}
@@ -0,0 +1,46 @@
+package factory
+
+func init() {
+	RegisterProduct("laptop", NewLaptop)
+	RegisterProduct("desktop", NewDesktop)
+}
+
+// Laptop representa un producto concreto de tipo laptop
+// Utiliza composición para heredar funcionalidad de Computer
+type Laptop struct {
+	Computer
+}
+
+// NewLaptop es el constructor para crear instancias de Laptop
+// Retorna una interfaz IProduct para mantener el polimorfismo, o un error de validación
+func NewLaptop(name string, stock int, price float64) (IProduct, error) {
+	computer, err := NewComputer("laptop", "LAP", name, stock, price)
+	if err != nil {
+		return nil, err
+	}
+	return &Laptop{Computer: computer}, nil
+}
+
+func (l *Laptop) Clone() IProduct {
+	return &Laptop{Computer: l.CloneComputer()}
+}
+
+// Desktop representa un producto concreto de tipo computadora de escritorio
+// Utiliza composición para heredar funcionalidad de Computer
+type Desktop struct {
+	Computer
+}
+
+// NewDesktop es el constructor para crear instancias de Desktop
+// Retorna una interfaz IProduct para mantener el polimorfismo, o un error de validación
+func NewDesktop(name string, stock int, price float64) (IProduct, error) {
+	computer, err := NewComputer("desktop", "DSK", name, stock, price)
+	if err != nil {
+		return nil, err
+	}
+	return &Desktop{Computer: computer}, nil
+}
+
+func (d *Desktop) Clone() IProduct {
+	return &Desktop{Computer: d.CloneComputer()}
+}
@@ -0,0 +1,48 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errores de creación de productos. Se pueden comprobar con errors.Is, aunque
+// lleguen envueltos en un *ValidationError o unidos con errors.Join.
+var (
+	ErrUnknownProductType = errors.New("tipo de producto desconocido")
+	ErrInvalidName        = errors.New("el nombre es obligatorio")
+	ErrInvalidStock       = errors.New("el stock no puede ser negativo")
+	ErrInvalidPrice       = errors.New("el precio no puede ser negativo")
+	ErrInvalidWarranty    = errors.New("la garantía no puede ser negativa")
+)
+
+// ValidationError describe un campo inválido al crear un producto. Con errors.As
+// se puede saber qué campo falló y con qué valor.
+type ValidationError struct {
+	Field string // Campo inválido: "name", "stock", "price" o "warranty"
+	Value any    // Valor recibido
+	Err   error  // Uno de los errores Err* de este paquete
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("❌ %s inválido (%#v): %v", e.Field, e.Value, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// validateProduct valida los campos comunes de todos los productos y retorna
+// un *ValidationError por cada campo inválido, unidos con errors.Join.
+func validateProduct(name string, stock int, price float64) error {
+	var errs []error
+	if name == "" {
+		errs = append(errs, &ValidationError{Field: "name", Value: name, Err: ErrInvalidName})
+	}
+	if stock < 0 {
+		errs = append(errs, &ValidationError{Field: "stock", Value: stock, Err: ErrInvalidStock})
+	}
+	if price < 0 {
+		errs = append(errs, &ValidationError{Field: "price", Value: price, Err: ErrInvalidPrice})
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,57 @@
+// Package factory implementa el patrón Factory para los productos de una tienda de
+// computadoras: cada tipo de producto se registra con RegisterProduct y
+// GetComputerFactory retorna su constructor sin conocer los tipos concretos.
+//
+// Es la factory de 05_factory extraída a un paquete importable, para que otros
+// paquetes puedan crear productos o registrar tipos nuevos.
+package factory
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// ProductConstructor es la firma de los constructores que la factory sabe usar.
+type ProductConstructor func(name string, stock int, price float64) (IProduct, error)
+
+// registry asocia cada tipo de producto con su constructor. Es seguro para uso
+// concurrente, así que se puede registrar y consultar desde cualquier goroutine.
+var registry syncutil.SafeMap[string, ProductConstructor]
+
+// RegisterProduct agrega un tipo de producto a la factory. Los productos se registran
+// a sí mismos (normalmente desde un init en su propio archivo), así que agregar uno
+// nuevo no requiere modificar la factory: abierta a extensión, cerrada a modificación.
+// Registrar dos veces el mismo tipo, o un constructor nil, es un error de programación.
+func RegisterProduct(productType string, ctor ProductConstructor) {
+	if ctor == nil {
+		panic("factory: constructor nil para " + productType)
+	}
+	if _, exists := registry.GetOrSet(productType, ctor); exists {
+		panic("factory: el tipo " + productType + " ya está registrado")
+	}
+}
+
+// RegisteredProducts retorna los tipos de producto registrados, en orden alfabético.
+func RegisteredProducts() []string {
+	types := registry.Keys()
+	slices.Sort(types)
+	return types
+}
+
+// GetComputerFactory es la función factory principal del patrón
+// Retorna una función constructora específica basada en el tipo solicitado
+// Parámetros:
+//   - ComputerType: string que especifica el tipo de computadora (uno de RegisteredProducts)
+//
+// Retorna:
+//   - Una función constructora específica para el tipo solicitado
+//   - ErrUnknownProductType si el tipo no está registrado
+func GetComputerFactory(ComputerType string) (ProductConstructor, error) {
+	ctor, ok := registry.Get(ComputerType)
+	if !ok {
+		return nil, fmt.Errorf("❌ Invalid computer type %q: %w", ComputerType, ErrUnknownProductType)
+	}
+	return ctor, nil
+}
@@ -0,0 +1,173 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGetComputerFactory(t *testing.T) {
+	for _, productType := range []string{"laptop", "desktop"} {
+		ctor, err := GetComputerFactory(productType)
+		if err != nil {
+			t.Fatalf("GetComputerFactory(%q): %v", productType, err)
+		}
+		product, err := ctor("Equipo", 3, 999.5)
+		if err != nil {
+			t.Fatalf("%s: %v", productType, err)
+		}
+		if product.Type() != productType || product.Name() != "Equipo" || product.Stock() != 3 || product.Price() != 999.5 {
+			t.Errorf("%s: producto = %v", productType, product)
+		}
+	}
+
+	if _, err := GetComputerFactory("tablet"); !errors.Is(err, ErrUnknownProductType) {
+		t.Errorf("GetComputerFactory(\"tablet\"): err = %v, quiero ErrUnknownProductType", err)
+	}
+}
+
+func TestSKUsAreSequentialPerPrefix(t *testing.T) {
+	first, err := NewLaptop("Laptop A", 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewLaptop("Laptop B", 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(first.SKU(), "LAP-") || first.SKU() == second.SKU() {
+		t.Errorf("SKUs = %q y %q, quiero dos SKUs LAP- distintos", first.SKU(), second.SKU())
+	}
+
+	// Un producto inválido no consume número: el siguiente SKU sigue la secuencia
+	if _, err := NewLaptop("", 1, 100); err == nil {
+		t.Fatal("NewLaptop sin nombre no retornó error")
+	}
+	third, err := NewLaptop("Laptop C", 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	if _, err := fmt.Sscanf(second.SKU(), "LAP-%d", &n); err != nil {
+		t.Fatalf("SKU %q: %v", second.SKU(), err)
+	}
+	if want := fmt.Sprintf("LAP-%04d", n+1); third.SKU() != want {
+		t.Errorf("SKU tras un producto inválido = %q, quiero %q", third.SKU(), want)
+	}
+
+	// Tampoco lo consume un Builder con specs inválidas, aunque nombre, stock y precio sean válidos
+	if _, err := NewComputerBuilder("laptop", "Laptop D").RAM(3).Build(); err == nil {
+		t.Fatal("Build con specs inválidas no retornó error")
+	}
+	fourth, err := NewLaptop("Laptop E", 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf("LAP-%04d", n+2); fourth.SKU() != want {
+		t.Errorf("SKU tras un Build inválido = %q, quiero %q", fourth.SKU(), want)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		product    string
+		stock      int
+		price      float64
+		warranty   int
+		wantErrs   []error  // Sentinels que debe cumplir el error con errors.Is
+		wantFields []string // Campos de los *ValidationError, en orden
+	}{
+		{"sin nombre", "", 1, 10, 12, []error{ErrInvalidName}, []string{"name"}},
+		{"stock negativo", "Laptop", -1, 10, 12, []error{ErrInvalidStock}, []string{"stock"}},
+		{"precio negativo", "Laptop", 1, -10, 12, []error{ErrInvalidPrice}, []string{"price"}},
+		{"garantía negativa", "Laptop", 1, 10, -1, []error{ErrInvalidWarranty}, []string{"warranty"}},
+		{"todo inválido", "", -1, -10, -1,
+			[]error{ErrInvalidName, ErrInvalidStock, ErrInvalidPrice, ErrInvalidWarranty},
+			[]string{"name", "stock", "price", "warranty"}},
+	}
+
+	ctor, err := GetProductFactory("laptop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product, err := ctor(tt.product, WithStock(tt.stock), WithPrice(tt.price), WithWarranty(tt.warranty))
+			if product != nil || err == nil {
+				t.Fatalf("= (%v, %v), quiero un error", product, err)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("errors.Is(err, %v) = false; err = %v", want, err)
+				}
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.wantFields[0] {
+				t.Fatalf("errors.As(err, *ValidationError) = %v, quiero el campo %q", validationErr, tt.wantFields[0])
+			}
+			if got := validationFields(err); !slices.Equal(got, tt.wantFields) {
+				t.Errorf("campos inválidos = %v, quiero %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+// validationFields retorna los campos de todos los *ValidationError contenidos en err,
+// recorriendo los errores unidos con errors.Join.
+func validationFields(err error) []string {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) && validationErr == err {
+		return []string{validationErr.Field}
+	}
+	var fields []string
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, inner := range joined.Unwrap() {
+			fields = append(fields, validationFields(inner)...)
+		}
+	}
+	return fields
+}
+
+// Cada modelo del catálogo debe poder crearse solo con los tipos que registra este
+// paquete: un modelo de un tipo registrado en otro paquete fallaría aquí.
+func TestCreateModel(t *testing.T) {
+	for _, model := range Models() {
+		product, err := CreateModel(model, 2)
+		if err != nil {
+			t.Errorf("CreateModel(%q): %v", model, err)
+			continue
+		}
+		if product.Stock() != 2 || product.Specs().CPU == "" {
+			t.Errorf("CreateModel(%q) = %v", model, product)
+		}
+	}
+	if _, err := CreateModel("smartwatch", 1); err == nil {
+		t.Error("CreateModel(\"smartwatch\") no retornó error")
+	}
+}
+
+func TestNewUnitSerials(t *testing.T) {
+	// Un nombre de modelo de menos de 3 letras; GetOrSet evita registrarlo dos veces con -count
+	models.GetOrSet("pc", func() *ComputerBuilder {
+		return NewComputerBuilder("desktop", "PC de oficina").CPU("Core i5").RAM(8).Storage(256).Price(499)
+	})
+	units := NewUnitFactory()
+	for _, tt := range []struct{ model, want string }{
+		{"pc", "PC-000001"},
+		{"pc", "PC-000002"},
+		{"ultrabook", "ULT-000001"},
+	} {
+		unit, err := units.NewUnit(tt.model)
+		if err != nil {
+			t.Errorf("NewUnit(%q): %v", tt.model, err)
+			continue
+		}
+		if unit.Serial != tt.want {
+			t.Errorf("NewUnit(%q).Serial = %s, quiero %s", tt.model, unit.Serial, tt.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package factory
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/memoize"
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// Model es el estado intrínseco de un producto (patrón Flyweight): todo lo que es
+// igual para cada unidad del mismo modelo. Es inmutable y se comparte entre todas
+// las unidades, así que no se debe modificar.
+type Model struct {
+	Key          string
+	SerialPrefix string // Prefijo de los números de serie de sus unidades
+	Type         string
+	Name         string
+	Price        float64
+	Specs        Specs
+	Description  string // Texto largo de la ficha del producto
+}
+
+// Unit es una unidad física de un modelo: solo guarda su estado propio (extrínseco)
+// y un puntero al Model compartido.
+type Unit struct {
+	Serial string
+	Model  *Model
+}
+
+func (u Unit) String() string {
+	return fmt.Sprintf("#%s %s (%s)", u.Serial, u.Model.Name, u.Model.Specs)
+}
+
+// UnitFactory crea unidades de los modelos del catálogo (los de CreateModel).
+// Cada Model se carga una sola vez, la primera vez que se pide una unidad suya,
+// y desde entonces todas las unidades apuntan a la misma instancia.
+type UnitFactory struct {
+	models  *memoize.Memory[string, *Model]
+	loaded  atomic.Int32
+	serials syncutil.SafeCounter[string]
+}
+
+// NewUnitFactory crea una UnitFactory sin ningún modelo cargado todavía.
+func NewUnitFactory() *UnitFactory {
+	f := &UnitFactory{}
+	f.models = memoize.NewMemory(f.loadModel, memoize.WithLogging(false))
+	return f
+}
+
+// NewUnit crea una unidad del modelo indicado, cargando el Model si es la primera.
+func (f *UnitFactory) NewUnit(model string) (Unit, error) {
+	shared, err := f.models.Get(model)
+	if err != nil {
+		return Unit{}, err
+	}
+	serial := fmt.Sprintf("%s-%06d", shared.SerialPrefix, f.serials.Inc(model))
+	return Unit{Serial: serial, Model: shared}, nil
+}
+
+// LoadedModels retorna cuántos Model distintos se han cargado.
+func (f *UnitFactory) LoadedModels() int {
+	return int(f.loaded.Load())
+}
+
+// loadModel arma el Model a partir de la receta del catálogo. Simula una carga
+// costosa (por ejemplo, leer la ficha desde una base de datos).
+func (f *UnitFactory) loadModel(key string) (*Model, error) {
+	recipe, ok := models.Get(key)
+	if !ok {
+		return nil, fmt.Errorf("❌ Unknown model: %s", key)
+	}
+	time.Sleep(50 * time.Millisecond)
+	f.loaded.Add(1)
+	builder := recipe()
+	return &Model{
+		Key:          key,
+		SerialPrefix: strings.ToUpper(key[:min(3, len(key))]),
+		Type:         builder.productType,
+		Name:         builder.name,
+		Price:        builder.price,
+		Specs:        builder.specs,
+		Description:  describe(builder.name, builder.specs),
+	}, nil
+}
+
+// describe genera la ficha del producto: un texto de unos 4KB, como la descripción
+// larga de una tienda en línea.
+func describe(name string, specs Specs) string {
+	paragraph := fmt.Sprintf("%s con %s. Diseñado para rendir en cualquier tarea, con materiales premium y soporte oficial. ", name, specs)
+	return strings.Repeat(paragraph, 4096/len(paragraph)+1)
+}
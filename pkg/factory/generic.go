@@ -0,0 +1,63 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+var (
+	// ErrUnknownKey indica que no hay ningún constructor registrado con esa clave.
+	ErrUnknownKey = errors.New("clave no registrada")
+	// ErrDuplicateKey indica que ya había un constructor registrado con esa clave.
+	ErrDuplicateKey = errors.New("clave ya registrada")
+)
+
+// Constructor son las formas de constructor que acepta Register: los que no pueden
+// fallar y los que retornan un error.
+type Constructor[T any] interface {
+	func() T | func() (T, error)
+}
+
+// Factory es la idea de GetComputerFactory para cualquier tipo: asocia claves con
+// constructores de T, que normalmente es una interfaz (IProduct, un método de pago,
+// un canal de notificación...). Es segura para uso concurrente y el valor cero está
+// listo para usarse.
+type Factory[T any] struct {
+	constructors syncutil.SafeMap[string, func() (T, error)]
+}
+
+// Register asocia key con ctor. Gracias al constraint Constructor, ctor puede ser
+// func() T o func() (T, error), y el compilador rechaza cualquier otra firma.
+func Register[T any, C Constructor[T]](f *Factory[T], key string, ctor C) error {
+	var normalized func() (T, error)
+	switch ctor := any(ctor).(type) {
+	case func() T:
+		normalized = func() (T, error) { return ctor(), nil }
+	case func() (T, error):
+		normalized = ctor
+	}
+	if _, exists := f.constructors.GetOrSet(key, normalized); exists {
+		return fmt.Errorf("❌ %q: %w", key, ErrDuplicateKey)
+	}
+	return nil
+}
+
+// Create crea un T con el constructor registrado en key.
+func (f *Factory[T]) Create(key string) (T, error) {
+	ctor, ok := f.constructors.Get(key)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("❌ %q: %w", key, ErrUnknownKey)
+	}
+	return ctor()
+}
+
+// Keys retorna las claves registradas, en orden alfabético.
+func (f *Factory[T]) Keys() []string {
+	keys := f.constructors.Keys()
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,91 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+)
+
+// defaultWarranty son los meses de garantía de un producto si no se indica otra cosa.
+const defaultWarranty = 12
+
+// productOptions reúne la configuración opcional de un producto.
+type productOptions struct {
+	stock      int
+	price      float64
+	warranty   int
+	attributes map[string]string
+}
+
+// ProductOption configura un producto creado con GetProductFactory.
+type ProductOption func(*productOptions)
+
+// WithStock indica las unidades disponibles. Por defecto 0.
+func WithStock(stock int) ProductOption {
+	return func(o *productOptions) {
+		o.stock = stock
+	}
+}
+
+// WithPrice indica el precio. Por defecto 0.
+func WithPrice(price float64) ProductOption {
+	return func(o *productOptions) {
+		o.price = price
+	}
+}
+
+// WithWarranty indica los meses de garantía. Por defecto 12.
+func WithWarranty(months int) ProductOption {
+	return func(o *productOptions) {
+		o.warranty = months
+	}
+}
+
+// WithAttribute agrega un atributo libre (color, teclado, ...).
+func WithAttribute(key, value string) ProductOption {
+	return func(o *productOptions) {
+		if o.attributes == nil {
+			o.attributes = make(map[string]string)
+		}
+		o.attributes[key] = value
+	}
+}
+
+// OptionsConstructor crea un producto a partir de su nombre y opciones.
+type OptionsConstructor func(name string, opts ...ProductOption) (IProduct, error)
+
+// GetProductFactory es como GetComputerFactory, pero el constructor que retorna
+// recibe opciones funcionales en lugar de argumentos posicionales: solo se indica
+// lo que difiere de los valores por defecto, y agregar una opción nueva no cambia
+// la firma de ningún constructor registrado.
+func GetProductFactory(productType string) (OptionsConstructor, error) {
+	ctor, err := GetComputerFactory(productType)
+	if err != nil {
+		return nil, err
+	}
+	return func(name string, opts ...ProductOption) (IProduct, error) {
+		options := productOptions{warranty: defaultWarranty}
+		for _, opt := range opts {
+			opt(&options)
+		}
+
+		if options.warranty < 0 {
+			// Se valida antes de llamar al constructor para no consumir un SKU
+			warrantyErr := &ValidationError{Field: "warranty", Value: options.warranty, Err: ErrInvalidWarranty}
+			return nil, errors.Join(validateProduct(name, options.stock, options.price), warrantyErr)
+		}
+		product, err := ctor(name, options.stock, options.price)
+		if err != nil {
+			return nil, err
+		}
+
+		configurable, ok := product.(interface{ setWarranty(int) }) // Lo cumplen los productos que embeben Computer
+		if !ok {
+			return nil, fmt.Errorf("❌ el tipo %s no admite garantía", productType)
+		}
+		configurable.setWarranty(options.warranty)
+		for key, value := range options.attributes {
+			product.SetAttribute(key, value)
+		}
+		return product, nil
+	}, nil
+}
@@ -0,0 +1,183 @@
+package factory
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// IProduct define la interfaz común para todos los productos que puede crear la factory
+// Establece el contrato que deben cumplir todos los productos concretos
+type IProduct interface {
+	Type() string // Tipo con el que se registró el producto ("laptop", "desktop", ...)
+	SKU() string  // Código único del producto, asignado al crearlo
+	Name() string
+	SetName(name string)
+	Stock() int
+	SetStock(stock int)
+	Price() float64
+	SetPrice(price float64)
+	Specs() Specs
+	Warranty() int // Meses de garantía
+	Attribute(key string) string
+	SetAttribute(key, value string)
+	Clone() IProduct // Copia profunda con un SKU nuevo (patrón Prototype)
+	String() string
+}
+
+// Computer es la estructura base que contiene los campos comunes
+// para todos los tipos de computadoras. Los productos concretos la embeben
+// y así implementan IProduct sin repetir código.
+type Computer struct {
+	kind       string
+	skuPrefix  string
+	sku        string
+	name       string
+	stock      int
+	price      float64
+	specs      Specs
+	warranty   int               // Meses
+	attributes map[string]string // Color, teclado, etc.
+}
+
+// Specs es la configuración técnica de una computadora. Los modelos complejos
+// se arman con ComputerBuilder.
+type Specs struct {
+	CPU       string `json:"cpu"`
+	RAMGB     int    `json:"ram_gb"`
+	GPU       string `json:"gpu,omitempty"` // Vacío si usa gráficos integrados
+	StorageGB int    `json:"storage_gb"`
+}
+
+// String muestra las especificaciones en una línea, por ejemplo:
+// Ryzen 9 7950X / 64GB RAM / RTX 4090 / 2048GB
+func (s Specs) String() string {
+	gpu := s.GPU
+	if gpu == "" {
+		gpu = "gráficos integrados"
+	}
+	return fmt.Sprintf("%s / %dGB RAM / %s / %dGB", s.CPU, s.RAMGB, gpu, s.StorageGB)
+}
+
+// skuSequences lleva el último número de SKU asignado por prefijo.
+var skuSequences syncutil.SafeCounter[string]
+
+// NewComputer valida y crea la parte común de un producto. Es exportada para que los
+// productos definidos en otros paquetes puedan embeber Computer. El SKU se arma con
+// skuPrefix y un número consecutivo por prefijo, por ejemplo LAP-0001; los productos
+// inválidos no consumen un número.
+func NewComputer(kind, skuPrefix, name string, stock int, price float64) (Computer, error) {
+	if err := validateProduct(name, stock, price); err != nil {
+		return Computer{}, err
+	}
+	return Computer{
+		kind:      kind,
+		skuPrefix: skuPrefix,
+		sku:       newSKU(skuPrefix),
+		name:      name,
+		stock:     stock,
+		price:     price,
+		warranty:  defaultWarranty,
+	}, nil
+}
+
+// newSKU genera el siguiente SKU de un prefijo.
+func newSKU(prefix string) string {
+	return fmt.Sprintf("%s-%04d", prefix, skuSequences.Inc(prefix))
+}
+
+// CloneComputer copia la parte común del producto para implementar Clone.
+// La copia es profunda (el map de atributos no se comparte) y recibe un SKU nuevo,
+// porque es otro producto. Cada producto concreto la usa así:
+//
+//	func (l *Laptop) Clone() IProduct {
+//		return &Laptop{Computer: l.CloneComputer()}
+//	}
+func (c *Computer) CloneComputer() Computer {
+	clone := *c
+	clone.sku = newSKU(c.skuPrefix)
+	clone.attributes = maps.Clone(c.attributes)
+	return clone
+}
+
+func (c *Computer) Type() string {
+	return c.kind
+}
+
+func (c *Computer) SKU() string {
+	return c.sku
+}
+
+func (c *Computer) Name() string {
+	return c.name
+}
+
+func (c *Computer) SetName(name string) {
+	c.name = name
+}
+
+func (c *Computer) Stock() int {
+	return c.stock
+}
+
+func (c *Computer) SetStock(stock int) {
+	c.stock = stock
+}
+
+func (c *Computer) Price() float64 {
+	return c.price
+}
+
+func (c *Computer) SetPrice(price float64) {
+	c.price = price
+}
+
+func (c *Computer) Specs() Specs {
+	return c.specs
+}
+
+// setSpecs es usada por ComputerBuilder. Al ser un método no exportado de Computer,
+// solo este paquete puede cambiar las especificaciones de un producto.
+func (c *Computer) setSpecs(specs Specs) {
+	c.specs = specs
+}
+
+func (c *Computer) Warranty() int {
+	return c.warranty
+}
+
+// setWarranty es usada por las opciones de GetProductFactory.
+func (c *Computer) setWarranty(months int) {
+	c.warranty = months
+}
+
+func (c *Computer) Attribute(key string) string {
+	return c.attributes[key]
+}
+
+func (c *Computer) SetAttribute(key, value string) {
+	if c.attributes == nil {
+		c.attributes = make(map[string]string)
+	}
+	c.attributes[key] = value
+}
+
+// String muestra el producto en una línea, por ejemplo:
+// [LAP-0001] MacBook Pro (laptop) 📊 10 💲 2499.99 {color: plata}
+func (c *Computer) String() string {
+	s := fmt.Sprintf("[%s] %s (%s) 📊 %d 💲 %.2f", c.sku, c.name, c.kind, c.stock, c.price)
+	if c.specs != (Specs{}) {
+		s += " ⚙️ " + c.specs.String()
+	}
+	if len(c.attributes) == 0 {
+		return s
+	}
+	attributes := make([]string, 0, len(c.attributes))
+	for _, key := range slices.Sorted(maps.Keys(c.attributes)) {
+		attributes = append(attributes, key+": "+c.attributes[key])
+	}
+	return s + " {" + strings.Join(attributes, ", ") + "}"
+}
@@ -0,0 +1,36 @@
+package factory
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// prototypes guarda productos preconfigurados que sirven de plantilla.
+var prototypes syncutil.SafeMap[string, IProduct]
+
+// RegisterPrototype guarda prototype como plantilla con el nombre dado,
+// reemplazando la anterior si existía. Se guarda una copia, así que modificar
+// prototype después no cambia la plantilla.
+func RegisterPrototype(name string, prototype IProduct) {
+	prototypes.Set(name, prototype.Clone())
+}
+
+// FromPrototype crea un producto clonando la plantilla name (patrón Prototype).
+// A diferencia de los constructores, no hace falta conocer ni repetir la
+// configuración: el clon ya trae nombre, precio y atributos de la plantilla.
+func FromPrototype(name string) (IProduct, error) {
+	prototype, ok := prototypes.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("❌ Unknown prototype: %s", name)
+	}
+	return prototype.Clone(), nil
+}
+
+// RegisteredPrototypes retorna los nombres de las plantillas, en orden alfabético.
+func RegisteredPrototypes() []string {
+	names := prototypes.Keys()
+	slices.Sort(names)
+	return names
+}
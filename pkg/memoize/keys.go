@@ -0,0 +1,51 @@
+package memoize
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// KeyFunc construye una clave comparable a partir de los argumentos de una función.
+// Permite memoizar funciones cuyos argumentos no sirven directamente como clave de un mapa
+// (varios parámetros, slices, mapas, structs con campos no comparables...).
+type KeyFunc[A any, K comparable] func(args A) K
+
+// MemoizeBy envuelve fn usando keyOf para obtener la clave de cache de cada llamada.
+// Dos llamadas con la misma clave comparten el resultado aunque sus argumentos sean
+// valores distintos, así que keyOf debe capturar todo lo que afecta al resultado.
+func MemoizeBy[A any, K comparable, V any](fn func(A) (V, error), keyOf KeyFunc[A, K], opts ...Option) func(A) (V, error) {
+	memory := NewMemory[K, V](nil, opts...)
+	return func(args A) (V, error) {
+		return memory.get(keyOf(args), func() (V, error) { return fn(args) })
+	}
+}
+
+// pair es la clave compuesta que usa Memoize2: un struct de campos comparables
+// también es comparable y sirve como clave de mapa sin necesidad de hashing.
+type pair[A, B comparable] struct {
+	first  A
+	second B
+}
+
+// Memoize2 envuelve una función de dos argumentos comparables.
+func Memoize2[A, B comparable, V any](fn func(A, B) (V, error), opts ...Option) func(A, B) (V, error) {
+	memoized := MemoizeBy(
+		func(p pair[A, B]) (V, error) { return fn(p.first, p.second) },
+		func(p pair[A, B]) pair[A, B] { return p },
+		opts...,
+	)
+	return func(a A, b B) (V, error) {
+		return memoized(pair[A, B]{a, b})
+	}
+}
+
+// HashKey resume cualquier lista de argumentos en una clave string (hash FNV-64a de su
+// representación %#v). Sirve para argumentos no comparables como slices o mapas.
+// Los punteros se representan por su dirección, no por el valor al que apuntan.
+func HashKey(args ...any) string {
+	hash := fnv.New64a()
+	for _, arg := range args {
+		fmt.Fprintf(hash, "%#v\x00", arg)
+	}
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
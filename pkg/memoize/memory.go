@@ -0,0 +1,355 @@
+// Package memoize cachea los resultados de funciones costosas.
+//
+// Incluye deduplicación de cálculos concurrentes (singleflight) con locks por
+// clave, almacenamiento
+// intercambiable (memoria, archivos o un cache estilo Redis), estadísticas,
+// invalidación, persistencia en disco y precálculo en segundo plano.
+package memoize
+
+import (
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// CacheableFunction define el tipo de función que puede ser cacheada.
+type CacheableFunction[K comparable, V any] func(key K) (V, error)
+
+// CachedFunctionResult es un tipo que representa el resultado de una función cacheada.
+type CachedFunctionResult[V any] struct {
+	value     V             // Valor calculado por la función
+	err       error         // Error retornado por la función
+	duration  time.Duration // Tiempo que tardó el cálculo (lo que ahorra cada acierto)
+	expiresAt time.Time     // Momento en que deja de ser válido (cero = nunca expira)
+}
+
+// expired indica si el resultado ya no debe servirse desde el cache.
+func (r CachedFunctionResult[V]) expired() bool {
+	return !r.expiresAt.IsZero() && time.Now().After(r.expiresAt)
+}
+
+// ErrNotFound indica que la función no tiene resultado para una clave (p. ej. un
+// usuario que no existe). Con WithNegativeTTL estos "no hay resultado" se cachean
+// solo durante un tiempo corto.
+var ErrNotFound = errors.New("memoize: no hay resultado para la clave")
+
+// inflightCall representa un cálculo en curso para una clave.
+// Todas las goroutines que piden la misma clave mientras se calcula esperan
+// en wg y comparten el mismo resultado (técnica singleflight).
+type inflightCall[V any] struct {
+	wg     sync.WaitGroup          // Se libera cuando el cálculo termina
+	result CachedFunctionResult[V] // Resultado compartido con los que esperan
+}
+
+// PanicError es el error que reciben todos los llamadores cuando la función memoizada
+// hace panic. El resultado no se guarda en el cache: el siguiente Get vuelve a intentarlo.
+type PanicError struct {
+	Value any    // Valor pasado a panic
+	Stack []byte // Stack trace de la goroutine que hizo panic
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("memoize: la función hizo panic: %v", e.Value)
+}
+
+// options agrupa la configuración opcional de Memory.
+type options struct {
+	logging bool                         // Imprime si cada Get fue cacheado, calculado o compartido
+	store   any                          // Store[K, V] a usar; nil significa un MemoryStore nuevo
+	onHit   func(saved time.Duration)    // Hook al servir un valor sin calcularlo
+	onMiss  func(duration time.Duration) // Hook al terminar un cálculo
+
+	negativeTTL time.Duration // Vida de los resultados ErrNotFound (0 = igual que el resto)
+	shards      int           // Particiones de locks por clave (0 = defaultShards)
+
+	autoSavePath        string // Archivo para cargar al crear y guardar al cerrar ("" = desactivado)
+	prefetchParallelism int    // Cálculos simultáneos de Prefetch (0 = número de CPUs)
+}
+
+// Option modifica la configuración de Memory (patrón functional options).
+type Option func(*options)
+
+// WithLogging activa o desactiva los mensajes [✅Cacheado]/[⚙️Calculado] de Get.
+func WithLogging(enabled bool) Option {
+	return func(o *options) {
+		o.logging = enabled
+	}
+}
+
+// WithOnHit registra un hook que se ejecuta cada vez que Get evita un cálculo.
+// Recibe el tiempo ahorrado respecto a calcular el valor de nuevo.
+func WithOnHit(hook func(saved time.Duration)) Option {
+	return func(o *options) {
+		o.onHit = hook
+	}
+}
+
+// WithOnMiss registra un hook que se ejecuta cada vez que Get tiene que calcular un valor.
+// Recibe lo que tardó la función.
+func WithOnMiss(hook func(duration time.Duration)) Option {
+	return func(o *options) {
+		o.onMiss = hook
+	}
+}
+
+// WithNegativeTTL cachea los resultados que fallan con ErrNotFound solo durante ttl.
+// Así las búsquedas repetidas de datos inexistentes no llegan a la función, pero
+// cuando el dato aparezca se vuelve a consultar pronto.
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.negativeTTL = ttl
+	}
+}
+
+// Stats resume el uso del cache de una Memory.
+type Stats struct {
+	Hits      int           // Consultas servidas desde el store
+	Negative  int           // Aciertos que eran un ErrNotFound cacheado (incluidos en Hits)
+	Shared    int           // Consultas que esperaron un cálculo en curso de otra goroutine
+	Misses    int           // Consultas que ejecutaron la función
+	TimeSpent time.Duration // Tiempo total ejecutando la función
+	TimeSaved time.Duration // Tiempo que se habría gastado de más sin el cache
+}
+
+type Memory[K comparable, V any] struct {
+	f       CacheableFunction[K, V]   // Función a cachear
+	store   Store[K, V]               // Almacenamiento de los resultados cacheados
+	shards  []*shard[K, V]            // Locks y cálculos en curso, repartidos por clave
+	seed    maphash.Seed              // Semilla para repartir claves entre shards
+	tags    map[string]map[K]struct{} // Claves asociadas a cada etiqueta (ver Tag)
+	mu      sync.Mutex                // Protege stats y tags; se toma siempre después del lock de un shard
+	options options                   // Configuración opcional
+	stats   Stats                     // Contadores de aciertos, fallos y tiempo ahorrado
+
+	stopExitWatch func() // Detiene la goroutine de auto-guardado (ver WithAutoSave)
+}
+
+// NewMemory crea una instancia de Memory con la función a cachear.
+func NewMemory[K comparable, V any](f CacheableFunction[K, V], opts ...Option) *Memory[K, V] {
+	o := options{logging: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var store Store[K, V] = NewMemoryStore[K, V]()
+	if o.store != nil {
+		typed, ok := o.store.(Store[K, V])
+		if !ok {
+			panic(fmt.Sprintf("❌ WithStore: %T no es un Store[%T, %T]", o.store, *new(K), *new(V)))
+		}
+		store = typed
+	}
+
+	m := &Memory[K, V]{
+		f:       f,
+		store:   store,
+		shards:  newShards[K, V](o.shards),
+		seed:    maphash.MakeSeed(),
+		tags:    make(map[string]map[K]struct{}),
+		options: o,
+	}
+	if o.autoSavePath != "" {
+		m.startAutoSave()
+	}
+	return m
+}
+
+// WithStore indica a Memory dónde guardar los resultados.
+// Los tipos K y V del store deben coincidir con los de la función memoizada.
+func WithStore[K comparable, V any](store Store[K, V]) Option {
+	return func(o *options) {
+		o.store = store
+	}
+}
+
+// Memoize envuelve fn con un cache (en memoria, salvo que se use WithStore) y retorna una función con la misma firma.
+// Es un decorador funcional: el llamador no necesita construir Memory explícitamente.
+func Memoize[K comparable, V any](fn func(K) (V, error), opts ...Option) func(K) (V, error) {
+	return NewMemory(CacheableFunction[K, V](fn), opts...).Get
+}
+
+// Get retorna el valor cacheado para una clave. Si no existe, lo calcula y lo almacena.
+// Si otra goroutine ya está calculando la misma clave, espera su resultado en lugar
+// de repetir el cálculo. Los errores (salvo ErrNotFound) no se almacenan: el siguiente
+// Get vuelve a intentarlo.
+func (m *Memory[K, V]) Get(key K) (V, error) {
+	return m.get(key, func() (V, error) { return m.f(key) })
+}
+
+// get implementa Get con una función de cálculo explícita, para que las claves
+// compuestas (ver MemoizeBy) puedan calcular a partir de los argumentos originales.
+func (m *Memory[K, V]) get(key K, compute func() (V, error)) (V, error) {
+	shard := m.shardFor(key)
+	shard.mu.Lock()
+	result, isCached := m.store.Get(key)
+	if isCached && result.expired() {
+		m.invalidate(shard, key)
+		isCached = false
+	}
+	if isCached {
+		shard.keys[key] = struct{}{} // Puede venir de un store compartido con otra Memory
+		shard.mu.Unlock()
+
+		m.mu.Lock()
+		m.stats.Hits++
+		if errors.Is(result.err, ErrNotFound) {
+			m.stats.Negative++
+		}
+		m.stats.TimeSaved += result.duration
+		m.mu.Unlock()
+		m.log("[✅Cacheado]")
+		m.hit(result.duration)
+		return result.value, result.err
+	}
+	if call, isInProgress := shard.inProgress[key]; isInProgress {
+		shard.mu.Unlock()
+		m.log("[⏳Esperando cálculo en curso]")
+		start := time.Now()
+		call.wg.Wait()
+		// Solo se ahorra la parte del cálculo que ya había ocurrido antes de llegar
+		saved := max(call.result.duration-time.Since(start), 0)
+
+		m.mu.Lock()
+		m.stats.Shared++
+		m.stats.TimeSaved += saved
+		m.mu.Unlock()
+		m.hit(saved)
+		return call.result.value, call.result.err
+	}
+	// Nadie está calculando esta clave: registramos el cálculo en curso
+	call := &inflightCall[V]{}
+	call.wg.Add(1)
+	shard.inProgress[key] = call
+	shard.mu.Unlock()
+
+	// Calcula el valor fuera del lock para no bloquear otras claves
+	start := time.Now()
+	call.result.value, call.result.err = safeCompute(compute)
+	call.result.duration = time.Since(start)
+	if m.options.negativeTTL > 0 && errors.Is(call.result.err, ErrNotFound) {
+		call.result.expiresAt = time.Now().Add(m.options.negativeTTL)
+	}
+
+	// Se guarda antes de quitar el registro en curso: quien llegue después
+	// encontrará el valor en el store o seguirá esperando en call.wg.
+	// Un panic o un error nunca se guardan: no son un resultado, son un fallo de este
+	// intento. La excepción es ErrNotFound, que sí es una respuesta (ver WithNegativeTTL).
+	var panicErr *PanicError
+	stored := false
+	if errors.As(call.result.err, &panicErr) {
+		m.log(fmt.Sprintf("[💥Panic recuperado: %v]", panicErr.Value))
+	} else if call.result.err != nil && !errors.Is(call.result.err, ErrNotFound) {
+		m.log("[❌Error, no se cachea]")
+	} else if err := m.store.Set(key, call.result); err != nil {
+		m.log(fmt.Sprintf("[❌Error guardando en el store: %v]", err))
+	} else {
+		stored = true
+	}
+
+	shard.mu.Lock()
+	if stored {
+		shard.keys[key] = struct{}{}
+	}
+	delete(shard.inProgress, key)
+	shard.mu.Unlock()
+	call.wg.Done() // Despierta a todos los que esperaban este resultado
+
+	m.mu.Lock()
+	m.stats.Misses++
+	m.stats.TimeSpent += call.result.duration
+	m.mu.Unlock()
+
+	if panicErr == nil {
+		m.log("[⚙️Calculado]")
+	}
+	if m.options.onMiss != nil {
+		m.options.onMiss(call.result.duration)
+	}
+	return call.result.value, call.result.err
+}
+
+// safeCompute ejecuta compute convirtiendo un posible panic en un *PanicError,
+// para que el registro en curso siempre se libere y nadie quede esperando.
+func safeCompute[V any](compute func() (V, error)) (value V, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = &PanicError{Value: recovered, Stack: debug.Stack()}
+		}
+	}()
+	return compute()
+}
+
+// Invalidate elimina el resultado cacheado de una clave para que el próximo Get la recalcule.
+// Retorna true si había un resultado guardado.
+func (m *Memory[K, V]) Invalidate(key K) bool {
+	shard := m.shardFor(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	return m.invalidate(shard, key)
+}
+
+// InvalidateAll elimina todos los resultados guardados por esta Memory.
+// Retorna cuántos se eliminaron.
+func (m *Memory[K, V]) InvalidateAll() int {
+	return m.InvalidateWhere(func(K, V) bool { return true })
+}
+
+// InvalidateWhere elimina los resultados para los que predicate retorna true.
+// Útil cuando cambian los datos de los que dependen algunos cálculos.
+// Retorna cuántos se eliminaron.
+func (m *Memory[K, V]) InvalidateWhere(predicate func(key K, value V) bool) int {
+	removed := 0
+	for _, shard := range m.shards {
+		shard.mu.Lock()
+		for key := range shard.keys {
+			result, exists := m.store.Get(key)
+			if !exists {
+				delete(shard.keys, key) // Expiró o lo borró otra Memory que comparte el store
+				continue
+			}
+			if predicate(key, result.value) && m.invalidate(shard, key) {
+				removed++
+			}
+		}
+		shard.mu.Unlock()
+	}
+	return removed
+}
+
+// invalidate elimina una clave del store. Debe llamarse con el lock de su shard tomado.
+func (m *Memory[K, V]) invalidate(shard *shard[K, V], key K) bool {
+	_, exists := m.store.Get(key)
+	if exists {
+		if err := m.store.Delete(key); err != nil {
+			m.log(fmt.Sprintf("[❌Error eliminando del store: %v]", err))
+			return false
+		}
+	}
+	delete(shard.keys, key)
+	m.untag(key)
+	return exists
+}
+
+// Stats retorna una copia de las estadísticas acumuladas.
+func (m *Memory[K, V]) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
+
+// hit ejecuta el hook OnHit si está configurado.
+func (m *Memory[K, V]) hit(saved time.Duration) {
+	if m.options.onHit != nil {
+		m.options.onHit(saved)
+	}
+}
+
+// log imprime un mensaje solo si el logging está activado.
+func (m *Memory[K, V]) log(message string) {
+	if m.options.logging {
+		fmt.Println(message)
+	}
+}
@@ -0,0 +1,188 @@
+package memoize
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errBoom = errors.New("boom")
+
+// counted retorna una función que cuenta sus llamadas y responde según key:
+// negativos fallan con errBoom, 0 con ErrNotFound y el resto retorna key*2.
+func counted(calls *atomic.Int64) CacheableFunction[int, int] {
+	return func(key int) (int, error) {
+		calls.Add(1)
+		switch {
+		case key < 0:
+			return 0, errBoom
+		case key == 0:
+			return 0, ErrNotFound
+		}
+		return key * 2, nil
+	}
+}
+
+func TestMemoryGet(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []int // Se piden en orden
+		wantCalls int64
+		wantValue int   // Resultado del último Get
+		wantErr   error // Error del último Get
+		wantStats Stats
+	}{
+		{"miss", []int{3}, 1, 6, nil, Stats{Misses: 1}},
+		{"hit", []int{3, 3, 3}, 1, 6, nil, Stats{Misses: 1, Hits: 2}},
+		{"claves distintas", []int{1, 2, 1}, 2, 2, nil, Stats{Misses: 2, Hits: 1}},
+		{"error no cacheado", []int{-1, -1}, 2, 0, errBoom, Stats{Misses: 2}},
+		{"ErrNotFound cacheado", []int{0, 0}, 1, 0, ErrNotFound, Stats{Misses: 1, Hits: 1, Negative: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int64
+			m := NewMemory(counted(&calls), WithLogging(false))
+			var value int
+			var err error
+			for _, key := range tt.keys {
+				value, err = m.Get(key)
+			}
+			if value != tt.wantValue || !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Get = (%d, %v), quiero (%d, %v)", value, err, tt.wantValue, tt.wantErr)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("llamadas = %d, quiero %d", got, tt.wantCalls)
+			}
+			stats := m.Stats()
+			stats.TimeSpent, stats.TimeSaved = 0, 0
+			if stats != tt.wantStats {
+				t.Errorf("Stats = %+v, quiero %+v", stats, tt.wantStats)
+			}
+		})
+	}
+}
+
+func TestMemoryNegativeTTL(t *testing.T) {
+	var calls atomic.Int64
+	m := NewMemory(counted(&calls), WithLogging(false), WithNegativeTTL(20*time.Millisecond))
+
+	for range 3 {
+		if _, err := m.Get(0); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Get(0) = %v, quiero ErrNotFound", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("antes de expirar: %d llamadas, quiero 1", got)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if _, err := m.Get(0); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get(0) = %v, quiero ErrNotFound", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("después de expirar: %d llamadas, quiero 2", got)
+	}
+
+	// Los valores normales no expiran con WithNegativeTTL
+	_, _ = m.Get(5)
+	time.Sleep(30 * time.Millisecond)
+	_, _ = m.Get(5)
+	if got := calls.Load(); got != 3 {
+		t.Errorf("valor normal: %d llamadas, quiero 3", got)
+	}
+}
+
+func TestMemoryConcurrentCallersShareComputation(t *testing.T) {
+	const callers = 50
+	var calls atomic.Int64
+	release := make(chan struct{})
+	m := NewMemory(func(key int) (int, error) {
+		calls.Add(1)
+		<-release // Todas las goroutines llegan mientras el cálculo sigue en curso
+		return key * 2, nil
+	}, WithLogging(false))
+
+	var wg sync.WaitGroup
+	results := make(chan int, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := m.Get(21)
+			if err != nil {
+				t.Errorf("Get: %v", err)
+			}
+			results <- value
+		}()
+	}
+	// Las que lleguen antes de liberar esperan el cálculo en curso; las que lleguen
+	// después encuentran el valor cacheado. En ningún caso se repite el cálculo.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for value := range results {
+		if value != 42 {
+			t.Errorf("valor = %d, quiero 42", value)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("la función se ejecutó %d veces, quiero 1", got)
+	}
+	if stats := m.Stats(); stats.Misses != 1 || stats.Shared+stats.Hits != callers-1 {
+		t.Errorf("Stats = %+v, quiero 1 cálculo y %d consultas compartidas o cacheadas", stats, callers-1)
+	}
+}
+
+func TestMemoryPanicReachesEveryWaiter(t *testing.T) {
+	const callers = 20
+	var calls atomic.Int64
+	var panicking atomic.Bool
+	panicking.Store(true)
+	release := make(chan struct{})
+	m := NewMemory(func(key int) (int, error) {
+		calls.Add(1)
+		<-release
+		if panicking.Load() {
+			panic("sin conexión")
+		}
+		return key * 2, nil
+	}, WithLogging(false))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := m.Get(21)
+			errs <- err
+		}()
+	}
+	time.Sleep(20 * time.Millisecond) // Todas llegan mientras el cálculo sigue en curso
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		var panicErr *PanicError
+		if !errors.As(err, &panicErr) || panicErr.Value != "sin conexión" {
+			t.Errorf("err = %v, quiero un *PanicError con el valor del panic", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("la función se ejecutó %d veces, quiero 1", got)
+	}
+
+	// El panic no se cachea: el siguiente Get vuelve a ejecutar la función
+	panicking.Store(false)
+	if got, err := m.Get(21); got != 42 || err != nil {
+		t.Errorf("Get tras el panic = (%d, %v), quiero (42, <nil>)", got, err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("tras el panic: %d llamadas, quiero 2", got)
+	}
+}
@@ -0,0 +1,122 @@
+package memoize
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// savedEntry es la representación en disco de un resultado guardado por Save.
+type savedEntry[K comparable, V any] struct {
+	Key   K
+	Entry fileEntry[V]
+}
+
+// WithAutoSave hace que Memory cargue los resultados de path al crearse y los guarde
+// al llamar a Close o al recibir Ctrl+C/SIGTERM, para que sobrevivan entre ejecuciones.
+func WithAutoSave(path string) Option {
+	return func(o *options) {
+		o.autoSavePath = path
+	}
+}
+
+// Save escribe en path (con gob) todos los resultados guardados por esta Memory.
+func (m *Memory[K, V]) Save(path string) error {
+	var entries []savedEntry[K, V]
+	for _, shard := range m.shards {
+		shard.mu.Lock()
+		for key := range shard.keys {
+			if result, exists := m.store.Get(key); exists {
+				entries = append(entries, savedEntry[K, V]{Key: key, Entry: newFileEntry(result)})
+			}
+		}
+		shard.mu.Unlock()
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("❌ No se pudo guardar el cache: %w", err)
+	}
+	defer file.Close()
+
+	if err := gob.NewEncoder(file).Encode(entries); err != nil {
+		return fmt.Errorf("❌ No se pudo codificar el cache: %w", err)
+	}
+	return nil
+}
+
+// Load lee de path los resultados escritos por Save y los agrega al store.
+// Retorna cuántos resultados se cargaron.
+func (m *Memory[K, V]) Load(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("❌ No se pudo abrir el cache: %w", err)
+	}
+	defer file.Close()
+
+	var entries []savedEntry[K, V]
+	if err := gob.NewDecoder(file).Decode(&entries); err != nil {
+		return 0, fmt.Errorf("❌ No se pudo decodificar el cache: %w", err)
+	}
+
+	for i, saved := range entries {
+		shard := m.shardFor(saved.Key)
+		shard.mu.Lock()
+		err := m.store.Set(saved.Key, saved.Entry.result())
+		if err == nil {
+			shard.keys[saved.Key] = struct{}{}
+		}
+		shard.mu.Unlock()
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(entries), nil
+}
+
+// Close guarda el cache si se configuró WithAutoSave y deja de escuchar las señales de salida.
+// Sin WithAutoSave no hace nada. Se puede llamar más de una vez: cada llamada vuelve a guardar.
+func (m *Memory[K, V]) Close() error {
+	if m.options.autoSavePath == "" {
+		return nil
+	}
+	m.stopExitWatch()
+	return m.Save(m.options.autoSavePath)
+}
+
+// startAutoSave carga el archivo de auto-guardado (si existe) y lanza una goroutine que
+// guarda el cache antes de terminar el proceso por Ctrl+C o SIGTERM.
+func (m *Memory[K, V]) startAutoSave() {
+	path := m.options.autoSavePath
+	if loaded, err := m.Load(path); err == nil {
+		m.log(fmt.Sprintf("[📂Cargados %d resultados de %s]", loaded, path))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		m.log(err.Error())
+	}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	var once sync.Once // Close puede llamarse más de una vez: done solo se cierra la primera
+	m.stopExitWatch = func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+
+	go func() {
+		select {
+		case <-signals:
+			if err := m.Save(path); err != nil {
+				fmt.Println(err)
+			}
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+}
@@ -0,0 +1,57 @@
+package memoize
+
+import (
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCloseTwice(t *testing.T) {
+	var calls atomic.Int64
+	path := filepath.Join(t.TempDir(), "cache.gob")
+	m := NewMemory(counted(&calls), WithLogging(false), WithAutoSave(path))
+	if _, err := m.Get(3); err != nil {
+		t.Fatalf("Get(3): %v", err)
+	}
+
+	for n := range 2 {
+		if err := m.Close(); err != nil {
+			t.Fatalf("Close #%d: %v", n+1, err)
+		}
+	}
+
+	restored := NewMemory(counted(&calls), WithLogging(false), WithAutoSave(path))
+	defer restored.Close()
+	if got, err := restored.Get(3); err != nil || got != 6 {
+		t.Errorf("Get(3) tras cargar = (%d, %v), quiero (6, <nil>)", got, err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("la función se ejecutó %d veces, quiero 1", got)
+	}
+}
+
+func TestSaveLoadKeepsErrNotFound(t *testing.T) {
+	var calls atomic.Int64
+	path := filepath.Join(t.TempDir(), "cache.gob")
+	m := NewMemory(counted(&calls), WithLogging(false))
+	m.Get(0)
+	m.Get(3)
+	if err := m.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	restored := NewMemory(counted(&calls), WithLogging(false))
+	if loaded, err := restored.Load(path); err != nil || loaded != 2 {
+		t.Fatalf("Load = (%d, %v), quiero (2, <nil>)", loaded, err)
+	}
+	if _, err := restored.Get(0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(0) tras cargar: err = %v, quiero ErrNotFound", err)
+	}
+	if got, err := restored.Get(3); err != nil || got != 6 {
+		t.Errorf("Get(3) tras cargar = (%d, %v), quiero (6, <nil>)", got, err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("la función se ejecutó %d veces, quiero 2", got)
+	}
+}
@@ -0,0 +1,75 @@
+package memoize
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/syncutil"
+)
+
+// WithPrefetchParallelism limita cuántas claves calcula Prefetch a la vez.
+// Por defecto se usa el número de CPUs.
+func WithPrefetchParallelism(n int) Option {
+	return func(o *options) {
+		o.prefetchParallelism = n
+	}
+}
+
+// PrefetchHandle permite esperar (o hacer select) a que termine un Prefetch.
+type PrefetchHandle struct {
+	done chan struct{} // Se cierra cuando todas las claves están calculadas
+	err  error         // Errores de todas las claves, unidos con errors.Join
+}
+
+// Done retorna un canal que se cierra cuando el precálculo termina.
+func (h *PrefetchHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait bloquea hasta que el precálculo termina y retorna los errores de las claves que fallaron.
+func (h *PrefetchHandle) Wait() error {
+	<-h.done
+	return h.err
+}
+
+// Prefetch calcula y guarda en segundo plano los valores de keys, con como máximo
+// WithPrefetchParallelism cálculos simultáneos. Sirve para "calentar" el cache antes
+// de medir consultas. Las claves ya cacheadas o en curso no se recalculan.
+func (m *Memory[K, V]) Prefetch(keys ...K) *PrefetchHandle {
+	parallelism := m.options.prefetchParallelism
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+
+	handle := &PrefetchHandle{done: make(chan struct{})}
+	slots := syncutil.NewSemaphore(parallelism) // Un lugar por cálculo simultáneo
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots.Acquire(context.Background(), 1)
+			defer slots.Release(1)
+
+			if _, err := m.Get(key); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		handle.err = errors.Join(errs...)
+		close(handle.done)
+	}()
+	return handle
+}
@@ -0,0 +1,48 @@
+package memoize
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// defaultShards es el número de particiones de locks si no se usa WithShards.
+const defaultShards = 32
+
+// shard agrupa un subconjunto de claves con su propio lock (lock striping).
+// Dos claves en particiones distintas nunca se bloquean entre sí, así que
+// Fibonacci(35) y Fibonacci(44) avanzan en paralelo mientras que dos
+// Get(44) simultáneos siguen compartiendo un único cálculo.
+type shard[K comparable, V any] struct {
+	mu         sync.Mutex             // Protege inProgress, keys y la consulta al store de estas claves
+	inProgress map[K]*inflightCall[V] // Cálculos en curso por clave
+	keys       map[K]struct{}         // Claves guardadas en el store por esta Memory
+}
+
+// WithShards define en cuántas particiones se reparten los locks por clave.
+// Más particiones reducen la contención entre claves distintas.
+func WithShards(n int) Option {
+	return func(o *options) {
+		o.shards = n
+	}
+}
+
+// newShards crea n particiones vacías.
+func newShards[K comparable, V any](n int) []*shard[K, V] {
+	if n <= 0 {
+		n = defaultShards
+	}
+	shards := make([]*shard[K, V], n)
+	for i := range shards {
+		shards[i] = &shard[K, V]{
+			inProgress: make(map[K]*inflightCall[V]),
+			keys:       make(map[K]struct{}),
+		}
+	}
+	return shards
+}
+
+// shardFor retorna la partición a la que pertenece key.
+func (m *Memory[K, V]) shardFor(key K) *shard[K, V] {
+	hash := maphash.Comparable(m.seed, key)
+	return m.shards[hash%uint64(len(m.shards))]
+}
@@ -0,0 +1,211 @@
+package memoize
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Store define dónde guarda Memory los resultados calculados.
+// Separar el almacenamiento de la lógica de memoización permite reutilizar
+// la misma Memory contra un mapa en memoria, archivos en disco o un cache tipo Redis.
+type Store[K comparable, V any] interface {
+	Get(key K) (CachedFunctionResult[V], bool)       // Retorna el resultado y si existía
+	Set(key K, result CachedFunctionResult[V]) error // Guarda (o reemplaza) un resultado
+	Delete(key K) error                              // Elimina un resultado si existe
+}
+
+// 1. Store en memoria
+
+// MemoryStore guarda los resultados en un mapa protegido por un RWMutex.
+// Es el store por defecto de Memory.
+type MemoryStore[K comparable, V any] struct {
+	data map[K]CachedFunctionResult[V]
+	mu   sync.RWMutex
+}
+
+// NewMemoryStore crea un store en memoria vacío.
+func NewMemoryStore[K comparable, V any]() *MemoryStore[K, V] {
+	return &MemoryStore[K, V]{
+		data: make(map[K]CachedFunctionResult[V]),
+	}
+}
+
+func (s *MemoryStore[K, V]) Get(key K) (CachedFunctionResult[V], bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result, exists := s.data[key]
+	return result, exists
+}
+
+func (s *MemoryStore[K, V]) Set(key K, result CachedFunctionResult[V]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = result
+	return nil
+}
+
+func (s *MemoryStore[K, V]) Delete(key K) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+	return nil
+}
+
+// 2. Store en archivos
+
+// fileEntry es la representación serializable de un resultado.
+// Los errores no se pueden codificar con gob, así que se guarda su mensaje y si era un
+// ErrNotFound, para que el error cargado siga cumpliendo errors.Is(err, ErrNotFound).
+type fileEntry[V any] struct {
+	Value     V
+	Err       string
+	NotFound  bool
+	Duration  time.Duration
+	ExpiresAt time.Time
+}
+
+// savedError es un error reconstruido a partir de un fileEntry.
+type savedError struct {
+	msg      string
+	notFound bool
+}
+
+func (e *savedError) Error() string {
+	return e.msg
+}
+
+func (e *savedError) Is(target error) bool {
+	return e.notFound && target == ErrNotFound
+}
+
+// newFileEntry convierte un resultado a su forma serializable.
+func newFileEntry[V any](result CachedFunctionResult[V]) fileEntry[V] {
+	entry := fileEntry[V]{Value: result.value, Duration: result.duration, ExpiresAt: result.expiresAt}
+	if result.err != nil {
+		entry.Err = result.err.Error()
+		entry.NotFound = errors.Is(result.err, ErrNotFound)
+	}
+	return entry
+}
+
+// result reconstruye el resultado a partir de su forma serializable.
+func (e fileEntry[V]) result() CachedFunctionResult[V] {
+	result := CachedFunctionResult[V]{value: e.Value, duration: e.Duration, expiresAt: e.ExpiresAt}
+	if e.Err != "" {
+		result.err = &savedError{msg: e.Err, notFound: e.NotFound}
+	}
+	return result
+}
+
+// FileStore guarda cada resultado en un archivo gob dentro de un directorio,
+// de modo que los valores sobreviven entre ejecuciones del programa.
+type FileStore[K comparable, V any] struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+// NewFileStore crea (si no existe) el directorio dir y retorna un store sobre él.
+func NewFileStore[K comparable, V any](dir string) (*FileStore[K, V], error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("❌ No se pudo crear el directorio del store: %w", err)
+	}
+	return &FileStore[K, V]{dir: dir}, nil
+}
+
+// path retorna la ruta del archivo asociado a una clave.
+func (s *FileStore[K, V]) path(key K) string {
+	return filepath.Join(s.dir, url.PathEscape(fmt.Sprint(key))+".gob")
+}
+
+func (s *FileStore[K, V]) Get(key K) (CachedFunctionResult[V], bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	file, err := os.Open(s.path(key))
+	if err != nil {
+		return CachedFunctionResult[V]{}, false
+	}
+	defer file.Close()
+
+	var entry fileEntry[V]
+	if err := gob.NewDecoder(file).Decode(&entry); err != nil {
+		return CachedFunctionResult[V]{}, false // Un archivo corrupto se trata como ausente
+	}
+	return entry.result(), true
+}
+
+func (s *FileStore[K, V]) Set(key K, result CachedFunctionResult[V]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Create(s.path(key))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return gob.NewEncoder(file).Encode(newFileEntry(result))
+}
+
+func (s *FileStore[K, V]) Delete(key K) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// 3. Store sobre un cache estilo Redis
+
+// RedisClient es el subconjunto de operaciones de un cache key-value estilo Redis
+// que necesita RedisStore. rediscache.SimpleRedisCache cumple esta interfaz.
+type RedisClient interface {
+	Set(key string, value any, ttl time.Duration)
+	Get(key string) (any, bool)
+	Delete(key string) bool
+}
+
+// RedisStore adapta un RedisClient a la interfaz Store.
+// Las claves se convierten a string con un prefijo para no chocar con otros datos.
+type RedisStore[K comparable, V any] struct {
+	client RedisClient
+	prefix string        // Prefijo de las claves, p. ej. "fibonacci:"
+	ttl    time.Duration // Tiempo de vida de cada resultado (0 = nunca expira)
+}
+
+// NewRedisStore crea un store que guarda los resultados en client.
+func NewRedisStore[K comparable, V any](client RedisClient, prefix string, ttl time.Duration) *RedisStore[K, V] {
+	return &RedisStore[K, V]{
+		client: client,
+		prefix: prefix,
+		ttl:    ttl,
+	}
+}
+
+func (s *RedisStore[K, V]) Get(key K) (CachedFunctionResult[V], bool) {
+	value, found := s.client.Get(s.prefix + fmt.Sprint(key))
+	if !found {
+		return CachedFunctionResult[V]{}, false
+	}
+	result, ok := value.(CachedFunctionResult[V])
+	return result, ok
+}
+
+func (s *RedisStore[K, V]) Set(key K, result CachedFunctionResult[V]) error {
+	s.client.Set(s.prefix+fmt.Sprint(key), result, s.ttl)
+	return nil
+}
+
+func (s *RedisStore[K, V]) Delete(key K) error {
+	s.client.Delete(s.prefix + fmt.Sprint(key))
+	return nil
+}
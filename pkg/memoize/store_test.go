@@ -0,0 +1,87 @@
+package memoize
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/afperdomo2/curso_go_patrones_diseno/pkg/rediscache"
+)
+
+func TestFileStoreKeepsErrors(t *testing.T) {
+	store, err := NewFileStore[int, int](t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		err          error
+		wantNotFound bool
+	}{
+		{"ErrNotFound", ErrNotFound, true},
+		{"ErrNotFound envuelto", fmt.Errorf("usuario 7: %w", ErrNotFound), true},
+		{"otro error", errBoom, false},
+	}
+	for key, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := store.Set(key, CachedFunctionResult[int]{err: tt.err}); err != nil {
+				t.Fatalf("Set: %v", err)
+			}
+			result, found := store.Get(key)
+			if !found {
+				t.Fatal("Get: no se encontró el resultado guardado")
+			}
+			if result.err == nil || result.err.Error() != tt.err.Error() {
+				t.Errorf("err = %v, quiero %v", result.err, tt.err)
+			}
+			if got := errors.Is(result.err, ErrNotFound); got != tt.wantNotFound {
+				t.Errorf("errors.Is(err, ErrNotFound) = %t, quiero %t", got, tt.wantNotFound)
+			}
+		})
+	}
+}
+
+// TestRedisStore memoiza sobre un SimpleRedisCache compartido: otra Memory con el mismo
+// prefijo reutiliza los resultados, y cuando vence el TTL se vuelven a calcular.
+func TestRedisStore(t *testing.T) {
+	cache := rediscache.NewSimpleRedisCache()
+	cache.SetLogging(false)
+	var calls atomic.Int64
+	newMemory := func() *Memory[int, int] {
+		store := NewRedisStore[int, int](cache, "doble:", 50*time.Millisecond)
+		return NewMemory(counted(&calls), WithLogging(false), WithStore[int, int](store))
+	}
+
+	first := newMemory()
+	if got, err := first.Get(3); got != 6 || err != nil {
+		t.Fatalf("Get(3) = (%d, %v), quiero (6, <nil>)", got, err)
+	}
+	if _, err := first.Get(0); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get(0): err = %v, quiero ErrNotFound", err)
+	}
+	if !cache.Exists("doble:3") || !cache.Exists("doble:0") {
+		t.Error("los resultados no quedaron en el cache con el prefijo \"doble:\"")
+	}
+
+	second := newMemory()
+	if got, err := second.Get(3); got != 6 || err != nil {
+		t.Errorf("Get(3) desde otra Memory = (%d, %v), quiero (6, <nil>)", got, err)
+	}
+	if _, err := second.Get(0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(0) desde otra Memory: err = %v, quiero ErrNotFound", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("la función se ejecutó %d veces, quiero 2: la segunda Memory usa el cache", got)
+	}
+
+	time.Sleep(60 * time.Millisecond) // Vence el TTL
+	if got, err := second.Get(3); got != 6 || err != nil {
+		t.Errorf("Get(3) tras el TTL = (%d, %v), quiero (6, <nil>)", got, err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("tras el TTL la función se ejecutó %d veces, quiero 3", got)
+	}
+}
@@ -0,0 +1,54 @@
+package memoize
+
+// Tag asocia una o más etiquetas a una clave, p. ej. el dataset del que se derivó su
+// resultado. Luego InvalidateTag descarta de una vez todas las claves de una etiqueta.
+func (m *Memory[K, V]) Tag(key K, tags ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, tag := range tags {
+		if m.tags[tag] == nil {
+			m.tags[tag] = make(map[K]struct{})
+		}
+		m.tags[tag][key] = struct{}{}
+	}
+}
+
+// GetWithTags es como Get pero además etiqueta la clave con tags.
+func (m *Memory[K, V]) GetWithTags(key K, tags ...string) (V, error) {
+	value, err := m.Get(key)
+	m.Tag(key, tags...)
+	return value, err
+}
+
+// InvalidateTag elimina los resultados de todas las claves con la etiqueta tag.
+// Retorna cuántos resultados se eliminaron.
+func (m *Memory[K, V]) InvalidateTag(tag string) int {
+	// Se copian las claves para no tomar el lock de un shard con m.mu tomado
+	m.mu.Lock()
+	keys := make([]K, 0, len(m.tags[tag]))
+	for key := range m.tags[tag] {
+		keys = append(keys, key)
+	}
+	delete(m.tags, tag)
+	m.mu.Unlock()
+
+	removed := 0
+	for _, key := range keys {
+		if m.Invalidate(key) {
+			removed++
+		}
+	}
+	return removed
+}
+
+// untag quita una clave de todas sus etiquetas.
+func (m *Memory[K, V]) untag(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for tag, keys := range m.tags {
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(m.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+// Package rediscache implementa un cache key-value en memoria al estilo Redis:
+// expiración por TTL, operaciones atómicas como SetNX y un pub/sub sencillo.
+// Es el cache de la lección 04_cache_redis, extraído para reutilizarlo en otras lecciones.
+package rediscache
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CacheItem representa un elemento en el cache con su valor y tiempo de expiración
+// Esta estructura encapsula el valor almacenado junto con metadatos básicos
+type CacheItem struct {
+	Value      any   // El valor que se almacena (puede ser cualquier tipo de dato)
+	Expiration int64 // Timestamp de cuando expira (0 significa que nunca expira)
+}
+
+// IsExpired verifica si el elemento del cache ha expirado
+// Retorna true si el elemento debe considerarse como eliminado
+func (item *CacheItem) IsExpired() bool {
+	if item.Expiration == 0 {
+		return false // Si es 0, nunca expira
+	}
+	return time.Now().UnixNano() > item.Expiration
+}
+
+// SimpleRedisCache implementa un cache básico en memoria similar a Redis
+// Usa un mapa simple para almacenar los datos y un mutex para thread-safety
+type SimpleRedisCache struct {
+	data  map[string]*CacheItem // Mapa que contiene todos los elementos del cache
+	mutex sync.RWMutex          // Mutex para permitir acceso concurrente seguro
+
+	subscribers map[string][]chan any // Canales suscritos a cada canal de pub/sub
+	subMutex    sync.Mutex            // Protege subscribers
+	quiet       atomic.Bool           // Si es true no se imprime cada operación
+}
+
+// NewSimpleRedisCache crea y retorna una nueva instancia del cache
+// Inicializa el mapa interno para almacenar los datos
+func NewSimpleRedisCache() *SimpleRedisCache {
+	return &SimpleRedisCache{
+		data:        make(map[string]*CacheItem),
+		subscribers: make(map[string][]chan any),
+	}
+}
+
+// SetLogging activa o desactiva los mensajes que imprime cada operación
+func (c *SimpleRedisCache) SetLogging(enabled bool) {
+	c.quiet.Store(!enabled)
+}
+
+// logf imprime un mensaje salvo que el logging esté desactivado
+func (c *SimpleRedisCache) logf(format string, args ...any) {
+	if !c.quiet.Load() {
+		fmt.Printf(format, args...)
+	}
+}
+
+// Set almacena un valor en el cache con una clave específica
+// Parámetros:
+//   - key: la clave para identificar el elemento
+//   - value: el valor a almacenar (puede ser cualquier tipo)
+//   - ttl: tiempo de vida del elemento (time.Duration, 0 = nunca expira)
+func (c *SimpleRedisCache) Set(key string, value any, ttl time.Duration) {
+	// Bloquear para escritura (exclusivo)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var expiration int64
+	if ttl > 0 {
+		// Calcular el timestamp de expiración
+		expiration = time.Now().Add(ttl).UnixNano()
+	}
+
+	// Crear el elemento y almacenarlo en el mapa
+	c.data[key] = &CacheItem{
+		Value:      value,
+		Expiration: expiration,
+	}
+
+	if ttl > 0 {
+		c.logf("✅ SET '%s' = '%v' (expira en %v)\n", key, value, ttl)
+	} else {
+		c.logf("✅ SET '%s' = '%v'\n", key, value)
+	}
+}
+
+// Get recupera un valor del cache usando su clave
+// Retorna:
+//   - any: el valor almacenado
+//   - bool: true si la clave existe y no ha expirado, false en caso contrario
+func (c *SimpleRedisCache) Get(key string) (any, bool) {
+	// Bloquear para lectura (permite múltiples lectores concurrentes)
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Buscar el elemento en el mapa
+	item, exists := c.data[key]
+	if !exists {
+		c.logf("❌ GET '%s' - Clave no encontrada\n", key)
+		return nil, false
+	}
+
+	// Verificar si el elemento ha expirado
+	if item.IsExpired() {
+		c.logf("⏰ GET '%s' - Clave expirada\n", key)
+		return nil, false
+	}
+
+	c.logf("✅ GET '%s' = '%v'\n", key, item.Value)
+	return item.Value, true
+}
+
+// Delete elimina un elemento del cache
+// Retorna true si el elemento existía y fue eliminado, false si no existía
+func (c *SimpleRedisCache) Delete(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verificar si la clave existe antes de eliminarla
+	if _, exists := c.data[key]; exists {
+		delete(c.data, key)
+		c.logf("🗑️ DELETE '%s' - Eliminado exitosamente\n", key)
+		return true
+	}
+
+	c.logf("❌ DELETE '%s' - Clave no encontrada\n", key)
+	return false
+}
+
+// DeleteIfEquals elimina key solo si existe, no ha expirado y guarda value.
+// Es el equivalente al script Lua "GET + DEL" que se usa en Redis para liberar un lock:
+// quien lo creó solo lo borra si sigue siendo suyo, y no el que otro tomó tras expirar.
+func (c *SimpleRedisCache) DeleteIfEquals(key string, value any) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	item, exists := c.data[key]
+	if !exists || item.IsExpired() || item.Value != value {
+		c.logf("🔒 DELETE '%s' - El valor ya no es '%v', no se elimina\n", key, value)
+		return false
+	}
+
+	delete(c.data, key)
+	c.logf("🗑️ DELETE '%s' = '%v' - Eliminado exitosamente\n", key, value)
+	return true
+}
+
+// Exists verifica si una clave existe en el cache y no ha expirado
+func (c *SimpleRedisCache) Exists(key string) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	item, exists := c.data[key]
+	if !exists || item.IsExpired() {
+		c.logf("❌ EXISTS '%s' - No existe o expiró\n", key)
+		return false
+	}
+
+	c.logf("✅ EXISTS '%s' - Existe\n", key)
+	return true
+}
+
+// Size retorna el número de elementos actualmente en el cache
+func (c *SimpleRedisCache) Size() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.data)
+}
+
+// SetNX ("SET if Not eXists") almacena el valor solo si la clave no existe o ya expiró.
+// Retorna true si lo almacenó. Como la comprobación y la escritura ocurren bajo el mismo
+// lock, sirve como lock distribuido: solo un cliente logra crear la clave.
+func (c *SimpleRedisCache) SetNX(key string, value any, ttl time.Duration) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if item, exists := c.data[key]; exists && !item.IsExpired() {
+		c.logf("🔒 SETNX '%s' - Ya existe\n", key)
+		return false
+	}
+
+	var expiration int64
+	if ttl > 0 {
+		expiration = time.Now().Add(ttl).UnixNano()
+	}
+	c.data[key] = &CacheItem{Value: value, Expiration: expiration}
+	c.logf("🔒 SETNX '%s' = '%v'\n", key, value)
+	return true
+}
+
+// Incr suma 1 al contador guardado en key y retorna el nuevo valor. Si la clave no
+// existe o expiró, la crea con valor 1 y expiración ttl (0 = nunca expira); los
+// incrementos siguientes no cambian la expiración, así que sirve para contar eventos
+// en una ventana de tiempo fija. Una clave con un valor que no es int64 se reinicia.
+func (c *SimpleRedisCache) Incr(key string, ttl time.Duration) int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	item, exists := c.data[key]
+	count, isCounter := int64(0), false
+	if exists && !item.IsExpired() {
+		count, isCounter = item.Value.(int64)
+	}
+	if !isCounter {
+		var expiration int64
+		if ttl > 0 {
+			expiration = time.Now().Add(ttl).UnixNano()
+		}
+		item = &CacheItem{Expiration: expiration}
+		c.data[key] = item
+	}
+	count++
+	item.Value = count
+	c.logf("➕ INCR '%s' = %d\n", key, count)
+	return count
+}
+
+// Publish envía message a todos los suscriptores de channel y retorna cuántos lo recibieron.
+// Igual que en Redis, los mensajes no se guardan: quien no está suscrito no los recibe.
+// Un suscriptor con el buffer lleno pierde el mensaje en lugar de bloquear al publicador.
+func (c *SimpleRedisCache) Publish(channel string, message any) int {
+	c.subMutex.Lock()
+	defer c.subMutex.Unlock()
+
+	delivered := 0
+	for _, subscriber := range c.subscribers[channel] {
+		select {
+		case subscriber <- message:
+			delivered++
+		default:
+		}
+	}
+	c.logf("📣 PUBLISH '%s' = '%v' (%d suscriptores)\n", channel, message, delivered)
+	return delivered
+}
+
+// Subscribe se suscribe a channel. Retorna el canal por el que llegan los mensajes y
+// una función para cancelar la suscripción (que cierra ese canal).
+func (c *SimpleRedisCache) Subscribe(channel string) (<-chan any, func()) {
+	messages := make(chan any, 16)
+
+	c.subMutex.Lock()
+	c.subscribers[channel] = append(c.subscribers[channel], messages)
+	c.subMutex.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			c.subMutex.Lock()
+			defer c.subMutex.Unlock()
+			remaining := make([]chan any, 0, len(c.subscribers[channel]))
+			for _, subscriber := range c.subscribers[channel] {
+				if subscriber != messages {
+					remaining = append(remaining, subscriber)
+				}
+			}
+			c.subscribers[channel] = remaining
+			close(messages)
+		})
+	}
+	return messages, unsubscribe
+}
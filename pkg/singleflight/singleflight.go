@@ -0,0 +1,148 @@
+// Package singleflight evita cálculos duplicados: si varias goroutines piden la misma
+// clave mientras se calcula, solo una ejecuta la función y todas reciben su resultado.
+//
+// Es la técnica de 03_cache_with_mutex extraída a un tipo genérico reutilizable,
+// parecido a golang.org/x/sync/singleflight pero con tipos y estadísticas.
+package singleflight
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError es el error que reciben todos los llamadores cuando fn hace panic.
+// Sin él, el panic tumbaría el proceso (fn corre en su propia goroutine) o dejaría
+// a los waiters esperando para siempre.
+type PanicError struct {
+	Value any    // Valor pasado a panic
+	Stack []byte // Stack trace de la goroutine que hizo panic
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("singleflight: la función hizo panic: %v", e.Value)
+}
+
+// Recover ejecuta fn y convierte un posible panic en un *PanicError.
+func Recover[V any](fn func() (V, error)) (v V, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = &PanicError{Value: recovered, Stack: debug.Stack()}
+		}
+	}()
+	return fn()
+}
+
+// call es un cálculo en curso (o recién terminado) para una clave.
+type call[V any] struct {
+	done chan struct{} // Se cierra cuando fn termina
+	val  V             // Resultado de fn
+	err  error         // Error de fn
+	dups int           // Cuántos llamadores adicionales pidieron este cálculo
+}
+
+// Stats resume cuántos cálculos se evitaron.
+type Stats struct {
+	Calls      int // Llamadas totales a Do/DoCtx
+	Executions int // Veces que realmente se ejecutó fn
+	Shared     int // Llamadas que recibieron el resultado de otra ejecución
+	Abandoned  int // Llamadas que dejaron de esperar porque su contexto terminó
+}
+
+// Result es el resultado de Do entregado por DoChan.
+type Result[V any] struct {
+	Val    V
+	Err    error
+	Shared bool // Si el resultado se entregó a más de un llamador
+}
+
+// Group agrupa cálculos por clave. El valor cero está listo para usarse.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex     // Protege calls y stats
+	calls map[K]*call[V] // Cálculos en curso por clave
+	stats Stats
+}
+
+// Do ejecuta fn para key, salvo que ya haya una ejecución en curso para esa clave:
+// en ese caso espera y retorna el mismo resultado. shared indica si el resultado
+// se entregó a más de un llamador.
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (v V, err error, shared bool) {
+	return g.DoCtx(context.Background(), key, fn)
+}
+
+// DoCtx es como Do, pero el llamador deja de esperar cuando ctx termina y recibe ctx.Err().
+// El cálculo no se cancela: sigue en su propia goroutine y los demás llamadores (y el
+// siguiente que llegue mientras tanto) reciben su resultado. Ningún canal queda sin leer,
+// así que abandonar la espera no bloquea ni filtra nada.
+func (g *Group[K, V]) DoCtx(ctx context.Context, key K, fn func() (V, error)) (v V, err error, shared bool) {
+	g.mu.Lock()
+	g.stats.Calls++
+	if g.calls == nil {
+		g.calls = make(map[K]*call[V])
+	}
+	c, inProgress := g.calls[key]
+	if inProgress {
+		c.dups++
+		g.stats.Shared++
+	} else {
+		c = &call[V]{done: make(chan struct{})}
+		g.calls[key] = c
+		g.stats.Executions++
+		go g.execute(key, c, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err, c.dups > 0
+	case <-ctx.Done():
+		g.mu.Lock()
+		g.stats.Abandoned++
+		g.mu.Unlock()
+		var zero V
+		return zero, ctx.Err(), inProgress
+	}
+}
+
+// DoChan es como Do, pero retorna un canal que recibirá el resultado, para poder
+// combinar la espera con timeouts u otros canales en un select.
+//
+// Dejar de leer el canal no cancela nada: el cálculo sigue y su resultado queda en el
+// buffer del canal (capacidad 1), así que ninguna goroutine se bloquea ni se filtra.
+// Para cancelar el trabajo en sí, fn debe observar su propio context.
+func (g *Group[K, V]) DoChan(key K, fn func() (V, error)) <-chan Result[V] {
+	ch := make(chan Result[V], 1)
+	go func() {
+		v, err, shared := g.Do(key, fn)
+		ch <- Result[V]{Val: v, Err: err, Shared: shared}
+	}()
+	return ch
+}
+
+// Forget hace que la próxima llamada para key ejecute fn de nuevo en lugar de esperar
+// el cálculo en curso. Quienes ya esperaban ese cálculo reciben su resultado igualmente.
+func (g *Group[K, V]) Forget(key K) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.calls, key)
+}
+
+// execute ejecuta fn, publica su resultado y libera a todos los que esperan.
+func (g *Group[K, V]) execute(key K, c *call[V], fn func() (V, error)) {
+	c.val, c.err = Recover(fn)
+
+	g.mu.Lock()
+	if g.calls[key] == c { // Tras un Forget la clave puede tener ya otro cálculo
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	close(c.done)
+}
+
+// Stats retorna una copia de las estadísticas acumuladas.
+func (g *Group[K, V]) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.stats
+}
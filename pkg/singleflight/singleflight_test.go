@@ -0,0 +1,185 @@
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blocking retorna una función que cuenta sus ejecuciones y espera a release.
+func blocking(executions *atomic.Int64, release <-chan struct{}, value string) func() (string, error) {
+	return func() (string, error) {
+		executions.Add(1)
+		<-release
+		return value, nil
+	}
+}
+
+// waitFor espera hasta que cond se cumpla o falla el test.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout esperando la condición")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDoSharesConcurrentCalls(t *testing.T) {
+	const callers = 20
+	var g Group[string, string]
+	var executions atomic.Int64
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	var sharedCount atomic.Int64
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err, shared := g.Do("k", blocking(&executions, release, "valor"))
+			if v != "valor" || err != nil {
+				t.Errorf("Do = (%q, %v), quiero (\"valor\", nil)", v, err)
+			}
+			if shared {
+				sharedCount.Add(1)
+			}
+		}()
+	}
+	waitFor(t, func() bool { return g.Stats().Calls == callers })
+	close(release)
+	wg.Wait()
+
+	if got := executions.Load(); got != 1 {
+		t.Errorf("ejecuciones = %d, quiero 1", got)
+	}
+	if got := sharedCount.Load(); got != callers {
+		t.Errorf("llamadas con shared = %d, quiero %d", got, callers)
+	}
+	want := Stats{Calls: callers, Executions: 1, Shared: callers - 1}
+	if got := g.Stats(); got != want {
+		t.Errorf("Stats = %+v, quiero %+v", got, want)
+	}
+}
+
+func TestDoSequentialCallsExecuteAgain(t *testing.T) {
+	var g Group[int, int]
+	var executions atomic.Int64
+	fn := func() (int, error) { return int(executions.Add(1)), nil }
+
+	for want := 1; want <= 3; want++ {
+		v, err, shared := g.Do(1, fn)
+		if v != want || err != nil || shared {
+			t.Errorf("Do #%d = (%d, %v, %t), quiero (%d, nil, false)", want, v, err, shared, want)
+		}
+	}
+}
+
+func TestDoCtxCancellation(t *testing.T) {
+	var g Group[string, string]
+	var executions atomic.Int64
+	release := make(chan struct{})
+
+	owner := g.DoChan("k", blocking(&executions, release, "valor"))
+	waitFor(t, func() bool { return executions.Load() == 1 })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	v, err, shared := g.DoCtx(ctx, "k", blocking(&executions, release, "otro"))
+	if !errors.Is(err, context.Canceled) || v != "" || !shared {
+		t.Errorf("DoCtx cancelado = (%q, %v, %t), quiero (\"\", context.Canceled, true)", v, err, shared)
+	}
+
+	// El cálculo sigue y el dueño recibe su resultado
+	close(release)
+	select {
+	case result := <-owner:
+		if result.Val != "valor" || result.Err != nil {
+			t.Errorf("resultado del dueño = %+v", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("el dueño no recibió el resultado")
+	}
+	if got := executions.Load(); got != 1 {
+		t.Errorf("ejecuciones = %d, quiero 1", got)
+	}
+	if got := g.Stats().Abandoned; got != 1 {
+		t.Errorf("Abandoned = %d, quiero 1", got)
+	}
+}
+
+func TestDoChan(t *testing.T) {
+	var g Group[string, int]
+	errFail := errors.New("falló")
+	select {
+	case result := <-g.DoChan("k", func() (int, error) { return 7, errFail }):
+		if result.Val != 7 || !errors.Is(result.Err, errFail) || result.Shared {
+			t.Errorf("DoChan = %+v", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("DoChan no entregó el resultado")
+	}
+}
+
+func TestForget(t *testing.T) {
+	var g Group[string, string]
+	var executions atomic.Int64
+	release := make(chan struct{})
+
+	first := g.DoChan("k", blocking(&executions, release, "primero"))
+	waitFor(t, func() bool { return executions.Load() == 1 })
+
+	g.Forget("k")
+	second := g.DoChan("k", blocking(&executions, release, "segundo"))
+	waitFor(t, func() bool { return executions.Load() == 2 })
+	close(release)
+
+	if r := <-first; r.Val != "primero" {
+		t.Errorf("primer cálculo = %q, quiero \"primero\"", r.Val)
+	}
+	if r := <-second; r.Val != "segundo" {
+		t.Errorf("segundo cálculo = %q, quiero \"segundo\"", r.Val)
+	}
+	if got := g.Stats(); got.Executions != 2 || got.Shared != 0 {
+		t.Errorf("Stats = %+v, quiero 2 ejecuciones sin compartir", got)
+	}
+}
+
+func TestPanicBecomesPanicErrorForEveryCaller(t *testing.T) {
+	const callers = 10
+	var g Group[string, int]
+	release := make(chan struct{})
+	fn := func() (int, error) {
+		<-release
+		panic("algo salió mal")
+	}
+
+	results := make([]<-chan Result[int], callers)
+	for n := range callers {
+		results[n] = g.DoChan("k", fn)
+	}
+	waitFor(t, func() bool { return g.Stats().Calls == callers })
+	close(release)
+
+	for n, ch := range results {
+		result := <-ch
+		var panicErr *PanicError
+		if !errors.As(result.Err, &panicErr) {
+			t.Fatalf("llamador %d: err = %v, quiero *PanicError", n, result.Err)
+		}
+		if panicErr.Value != "algo salió mal" || len(panicErr.Stack) == 0 {
+			t.Errorf("llamador %d: PanicError = {%v, %d bytes de stack}", n, panicErr.Value, len(panicErr.Stack))
+		}
+	}
+
+	// El panic no deja la clave bloqueada: la siguiente llamada ejecuta de nuevo
+	v, err, _ := g.Do("k", func() (int, error) { return 1, nil })
+	if v != 1 || err != nil {
+		t.Errorf("Do después del panic = (%d, %v), quiero (1, nil)", v, err)
+	}
+}
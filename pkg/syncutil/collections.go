@@ -0,0 +1,166 @@
+package syncutil
+
+import "sync"
+
+// SafeMap es un map seguro para uso concurrente, protegido por un RWMutex: las lecturas
+// pueden ocurrir a la vez y las escrituras son exclusivas. El valor cero está listo para usarse.
+type SafeMap[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// Get retorna el valor de key y si existía.
+func (s *SafeMap[K, V]) Get(key K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.m[key]
+	return value, ok
+}
+
+// Set guarda value en key, reemplazando el valor anterior.
+func (s *SafeMap[K, V]) Set(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = make(map[K]V)
+	}
+	s.m[key] = value
+}
+
+// GetOrSet retorna el valor de key si ya existía (y true); si no, guarda value y lo
+// retorna (y false). La comprobación y la escritura son una sola operación atómica.
+func (s *SafeMap[K, V]) GetOrSet(key K, value V) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.m[key]; ok {
+		return existing, true
+	}
+	if s.m == nil {
+		s.m = make(map[K]V)
+	}
+	s.m[key] = value
+	return value, false
+}
+
+// Delete elimina key y retorna si existía.
+func (s *SafeMap[K, V]) Delete(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.m[key]
+	delete(s.m, key)
+	return ok
+}
+
+// Len retorna la cantidad de claves.
+func (s *SafeMap[K, V]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.m)
+}
+
+// Keys retorna una copia de las claves, en orden indefinido.
+func (s *SafeMap[K, V]) Keys() []K {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]K, 0, len(s.m))
+	for key := range s.m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Range llama a fn con cada par clave-valor hasta que fn retorne false.
+// fn corre con el lock de lectura tomado, así que no debe modificar el SafeMap.
+func (s *SafeMap[K, V]) Range(fn func(key K, value V) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for key, value := range s.m {
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+// SafeSet es un conjunto seguro para uso concurrente. El valor cero está listo para usarse.
+type SafeSet[T comparable] struct {
+	m SafeMap[T, struct{}]
+}
+
+// Add agrega value y retorna true si no estaba en el conjunto.
+func (s *SafeSet[T]) Add(value T) bool {
+	_, existed := s.m.GetOrSet(value, struct{}{})
+	return !existed
+}
+
+// Remove quita value y retorna si estaba en el conjunto.
+func (s *SafeSet[T]) Remove(value T) bool {
+	return s.m.Delete(value)
+}
+
+// Contains indica si value está en el conjunto.
+func (s *SafeSet[T]) Contains(value T) bool {
+	_, ok := s.m.Get(value)
+	return ok
+}
+
+// Len retorna la cantidad de elementos.
+func (s *SafeSet[T]) Len() int {
+	return s.m.Len()
+}
+
+// Values retorna una copia de los elementos, en orden indefinido.
+func (s *SafeSet[T]) Values() []T {
+	return s.m.Keys()
+}
+
+// SafeCounter cuenta ocurrencias por clave de forma segura para uso concurrente.
+// El valor cero está listo para usarse.
+type SafeCounter[K comparable] struct {
+	mu     sync.RWMutex
+	counts map[K]int
+}
+
+// Inc suma uno a key y retorna el nuevo valor.
+func (c *SafeCounter[K]) Inc(key K) int {
+	return c.Add(key, 1)
+}
+
+// Add suma n a key y retorna el nuevo valor.
+func (c *SafeCounter[K]) Add(key K, n int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[K]int)
+	}
+	c.counts[key] += n
+	return c.counts[key]
+}
+
+// Get retorna la cuenta de key (0 si nunca se contó).
+func (c *SafeCounter[K]) Get(key K) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.counts[key]
+}
+
+// Total retorna la suma de todas las cuentas.
+func (c *SafeCounter[K]) Total() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	total := 0
+	for _, count := range c.counts {
+		total += count
+	}
+	return total
+}
+
+// Snapshot retorna una copia de todas las cuentas.
+func (c *SafeCounter[K]) Snapshot() map[K]int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	snapshot := make(map[K]int, len(c.counts))
+	for key, count := range c.counts {
+		snapshot[key] = count
+	}
+	return snapshot
+}
@@ -0,0 +1,122 @@
+package syncutil
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSafeMap(t *testing.T) {
+	var m SafeMap[string, int]
+	if _, ok := m.Get("a"); ok {
+		t.Fatal("Get en un SafeMap vacío encontró la clave")
+	}
+	m.Set("a", 1)
+	m.Set("b", 2)
+	if v, ok := m.Get("a"); v != 1 || !ok {
+		t.Errorf("Get(a) = (%d, %t), quiero (1, true)", v, ok)
+	}
+	if v, existed := m.GetOrSet("a", 10); v != 1 || !existed {
+		t.Errorf("GetOrSet(a) = (%d, %t), quiero (1, true)", v, existed)
+	}
+	if v, existed := m.GetOrSet("c", 3); v != 3 || existed {
+		t.Errorf("GetOrSet(c) = (%d, %t), quiero (3, false)", v, existed)
+	}
+	if keys := m.Keys(); !slices.Equal(slices.Sorted(slices.Values(keys)), []string{"a", "b", "c"}) {
+		t.Errorf("Keys = %v", keys)
+	}
+	if !m.Delete("b") || m.Delete("b") {
+		t.Error("Delete(b) debe retornar true la primera vez y false la segunda")
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len = %d, quiero 2", m.Len())
+	}
+
+	visited := 0
+	m.Range(func(string, int) bool {
+		visited++
+		return false // Se detiene en el primero
+	})
+	if visited != 1 {
+		t.Errorf("Range visitó %d claves después de retornar false, quiero 1", visited)
+	}
+}
+
+func TestSafeMapGetOrSetConcurrent(t *testing.T) {
+	const goroutines = 50
+	var m SafeMap[string, int]
+	var wg sync.WaitGroup
+	winners := make(chan int, goroutines)
+	for n := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, existed := m.GetOrSet("clave", n); !existed {
+				winners <- n
+			}
+		}()
+	}
+	wg.Wait()
+	close(winners)
+
+	var won []int
+	for n := range winners {
+		won = append(won, n)
+	}
+	if len(won) != 1 {
+		t.Fatalf("%d goroutines guardaron su valor, quiero 1", len(won))
+	}
+	if v, _ := m.Get("clave"); v != won[0] {
+		t.Errorf("valor = %d, quiero el de la ganadora (%d)", v, won[0])
+	}
+}
+
+func TestSafeSet(t *testing.T) {
+	var s SafeSet[int]
+	if !s.Add(1) || s.Add(1) || !s.Add(2) {
+		t.Error("Add debe retornar true solo para elementos nuevos")
+	}
+	if !s.Contains(1) || s.Contains(3) {
+		t.Error("Contains no refleja los elementos agregados")
+	}
+	if !s.Remove(1) || s.Remove(1) {
+		t.Error("Remove debe retornar true solo si el elemento estaba")
+	}
+	if got := s.Values(); !slices.Equal(got, []int{2}) || s.Len() != 1 {
+		t.Errorf("Values = %v, Len = %d, quiero [2] y 1", got, s.Len())
+	}
+}
+
+func TestSafeCounterConcurrent(t *testing.T) {
+	const goroutines, increments = 20, 500
+	var c SafeCounter[string]
+	var wg sync.WaitGroup
+	for n := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := "par"
+			if n%2 == 1 {
+				key = "impar"
+			}
+			for range increments {
+				c.Inc(key)
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := map[string]int{"par": goroutines / 2 * increments, "impar": goroutines / 2 * increments}
+	snapshot := c.Snapshot()
+	for key, count := range want {
+		if c.Get(key) != count || snapshot[key] != count {
+			t.Errorf("cuenta de %s = %d (snapshot %d), quiero %d", key, c.Get(key), snapshot[key], count)
+		}
+	}
+	if c.Total() != goroutines*increments {
+		t.Errorf("Total = %d, quiero %d", c.Total(), goroutines*increments)
+	}
+	if got := c.Add("par", -10); got != want["par"]-10 {
+		t.Errorf("Add(-10) = %d, quiero %d", got, want["par"]-10)
+	}
+}
@@ -0,0 +1,62 @@
+package syncutil
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Group lanza varias tareas relacionadas y espera a todas, al estilo de
+// golang.org/x/sync/errgroup: el primer error cancela el contexto compartido para que
+// las demás tareas abandonen su trabajo, y Wait retorna los errores de todas.
+type Group struct {
+	wg     sync.WaitGroup
+	cancel context.CancelCauseFunc
+	mu     sync.Mutex
+	errs   []error
+}
+
+// WithContext crea un Group y el contexto que reciben sus tareas. El contexto se
+// cancela con el primer error o cuando Wait retorna.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go ejecuta task en una goroutine nueva.
+func (g *Group) Go(task func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := task(); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+// fail registra err y, si es el primero, cancela el contexto del grupo.
+// Los context.Canceled de las tareas que abandonaron por esa cancelación no se registran:
+// son una consecuencia del primer error, no errores nuevos.
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.errs) > 0 && errors.Is(err, context.Canceled) {
+		return
+	}
+	g.errs = append(g.errs, err)
+	if len(g.errs) == 1 && g.cancel != nil {
+		g.cancel(err)
+	}
+}
+
+// Wait espera a que terminen todas las tareas y retorna sus errores unidos con
+// errors.Join, con el primero en aparecer al principio. Retorna nil si ninguna falló.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(nil)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
@@ -0,0 +1,68 @@
+package syncutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGroupNoErrors(t *testing.T) {
+	g, ctx := WithContext(context.Background())
+	results := make([]int, 5)
+	for n := range results {
+		g.Go(func() error {
+			results[n] = n * n
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait = %v, quiero nil", err)
+	}
+	if results[4] != 16 {
+		t.Errorf("results = %v", results)
+	}
+	// Wait cancela el contexto al terminar
+	if ctx.Err() == nil {
+		t.Error("el contexto sigue activo después de Wait")
+	}
+}
+
+func TestGroupFirstErrorCancelsOthers(t *testing.T) {
+	errFirst := errors.New("primero")
+	errSecond := errors.New("segundo")
+	g, ctx := WithContext(context.Background())
+
+	g.Go(func() error { return errFirst })
+	g.Go(func() error {
+		<-ctx.Done() // Abandona por la cancelación: su context.Canceled no se registra
+		return ctx.Err()
+	})
+	g.Go(func() error {
+		<-ctx.Done()
+		return errSecond // Un error propio sí se registra
+	})
+
+	err := g.Wait()
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Errorf("Wait = %v, quiero los dos errores", err)
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Errorf("Wait = %v, no debe incluir context.Canceled", err)
+	}
+	if cause := context.Cause(ctx); !errors.Is(cause, errFirst) {
+		t.Errorf("causa de la cancelación = %v, quiero %v", cause, errFirst)
+	}
+}
+
+func TestGroupZeroValue(t *testing.T) {
+	var g Group
+	errFail := errors.New("falló")
+	g.Go(func() error {
+		time.Sleep(time.Millisecond)
+		return errFail
+	})
+	if err := g.Wait(); !errors.Is(err, errFail) {
+		t.Errorf("Wait = %v, quiero %v", err, errFail)
+	}
+}
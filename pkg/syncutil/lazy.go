@@ -0,0 +1,82 @@
+package syncutil
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// Lazy crea un valor costoso (una conexión, un cliente, un índice) la primera vez que
+// se pide y lo comparte con todas las llamadas siguientes. Aunque muchas goroutines
+// llamen a Get a la vez, init se ejecuta una sola vez y las demás esperan su resultado.
+//
+// A diferencia de sync.Once (y sync.OnceValues), un fallo no queda guardado: las
+// goroutines que esperaban ese intento reciben su error, y la siguiente llamada a Get
+// vuelve a intentarlo.
+type Lazy[T any] struct {
+	done  atomic.Bool // Camino rápido: true cuando value ya existe
+	mu    sync.Mutex
+	init  func() (T, error)
+	value T
+	call  *lazyCall[T] // Intento en curso, compartido por quienes llegan mientras tanto
+}
+
+// errLazyPanicked es el error que reciben los que esperaban un intento que hizo panic.
+var errLazyPanicked = errors.New("syncutil: la inicialización de Lazy hizo panic")
+
+// lazyCall es un intento de inicialización en curso.
+type lazyCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// NewLazy crea un Lazy que calculará su valor con init en la primera llamada a Get.
+func NewLazy[T any](init func() (T, error)) *Lazy[T] {
+	return &Lazy[T]{init: init}
+}
+
+// Get retorna el valor, creándolo si todavía no existe. Si ya hay un intento en curso,
+// espera su resultado en lugar de lanzar otro.
+func (l *Lazy[T]) Get() (T, error) {
+	if l.done.Load() {
+		return l.value, nil
+	}
+
+	l.mu.Lock()
+	if l.done.Load() { // Otra goroutine lo creó mientras se esperaba el lock
+		l.mu.Unlock()
+		return l.value, nil
+	}
+	if c := l.call; c != nil {
+		l.mu.Unlock()
+		<-c.done
+		return c.value, c.err
+	}
+	c := &lazyCall[T]{done: make(chan struct{})}
+	l.call = c
+	l.mu.Unlock()
+
+	finished := false
+	defer func() {
+		if !finished { // init hizo panic: el panic sigue su curso, pero los demás no se quedan esperando
+			c.err = errLazyPanicked
+		}
+		l.mu.Lock()
+		if c.err == nil {
+			l.value = c.value
+			l.done.Store(true)
+		}
+		l.call = nil // Si falló, el próximo Get lanza un intento nuevo
+		l.mu.Unlock()
+		close(c.done)
+	}()
+	c.value, c.err = l.init()
+	finished = true
+	return c.value, c.err
+}
+
+// Initialized indica si el valor ya se creó con éxito, sin provocar la inicialización.
+func (l *Lazy[T]) Initialized() bool {
+	return l.done.Load()
+}
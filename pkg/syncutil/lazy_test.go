@@ -0,0 +1,129 @@
+package syncutil
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLazyInitializesOnce(t *testing.T) {
+	const goroutines = 50
+	var calls atomic.Int64
+	lazy := NewLazy(func() (string, error) {
+		calls.Add(1)
+		time.Sleep(5 * time.Millisecond)
+		return "conexión", nil
+	})
+	if lazy.Initialized() {
+		t.Fatal("Initialized antes del primer Get")
+	}
+
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := lazy.Get(); v != "conexión" || err != nil {
+				t.Errorf("Get = (%q, %v)", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("init se ejecutó %d veces, quiero 1", got)
+	}
+	if !lazy.Initialized() {
+		t.Error("Initialized = false después de un Get exitoso")
+	}
+}
+
+func TestLazyRetriesAfterFailure(t *testing.T) {
+	errDown := errors.New("servidor caído")
+	var calls atomic.Int64
+	lazy := NewLazy(func() (int, error) {
+		if calls.Add(1) < 3 {
+			return 0, errDown
+		}
+		return 42, nil
+	})
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		if _, err := lazy.Get(); !errors.Is(err, errDown) {
+			t.Fatalf("intento %d: err = %v, quiero %v", attempt, err, errDown)
+		}
+		if lazy.Initialized() {
+			t.Fatalf("intento %d: un fallo no debe dejar el valor inicializado", attempt)
+		}
+	}
+	if v, err := lazy.Get(); v != 42 || err != nil {
+		t.Fatalf("tercer intento = (%d, %v), quiero (42, nil)", v, err)
+	}
+	// Una vez creado, no se vuelve a llamar a init
+	_, _ = lazy.Get()
+	if got := calls.Load(); got != 3 {
+		t.Errorf("init se ejecutó %d veces, quiero 3", got)
+	}
+}
+
+func TestLazyWaitersShareFailure(t *testing.T) {
+	const goroutines = 10
+	errDown := errors.New("servidor caído")
+	release := make(chan struct{})
+	var calls atomic.Int64
+	lazy := NewLazy(func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 0, errDown
+	})
+
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := lazy.Get(); !errors.Is(err, errDown) {
+				t.Errorf("Get = %v, quiero %v", err, errDown)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond) // Que todas lleguen mientras el intento sigue en curso
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("init se ejecutó %d veces para un solo intento, quiero 1", got)
+	}
+}
+
+func TestLazyPanicReleasesWaiters(t *testing.T) {
+	release := make(chan struct{})
+	lazy := NewLazy(func() (int, error) {
+		<-release
+		panic("init roto")
+	})
+
+	waiter := make(chan error, 1)
+	go func() {
+		defer func() { _ = recover() }() // La goroutine que inicializa recibe el panic
+		_, _ = lazy.Get()
+	}()
+	time.Sleep(10 * time.Millisecond)
+	go func() {
+		_, err := lazy.Get()
+		waiter <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	select {
+	case err := <-waiter:
+		if !errors.Is(err, errLazyPanicked) {
+			t.Errorf("el que esperaba recibió %v, quiero errLazyPanicked", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("el que esperaba quedó bloqueado después del panic")
+	}
+}
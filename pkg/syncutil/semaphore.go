@@ -0,0 +1,87 @@
+// Package syncutil reúne utilidades de concurrencia que se repiten en los ejemplos
+// del curso, empezando por las de 01_sync.
+package syncutil
+
+import (
+	"context"
+	"fmt"
+)
+
+// Semaphore limita cuántas unidades de un recurso se usan a la vez. Cada Acquire
+// pide un peso (por ejemplo, una consulta pesada puede ocupar dos conexiones) y
+// Release lo devuelve.
+//
+// Internamente es un canal con buffer: cada unidad ocupada es un elemento en el canal.
+// Un segundo canal de capacidad 1 hace de turno, para que dos Acquire con peso no
+// se queden cada uno con una parte de las unidades y se bloqueen mutuamente.
+type Semaphore struct {
+	tokens chan struct{}
+	turn   chan struct{}
+}
+
+// NewSemaphore crea un semáforo con size unidades disponibles.
+func NewSemaphore(size int) *Semaphore {
+	return &Semaphore{
+		tokens: make(chan struct{}, size),
+		turn:   make(chan struct{}, 1),
+	}
+}
+
+// Acquire ocupa n unidades, esperando hasta que estén libres o hasta que ctx se cancele.
+// Si ctx se cancela a mitad de camino, devuelve las unidades que alcanzó a ocupar.
+func (s *Semaphore) Acquire(ctx context.Context, n int) error {
+	if n > cap(s.tokens) {
+		return fmt.Errorf("syncutil: peso %d mayor que la capacidad %d del semáforo", n, cap(s.tokens))
+	}
+	select {
+	case s.turn <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.turn }()
+
+	for acquired := range n {
+		select {
+		case s.tokens <- struct{}{}:
+		case <-ctx.Done():
+			s.Release(acquired)
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// TryAcquire ocupa n unidades solo si están libres en este momento, sin esperar.
+func (s *Semaphore) TryAcquire(n int) bool {
+	select {
+	case s.turn <- struct{}{}:
+	default:
+		return false // Otro Acquire está esperando su turno
+	}
+	defer func() { <-s.turn }()
+
+	if cap(s.tokens)-len(s.tokens) < n {
+		return false
+	}
+	// Con el turno tomado nadie más puede ocupar unidades, así que estos envíos no bloquean
+	for range n {
+		s.tokens <- struct{}{}
+	}
+	return true
+}
+
+// Release devuelve n unidades. Devolver más de las ocupadas es un error de programación.
+func (s *Semaphore) Release(n int) {
+	for range n {
+		select {
+		case <-s.tokens:
+		default:
+			panic("syncutil: Release de más unidades de las ocupadas")
+		}
+	}
+}
+
+// InUse retorna cuántas unidades están ocupadas en este momento.
+func (s *Semaphore) InUse() int {
+	return len(s.tokens)
+}
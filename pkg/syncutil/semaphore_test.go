@@ -0,0 +1,91 @@
+package syncutil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSemaphoreLimitsConcurrency(t *testing.T) {
+	const size, workers = 3, 20
+	sem := NewSemaphore(size)
+	var running, peak atomic.Int64
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sem.Acquire(context.Background(), 1); err != nil {
+				t.Errorf("Acquire: %v", err)
+				return
+			}
+			defer sem.Release(1)
+			now := running.Add(1)
+			for {
+				old := peak.Load()
+				if now <= old || peak.CompareAndSwap(old, now) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > size {
+		t.Errorf("hubo %d tareas a la vez, el límite es %d", got, size)
+	}
+	if sem.InUse() != 0 {
+		t.Errorf("InUse = %d al terminar, quiero 0", sem.InUse())
+	}
+}
+
+func TestSemaphoreWeights(t *testing.T) {
+	sem := NewSemaphore(3)
+	if err := sem.Acquire(context.Background(), 4); err == nil {
+		t.Error("Acquire con peso mayor que la capacidad debe fallar")
+	}
+	if !sem.TryAcquire(2) {
+		t.Fatal("TryAcquire(2) con 3 libres debe funcionar")
+	}
+	if sem.TryAcquire(2) {
+		t.Error("TryAcquire(2) con 1 libre no debe funcionar")
+	}
+	if sem.InUse() != 2 {
+		t.Errorf("InUse = %d, quiero 2", sem.InUse())
+	}
+	sem.Release(2)
+	if sem.InUse() != 0 {
+		t.Errorf("InUse = %d después de Release, quiero 0", sem.InUse())
+	}
+}
+
+func TestSemaphoreAcquireCanceled(t *testing.T) {
+	sem := NewSemaphore(2)
+	if err := sem.Acquire(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := sem.Acquire(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Acquire = %v, quiero context.DeadlineExceeded", err)
+	}
+	// La unidad que alcanzó a ocupar se devolvió
+	if sem.InUse() != 1 {
+		t.Errorf("InUse = %d después de cancelar, quiero 1", sem.InUse())
+	}
+}
+
+func TestSemaphoreReleaseTooMuchPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Release de más unidades de las ocupadas debe hacer panic")
+		}
+	}()
+	NewSemaphore(1).Release(1)
+}
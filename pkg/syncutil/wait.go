@@ -0,0 +1,28 @@
+package syncutil
+
+import (
+	"sync"
+	"time"
+)
+
+// WaitTimeout espera a que wg llegue a cero, pero como máximo d. Retorna true si todas
+// las goroutines terminaron a tiempo y false si venció el plazo.
+//
+// sync.WaitGroup no se puede cancelar, así que la espera ocurre en una goroutine
+// auxiliar: si vence el plazo, esa goroutine sigue esperando hasta que wg llegue a cero.
+func WaitTimeout(wg *sync.WaitGroup, d time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
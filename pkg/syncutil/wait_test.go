@@ -0,0 +1,76 @@
+package syncutil
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWaitTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		work    time.Duration // Lo que tarda cada goroutine
+		timeout time.Duration
+		want    bool
+	}{
+		{"terminan a tiempo", 5 * time.Millisecond, time.Second, true},
+		{"vence el plazo", 200 * time.Millisecond, 20 * time.Millisecond, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			for range 3 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					time.Sleep(tt.work)
+				}()
+			}
+
+			start := time.Now()
+			if got := WaitTimeout(&wg, tt.timeout); got != tt.want {
+				t.Errorf("WaitTimeout = %t, quiero %t", got, tt.want)
+			}
+			if elapsed := time.Since(start); elapsed >= min(tt.work, tt.timeout)+150*time.Millisecond {
+				t.Errorf("WaitTimeout tardó %v, debería retornar tras %v", elapsed, min(tt.work, tt.timeout))
+			}
+			wg.Wait() // No dejar goroutines vivas entre casos
+		})
+	}
+}
+
+func TestWaitTimeoutEmptyGroup(t *testing.T) {
+	var wg sync.WaitGroup
+	if !WaitTimeout(&wg, time.Millisecond) {
+		t.Error("WaitTimeout con un WaitGroup en cero = false, quiero true")
+	}
+}
+
+// TestWaitTimeoutDoesNotLeak comprueba que la goroutine auxiliar de WaitTimeout no
+// queda viva para siempre cuando vence el plazo: termina junto con las que esperaba.
+func TestWaitTimeoutDoesNotLeak(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-release
+		}()
+	}
+
+	if WaitTimeout(&wg, 10*time.Millisecond) {
+		t.Fatal("WaitTimeout = true con las goroutines bloqueadas, quiero false")
+	}
+	close(release)
+	wg.Wait()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			t.Fatalf("quedaron %d goroutines, quiero %d: la auxiliar de WaitTimeout no terminó", runtime.NumGoroutine(), baseline)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}